@@ -1,25 +1,140 @@
 package apperror
 
 import (
+	"encoding/json"
 	"errors"
 	"fmt"
+	"net/http"
+	"reflect"
+	"runtime"
+	"sort"
 	"strings"
+
+	"github.com/google/uuid"
+	"github.com/zhongjie-cai/app-error/internal/errortext"
 )
 
 // func pointers for injection / testing: apperror.go
 var (
-	fmtSprint              = fmt.Sprint
-	fmtSprintf             = fmt.Sprintf
-	fmtErrorf              = fmt.Errorf
-	stringsJoin            = strings.Join
-	formatExtraDataFunc    = formatExtraData
-	printBaseAppErrorFunc  = printBaseAppError
-	getErrorMessageFunc    = getErrorMessage
-	printInnerErrorsFunc   = printInnerErrors
-	errorsIs               = errors.Is
-	equalsErrorFunc        = equalsError
-	appErrorContainsFunc   = appErrorContains
-	innerErrorContainsFunc = innerErrorContains
-	cleanupInnerErrorsFunc = cleanupInnerErrors
-	newBaseAppErrorFunc    = NewBaseAppError
+	fmtSprint                = fmt.Sprint
+	fmtSprintf               = fmt.Sprintf
+	fmtErrorf                = fmt.Errorf
+	stringsJoin              = strings.Join
+	getErrorMessageFunc      = getErrorMessage
+	printInnerErrorsFunc     = printInnerErrors
+	errorsIs                 = errors.Is
+	equalsErrorFunc          = equalsError
+	appErrorContainsFunc     = appErrorContains
+	innerErrorContainsFunc   = innerErrorContains
+	cleanupInnerErrorsFunc   = cleanupInnerErrors
+	newBaseAppErrorFunc      = NewBaseAppError
+	stringsContainsRune      = strings.ContainsRune
+	setMessageFunc           = setMessage
+	formatExtraDataValueFunc = formatExtraDataValue
+)
+
+// func pointers for injection / testing: render.go
+var (
+	jsonMarshal            = json.Marshal
+	httpStatusText         = http.StatusText
+	getViolationFunc       = getViolation
+	collectViolationsFunc  = collectViolations
+	marshalInnerErrorsFunc = marshalInnerErrors
+	newProblemFunc         = NewProblem
+)
+
+// func pointers for injection / testing: collector.go
+var (
+	appendFieldNameFunc = appendFieldName
+)
+
+// func pointers for injection / testing: violation.go
+var (
+	trimNamespaceFunc          = trimNamespace
+	joinFieldPathFunc          = joinFieldPath
+	convertFieldViolationsFunc = convertFieldViolations
+)
+
+// func pointers for injection / testing: format.go
+var (
+	stringsSplit           = strings.Split
+	sortStrings            = sort.Strings
+	indentLinesFunc        = indentLines
+	formatTreeFunc         = formatTree
+	formatInnerErrorFunc   = formatInnerError
+	formatCodeGoSyntaxFunc = formatCodeGoSyntax
+	formatGoSyntaxFunc     = formatGoSyntax
+)
+
+// func pointers for injection / testing: attr.go
+var (
+	findAttrFunc   = findAttr
+	removeAttrFunc = removeAttr
+)
+
+// func pointers for injection / testing: immutable.go
+var (
+	cloneBaseAppErrorFunc = cloneBaseAppError
+	newSentinelFunc       = newSentinel
+	getCustomSentinelFunc = getCustomSentinel
+	innerErrorIsFunc      = innerErrorIs
+)
+
+// func pointers for injection / testing: parse.go
+var (
+	errortextParse  = errortext.Parse
+	errorsNew       = errors.New
+	parseCodeFunc   = parseCode
+	convertNodeFunc = convertNode
+)
+
+// func pointers for injection / testing: printmode.go
+var (
+	errortextEscape = errortext.Escape
+)
+
+// func pointers for injection / testing: nil.go
+var (
+	reflectValueOf = reflect.ValueOf
+	isNilFunc      = IsNil
+)
+
+// func pointers for injection / testing: metrics.go
+var (
+	emitMetricsEventFunc = emitMetricsEvent
+	getCodeEnumFunc      = getCodeEnum
+)
+
+// func pointers for injection / testing: observer.go
+var (
+	callObserverFunc    = callObserver
+	notifyObserversFunc = notifyObservers
+)
+
+// func pointers for injection / testing: instance.go
+var (
+	uuidNewV7         = uuid.NewV7
+	newUUIDv7Func     = newUUIDv7
+	newInstanceIDFunc = newInstanceID
+)
+
+// func pointers for injection / testing: fault.go
+var (
+	errorsAs               = errors.As
+	getInnerErrorsFunc     = getInnerErrors
+	hasDependencyFaultFunc = hasDependencyFault
+)
+
+// func pointers for injection / testing: component.go
+var (
+	fromComponentFunc        = FromComponent
+	findComponentFunc        = findComponent
+	responsibleComponentFunc = ResponsibleComponent
+)
+
+// func pointers for injection / testing: stack.go
+var (
+	runtimeCallers       = runtime.Callers
+	runtimeCallersFrames = runtime.CallersFrames
+	formatStackFunc      = formatStack
 )
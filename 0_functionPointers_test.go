@@ -1,43 +1,144 @@
 package apperror
 
 import (
+	"encoding/json"
 	"errors"
 	"fmt"
+	"net/http"
+	"reflect"
+	"runtime"
+	"sort"
 	"strings"
 	"testing"
 
+	"github.com/google/uuid"
 	"github.com/stretchr/testify/assert"
+	"github.com/zhongjie-cai/app-error/internal/errortext"
 )
 
 var (
-	fmtSprintExpected              int
-	fmtSprintCalled                int
-	fmtSprintfExpected             int
-	fmtSprintfCalled               int
-	fmtErrorfExpected              int
-	fmtErrorfCalled                int
-	stringsJoinExpected            int
-	stringsJoinCalled              int
-	formatExtraDataFuncExpected    int
-	formatExtraDataFuncCalled      int
-	printBaseAppErrorFuncExpected  int
-	printBaseAppErrorFuncCalled    int
-	getErrorMessageFuncExpected    int
-	getErrorMessageFuncCalled      int
-	printInnerErrorsFuncExpected   int
-	printInnerErrorsFuncCalled     int
-	errorsIsExpected               int
-	errorsIsCalled                 int
-	equalsErrorFuncExpected        int
-	equalsErrorFuncCalled          int
-	appErrorContainsFuncExpected   int
-	appErrorContainsFuncCalled     int
-	innerErrorContainsFuncExpected int
-	innerErrorContainsFuncCalled   int
-	cleanupInnerErrorsFuncExpected int
-	cleanupInnerErrorsFuncCalled   int
-	newBaseAppErrorFuncExpected    int
-	newBaseAppErrorFuncCalled      int
+	fmtSprintExpected                  int
+	fmtSprintCalled                    int
+	fmtSprintfExpected                 int
+	fmtSprintfCalled                   int
+	fmtErrorfExpected                  int
+	fmtErrorfCalled                    int
+	stringsJoinExpected                int
+	stringsJoinCalled                  int
+	getErrorMessageFuncExpected        int
+	getErrorMessageFuncCalled          int
+	printInnerErrorsFuncExpected       int
+	printInnerErrorsFuncCalled         int
+	errorsIsExpected                   int
+	errorsIsCalled                     int
+	equalsErrorFuncExpected            int
+	equalsErrorFuncCalled              int
+	appErrorContainsFuncExpected       int
+	appErrorContainsFuncCalled         int
+	innerErrorContainsFuncExpected     int
+	innerErrorContainsFuncCalled       int
+	cleanupInnerErrorsFuncExpected     int
+	cleanupInnerErrorsFuncCalled       int
+	newBaseAppErrorFuncExpected        int
+	newBaseAppErrorFuncCalled          int
+	jsonMarshalExpected                int
+	jsonMarshalCalled                  int
+	httpStatusTextExpected             int
+	httpStatusTextCalled               int
+	getViolationFuncExpected           int
+	getViolationFuncCalled             int
+	collectViolationsFuncExpected      int
+	collectViolationsFuncCalled        int
+	marshalInnerErrorsFuncExpected     int
+	marshalInnerErrorsFuncCalled       int
+	newProblemFuncExpected             int
+	newProblemFuncCalled               int
+	appendFieldNameFuncExpected        int
+	appendFieldNameFuncCalled          int
+	trimNamespaceFuncExpected          int
+	trimNamespaceFuncCalled            int
+	joinFieldPathFuncExpected          int
+	joinFieldPathFuncCalled            int
+	convertFieldViolationsFuncExpected int
+	convertFieldViolationsFuncCalled   int
+	stringsSplitExpected               int
+	stringsSplitCalled                 int
+	sortStringsExpected                int
+	sortStringsCalled                  int
+	indentLinesFuncExpected            int
+	indentLinesFuncCalled              int
+	formatTreeFuncExpected             int
+	formatTreeFuncCalled               int
+	formatInnerErrorFuncExpected       int
+	formatInnerErrorFuncCalled         int
+	formatCodeGoSyntaxFuncExpected     int
+	formatCodeGoSyntaxFuncCalled       int
+	formatGoSyntaxFuncExpected         int
+	formatGoSyntaxFuncCalled           int
+	findAttrFuncExpected               int
+	findAttrFuncCalled                 int
+	removeAttrFuncExpected             int
+	removeAttrFuncCalled               int
+	cloneBaseAppErrorFuncExpected      int
+	cloneBaseAppErrorFuncCalled        int
+	newSentinelFuncExpected            int
+	newSentinelFuncCalled              int
+	getCustomSentinelFuncExpected      int
+	getCustomSentinelFuncCalled        int
+	innerErrorIsFuncExpected           int
+	innerErrorIsFuncCalled             int
+	errortextParseExpected             int
+	errortextParseCalled               int
+	errorsNewExpected                  int
+	errorsNewCalled                    int
+	parseCodeFuncExpected              int
+	parseCodeFuncCalled                int
+	convertNodeFuncExpected            int
+	convertNodeFuncCalled              int
+	errortextEscapeExpected            int
+	errortextEscapeCalled              int
+	reflectValueOfExpected             int
+	reflectValueOfCalled               int
+	isNilFuncExpected                  int
+	isNilFuncCalled                    int
+	emitMetricsEventFuncExpected       int
+	emitMetricsEventFuncCalled         int
+	getCodeEnumFuncExpected            int
+	getCodeEnumFuncCalled              int
+	callObserverFuncExpected           int
+	callObserverFuncCalled             int
+	notifyObserversFuncExpected        int
+	notifyObserversFuncCalled          int
+	uuidNewV7Expected                  int
+	uuidNewV7Called                    int
+	newUUIDv7FuncExpected              int
+	newUUIDv7FuncCalled                int
+	newInstanceIDFuncExpected          int
+	newInstanceIDFuncCalled            int
+	errorsAsExpected                   int
+	errorsAsCalled                     int
+	getInnerErrorsFuncExpected         int
+	getInnerErrorsFuncCalled           int
+	hasDependencyFaultFuncExpected     int
+	hasDependencyFaultFuncCalled       int
+	fromComponentFuncExpected          int
+	fromComponentFuncCalled            int
+	findComponentFuncExpected          int
+	findComponentFuncCalled            int
+	responsibleComponentFuncExpected   int
+	responsibleComponentFuncCalled     int
+	stringsContainsRuneExpected        int
+	stringsContainsRuneCalled          int
+	setMessageFuncExpected             int
+	setMessageFuncCalled               int
+	formatExtraDataValueFuncExpected   int
+	formatExtraDataValueFuncCalled     int
+	runtimeCallersExpected             int
+	runtimeCallersCalled               int
+	runtimeCallersFramesExpected       int
+	runtimeCallersFramesCalled         int
+	formatStackFuncExpected            int
+	formatStackFuncCalled              int
 )
 
 func createMock(t *testing.T) {
@@ -65,18 +166,6 @@ func createMock(t *testing.T) {
 		stringsJoinCalled++
 		return ""
 	}
-	formatExtraDataFuncExpected = 0
-	formatExtraDataFuncCalled = 0
-	formatExtraDataFunc = func(extraData map[string]interface{}) string {
-		formatExtraDataFuncCalled++
-		return ""
-	}
-	printBaseAppErrorFuncExpected = 0
-	printBaseAppErrorFuncCalled = 0
-	printBaseAppErrorFunc = func(baseAppError *BaseAppError) string {
-		printBaseAppErrorFuncCalled++
-		return ""
-	}
 	getErrorMessageFuncExpected = 0
 	getErrorMessageFuncCalled = 0
 	getErrorMessageFunc = func(err error) string {
@@ -103,13 +192,13 @@ func createMock(t *testing.T) {
 	}
 	appErrorContainsFuncExpected = 0
 	appErrorContainsFuncCalled = 0
-	appErrorContainsFunc = func(appError AppError, err error) bool {
+	appErrorContainsFunc = func(appError AppError, err error, matcher Matcher) bool {
 		appErrorContainsFuncCalled++
 		return false
 	}
 	innerErrorContainsFuncExpected = 0
 	innerErrorContainsFuncCalled = 0
-	innerErrorContainsFunc = func(innerErrors []error, err error) bool {
+	innerErrorContainsFunc = func(innerErrors []error, err error, matcher Matcher) bool {
 		innerErrorContainsFuncCalled++
 		return false
 	}
@@ -125,6 +214,295 @@ func createMock(t *testing.T) {
 		newBaseAppErrorFuncCalled++
 		return nil
 	}
+	jsonMarshalExpected = 0
+	jsonMarshalCalled = 0
+	jsonMarshal = func(v interface{}) ([]byte, error) {
+		jsonMarshalCalled++
+		return nil, nil
+	}
+	httpStatusTextExpected = 0
+	httpStatusTextCalled = 0
+	httpStatusText = func(code int) string {
+		httpStatusTextCalled++
+		return ""
+	}
+	getViolationFuncExpected = 0
+	getViolationFuncCalled = 0
+	getViolationFunc = func(err error) (FieldViolation, bool) {
+		getViolationFuncCalled++
+		return FieldViolation{}, false
+	}
+	collectViolationsFuncExpected = 0
+	collectViolationsFuncCalled = 0
+	collectViolationsFunc = func(innerErrors []error, recursive bool) []FieldViolation {
+		collectViolationsFuncCalled++
+		return nil
+	}
+	marshalInnerErrorsFuncExpected = 0
+	marshalInnerErrorsFuncCalled = 0
+	marshalInnerErrorsFunc = func(innerErrors []error) []interface{} {
+		marshalInnerErrorsFuncCalled++
+		return nil
+	}
+	newProblemFuncExpected = 0
+	newProblemFuncCalled = 0
+	newProblemFunc = func(appError AppError) Problem {
+		newProblemFuncCalled++
+		return Problem{}
+	}
+	appendFieldNameFuncExpected = 0
+	appendFieldNameFuncCalled = 0
+	appendFieldNameFunc = func(path string, name string) string {
+		appendFieldNameFuncCalled++
+		return ""
+	}
+	trimNamespaceFuncExpected = 0
+	trimNamespaceFuncCalled = 0
+	trimNamespaceFunc = func(namespace string) string {
+		trimNamespaceFuncCalled++
+		return ""
+	}
+	joinFieldPathFuncExpected = 0
+	joinFieldPathFuncCalled = 0
+	joinFieldPathFunc = func(prefix string, field string) string {
+		joinFieldPathFuncCalled++
+		return ""
+	}
+	convertFieldViolationsFuncExpected = 0
+	convertFieldViolationsFuncCalled = 0
+	convertFieldViolationsFunc = func(prefix string, err error) []FieldViolation {
+		convertFieldViolationsFuncCalled++
+		return nil
+	}
+	stringsSplitExpected = 0
+	stringsSplitCalled = 0
+	stringsSplit = func(s string, sep string) []string {
+		stringsSplitCalled++
+		return nil
+	}
+	sortStringsExpected = 0
+	sortStringsCalled = 0
+	sortStrings = func(x []string) {
+		sortStringsCalled++
+	}
+	indentLinesFuncExpected = 0
+	indentLinesFuncCalled = 0
+	indentLinesFunc = func(text string) string {
+		indentLinesFuncCalled++
+		return ""
+	}
+	formatTreeFuncExpected = 0
+	formatTreeFuncCalled = 0
+	formatTreeFunc = func(baseAppError *BaseAppError) string {
+		formatTreeFuncCalled++
+		return ""
+	}
+	formatInnerErrorFuncExpected = 0
+	formatInnerErrorFuncCalled = 0
+	formatInnerErrorFunc = func(innerError error) string {
+		formatInnerErrorFuncCalled++
+		return ""
+	}
+	formatCodeGoSyntaxFuncExpected = 0
+	formatCodeGoSyntaxFuncCalled = 0
+	formatCodeGoSyntaxFunc = func(code Code) string {
+		formatCodeGoSyntaxFuncCalled++
+		return ""
+	}
+	formatGoSyntaxFuncExpected = 0
+	formatGoSyntaxFuncCalled = 0
+	formatGoSyntaxFunc = func(baseAppError *BaseAppError) string {
+		formatGoSyntaxFuncCalled++
+		return ""
+	}
+	findAttrFuncExpected = 0
+	findAttrFuncCalled = 0
+	findAttrFunc = func(attrs []Attr, key string) int {
+		findAttrFuncCalled++
+		return -1
+	}
+	removeAttrFuncExpected = 0
+	removeAttrFuncCalled = 0
+	removeAttrFunc = func(attrs []Attr, key string) []Attr {
+		removeAttrFuncCalled++
+		return nil
+	}
+	cloneBaseAppErrorFuncExpected = 0
+	cloneBaseAppErrorFuncCalled = 0
+	cloneBaseAppErrorFunc = func(baseAppError *BaseAppError) *BaseAppError {
+		cloneBaseAppErrorFuncCalled++
+		return nil
+	}
+	newSentinelFuncExpected = 0
+	newSentinelFuncCalled = 0
+	newSentinelFunc = func(code Code, message string) *BaseAppError {
+		newSentinelFuncCalled++
+		return nil
+	}
+	getCustomSentinelFuncExpected = 0
+	getCustomSentinelFuncCalled = 0
+	getCustomSentinelFunc = func(code Code) AppError {
+		getCustomSentinelFuncCalled++
+		return nil
+	}
+	innerErrorIsFuncExpected = 0
+	innerErrorIsFuncCalled = 0
+	innerErrorIsFunc = func(innerErrors []error, target error) bool {
+		innerErrorIsFuncCalled++
+		return false
+	}
+	errortextParseExpected = 0
+	errortextParseCalled = 0
+	errortextParse = func(text string) (*errortext.Node, error) {
+		errortextParseCalled++
+		return nil, nil
+	}
+	errorsNewExpected = 0
+	errorsNewCalled = 0
+	errorsNew = func(text string) error {
+		errorsNewCalled++
+		return nil
+	}
+	parseCodeFuncExpected = 0
+	parseCodeFuncCalled = 0
+	parseCodeFunc = func(name string) (Code, bool) {
+		parseCodeFuncCalled++
+		return 0, false
+	}
+	convertNodeFuncExpected = 0
+	convertNodeFuncCalled = 0
+	convertNodeFunc = func(node *errortext.Node) *BaseAppError {
+		convertNodeFuncCalled++
+		return nil
+	}
+	errortextEscapeExpected = 0
+	errortextEscapeCalled = 0
+	errortextEscape = func(text string) string {
+		errortextEscapeCalled++
+		return ""
+	}
+	reflectValueOfExpected = 0
+	reflectValueOfCalled = 0
+	reflectValueOf = func(i interface{}) reflect.Value {
+		reflectValueOfCalled++
+		return reflect.Value{}
+	}
+	isNilFuncExpected = 0
+	isNilFuncCalled = 0
+	isNilFunc = func(err error) bool {
+		isNilFuncCalled++
+		return false
+	}
+	emitMetricsEventFuncExpected = 0
+	emitMetricsEventFuncCalled = 0
+	emitMetricsEventFunc = func(kind MetricsEventKind, code Code, httpStatusCode int, component string) {
+		emitMetricsEventFuncCalled++
+	}
+	getCodeEnumFuncExpected = 0
+	getCodeEnumFuncCalled = 0
+	getCodeEnumFunc = func(appError AppError) Code {
+		getCodeEnumFuncCalled++
+		return 0
+	}
+	callObserverFuncExpected = 0
+	callObserverFuncCalled = 0
+	callObserverFunc = func(observer Observer, event LifecycleEvent) {
+		callObserverFuncCalled++
+	}
+	notifyObserversFuncExpected = 0
+	notifyObserversFuncCalled = 0
+	notifyObserversFunc = func(event LifecycleEvent) {
+		notifyObserversFuncCalled++
+	}
+	uuidNewV7Expected = 0
+	uuidNewV7Called = 0
+	uuidNewV7 = func() (uuid.UUID, error) {
+		uuidNewV7Called++
+		return uuid.Nil, nil
+	}
+	newUUIDv7FuncExpected = 0
+	newUUIDv7FuncCalled = 0
+	newUUIDv7Func = func() string {
+		newUUIDv7FuncCalled++
+		return ""
+	}
+	newInstanceIDFuncExpected = 0
+	newInstanceIDFuncCalled = 0
+	newInstanceIDFunc = func() string {
+		newInstanceIDFuncCalled++
+		return ""
+	}
+	errorsAsExpected = 0
+	errorsAsCalled = 0
+	errorsAs = func(err error, target interface{}) bool {
+		errorsAsCalled++
+		return false
+	}
+	getInnerErrorsFuncExpected = 0
+	getInnerErrorsFuncCalled = 0
+	getInnerErrorsFunc = func(err error) []error {
+		getInnerErrorsFuncCalled++
+		return nil
+	}
+	hasDependencyFaultFuncExpected = 0
+	hasDependencyFaultFuncCalled = 0
+	hasDependencyFaultFunc = func(innerErrors []error) bool {
+		hasDependencyFaultFuncCalled++
+		return false
+	}
+	fromComponentFuncExpected = 0
+	fromComponentFuncCalled = 0
+	fromComponentFunc = func(component string, err error) error {
+		fromComponentFuncCalled++
+		return nil
+	}
+	findComponentFuncExpected = 0
+	findComponentFuncCalled = 0
+	findComponentFunc = func(err error, depth int) (string, int) {
+		findComponentFuncCalled++
+		return "", -1
+	}
+	responsibleComponentFuncExpected = 0
+	responsibleComponentFuncCalled = 0
+	responsibleComponentFunc = func(err error) string {
+		responsibleComponentFuncCalled++
+		return ""
+	}
+	stringsContainsRuneExpected = 0
+	stringsContainsRuneCalled = 0
+	stringsContainsRune = func(s string, r rune) bool {
+		stringsContainsRuneCalled++
+		return false
+	}
+	setMessageFuncExpected = 0
+	setMessageFuncCalled = 0
+	setMessageFunc = func(baseAppError *BaseAppError, messageFormat string, parameters []interface{}) {
+		setMessageFuncCalled++
+	}
+	formatExtraDataValueFuncExpected = 0
+	formatExtraDataValueFuncCalled = 0
+	formatExtraDataValueFunc = func(value interface{}) string {
+		formatExtraDataValueFuncCalled++
+		return ""
+	}
+	runtimeCallersExpected = 0
+	runtimeCallersCalled = 0
+	runtimeCallers = func(skip int, pc []uintptr) int {
+		runtimeCallersCalled++
+		return 0
+	}
+	runtimeCallersFramesExpected = 0
+	runtimeCallersFramesCalled = 0
+	runtimeCallersFrames = func(callers []uintptr) *runtime.Frames {
+		runtimeCallersFramesCalled++
+		return nil
+	}
+	formatStackFuncExpected = 0
+	formatStackFuncCalled = 0
+	formatStackFunc = func(stack []uintptr) string {
+		formatStackFuncCalled++
+		return ""
+	}
 }
 
 func verifyAll(t *testing.T) {
@@ -136,10 +514,6 @@ func verifyAll(t *testing.T) {
 	assert.Equal(t, fmtErrorfExpected, fmtErrorfCalled, "Unexpected number of calls to fmtErrorf")
 	stringsJoin = strings.Join
 	assert.Equal(t, stringsJoinExpected, stringsJoinCalled, "Unexpected number of calls to stringsJoin")
-	formatExtraDataFunc = formatExtraData
-	assert.Equal(t, formatExtraDataFuncExpected, formatExtraDataFuncCalled, "Unexpected number of calls to formatExtraDataFunc")
-	printBaseAppErrorFunc = printBaseAppError
-	assert.Equal(t, printBaseAppErrorFuncExpected, printBaseAppErrorFuncCalled, "Unexpected number of calls to printBaseAppErrorFunc")
 	getErrorMessageFunc = getErrorMessage
 	assert.Equal(t, getErrorMessageFuncExpected, getErrorMessageFuncCalled, "Unexpected number of calls to getErrorMessageFunc")
 	printInnerErrorsFunc = printInnerErrors
@@ -156,4 +530,102 @@ func verifyAll(t *testing.T) {
 	assert.Equal(t, cleanupInnerErrorsFuncExpected, cleanupInnerErrorsFuncCalled, "Unexpected number of calls to cleanupInnerErrorsFunc")
 	newBaseAppErrorFunc = NewBaseAppError
 	assert.Equal(t, newBaseAppErrorFuncExpected, newBaseAppErrorFuncCalled, "Unexpected number of calls to newBaseAppErrorFunc")
+	jsonMarshal = json.Marshal
+	assert.Equal(t, jsonMarshalExpected, jsonMarshalCalled, "Unexpected number of calls to jsonMarshal")
+	httpStatusText = http.StatusText
+	assert.Equal(t, httpStatusTextExpected, httpStatusTextCalled, "Unexpected number of calls to httpStatusText")
+	getViolationFunc = getViolation
+	assert.Equal(t, getViolationFuncExpected, getViolationFuncCalled, "Unexpected number of calls to getViolationFunc")
+	collectViolationsFunc = collectViolations
+	assert.Equal(t, collectViolationsFuncExpected, collectViolationsFuncCalled, "Unexpected number of calls to collectViolationsFunc")
+	marshalInnerErrorsFunc = marshalInnerErrors
+	assert.Equal(t, marshalInnerErrorsFuncExpected, marshalInnerErrorsFuncCalled, "Unexpected number of calls to marshalInnerErrorsFunc")
+	newProblemFunc = NewProblem
+	assert.Equal(t, newProblemFuncExpected, newProblemFuncCalled, "Unexpected number of calls to newProblemFunc")
+	appendFieldNameFunc = appendFieldName
+	assert.Equal(t, appendFieldNameFuncExpected, appendFieldNameFuncCalled, "Unexpected number of calls to appendFieldNameFunc")
+	trimNamespaceFunc = trimNamespace
+	assert.Equal(t, trimNamespaceFuncExpected, trimNamespaceFuncCalled, "Unexpected number of calls to trimNamespaceFunc")
+	joinFieldPathFunc = joinFieldPath
+	assert.Equal(t, joinFieldPathFuncExpected, joinFieldPathFuncCalled, "Unexpected number of calls to joinFieldPathFunc")
+	convertFieldViolationsFunc = convertFieldViolations
+	assert.Equal(t, convertFieldViolationsFuncExpected, convertFieldViolationsFuncCalled, "Unexpected number of calls to convertFieldViolationsFunc")
+	stringsSplit = strings.Split
+	assert.Equal(t, stringsSplitExpected, stringsSplitCalled, "Unexpected number of calls to stringsSplit")
+	sortStrings = sort.Strings
+	assert.Equal(t, sortStringsExpected, sortStringsCalled, "Unexpected number of calls to sortStrings")
+	indentLinesFunc = indentLines
+	assert.Equal(t, indentLinesFuncExpected, indentLinesFuncCalled, "Unexpected number of calls to indentLinesFunc")
+	formatTreeFunc = formatTree
+	assert.Equal(t, formatTreeFuncExpected, formatTreeFuncCalled, "Unexpected number of calls to formatTreeFunc")
+	formatInnerErrorFunc = formatInnerError
+	assert.Equal(t, formatInnerErrorFuncExpected, formatInnerErrorFuncCalled, "Unexpected number of calls to formatInnerErrorFunc")
+	formatCodeGoSyntaxFunc = formatCodeGoSyntax
+	assert.Equal(t, formatCodeGoSyntaxFuncExpected, formatCodeGoSyntaxFuncCalled, "Unexpected number of calls to formatCodeGoSyntaxFunc")
+	formatGoSyntaxFunc = formatGoSyntax
+	assert.Equal(t, formatGoSyntaxFuncExpected, formatGoSyntaxFuncCalled, "Unexpected number of calls to formatGoSyntaxFunc")
+	findAttrFunc = findAttr
+	assert.Equal(t, findAttrFuncExpected, findAttrFuncCalled, "Unexpected number of calls to findAttrFunc")
+	removeAttrFunc = removeAttr
+	assert.Equal(t, removeAttrFuncExpected, removeAttrFuncCalled, "Unexpected number of calls to removeAttrFunc")
+	cloneBaseAppErrorFunc = cloneBaseAppError
+	assert.Equal(t, cloneBaseAppErrorFuncExpected, cloneBaseAppErrorFuncCalled, "Unexpected number of calls to cloneBaseAppErrorFunc")
+	newSentinelFunc = newSentinel
+	assert.Equal(t, newSentinelFuncExpected, newSentinelFuncCalled, "Unexpected number of calls to newSentinelFunc")
+	getCustomSentinelFunc = getCustomSentinel
+	assert.Equal(t, getCustomSentinelFuncExpected, getCustomSentinelFuncCalled, "Unexpected number of calls to getCustomSentinelFunc")
+	innerErrorIsFunc = innerErrorIs
+	assert.Equal(t, innerErrorIsFuncExpected, innerErrorIsFuncCalled, "Unexpected number of calls to innerErrorIsFunc")
+	errortextParse = errortext.Parse
+	assert.Equal(t, errortextParseExpected, errortextParseCalled, "Unexpected number of calls to errortextParse")
+	errorsNew = errors.New
+	assert.Equal(t, errorsNewExpected, errorsNewCalled, "Unexpected number of calls to errorsNew")
+	parseCodeFunc = parseCode
+	assert.Equal(t, parseCodeFuncExpected, parseCodeFuncCalled, "Unexpected number of calls to parseCodeFunc")
+	convertNodeFunc = convertNode
+	assert.Equal(t, convertNodeFuncExpected, convertNodeFuncCalled, "Unexpected number of calls to convertNodeFunc")
+	errortextEscape = errortext.Escape
+	assert.Equal(t, errortextEscapeExpected, errortextEscapeCalled, "Unexpected number of calls to errortextEscape")
+	reflectValueOf = reflect.ValueOf
+	assert.Equal(t, reflectValueOfExpected, reflectValueOfCalled, "Unexpected number of calls to reflectValueOf")
+	isNilFunc = IsNil
+	assert.Equal(t, isNilFuncExpected, isNilFuncCalled, "Unexpected number of calls to isNilFunc")
+	emitMetricsEventFunc = emitMetricsEvent
+	assert.Equal(t, emitMetricsEventFuncExpected, emitMetricsEventFuncCalled, "Unexpected number of calls to emitMetricsEventFunc")
+	getCodeEnumFunc = getCodeEnum
+	assert.Equal(t, getCodeEnumFuncExpected, getCodeEnumFuncCalled, "Unexpected number of calls to getCodeEnumFunc")
+	callObserverFunc = callObserver
+	assert.Equal(t, callObserverFuncExpected, callObserverFuncCalled, "Unexpected number of calls to callObserverFunc")
+	notifyObserversFunc = notifyObservers
+	assert.Equal(t, notifyObserversFuncExpected, notifyObserversFuncCalled, "Unexpected number of calls to notifyObserversFunc")
+	uuidNewV7 = uuid.NewV7
+	assert.Equal(t, uuidNewV7Expected, uuidNewV7Called, "Unexpected number of calls to uuidNewV7")
+	newUUIDv7Func = newUUIDv7
+	assert.Equal(t, newUUIDv7FuncExpected, newUUIDv7FuncCalled, "Unexpected number of calls to newUUIDv7Func")
+	newInstanceIDFunc = newInstanceID
+	assert.Equal(t, newInstanceIDFuncExpected, newInstanceIDFuncCalled, "Unexpected number of calls to newInstanceIDFunc")
+	errorsAs = errors.As
+	assert.Equal(t, errorsAsExpected, errorsAsCalled, "Unexpected number of calls to errorsAs")
+	getInnerErrorsFunc = getInnerErrors
+	assert.Equal(t, getInnerErrorsFuncExpected, getInnerErrorsFuncCalled, "Unexpected number of calls to getInnerErrorsFunc")
+	hasDependencyFaultFunc = hasDependencyFault
+	assert.Equal(t, hasDependencyFaultFuncExpected, hasDependencyFaultFuncCalled, "Unexpected number of calls to hasDependencyFaultFunc")
+	fromComponentFunc = FromComponent
+	assert.Equal(t, fromComponentFuncExpected, fromComponentFuncCalled, "Unexpected number of calls to fromComponentFunc")
+	findComponentFunc = findComponent
+	assert.Equal(t, findComponentFuncExpected, findComponentFuncCalled, "Unexpected number of calls to findComponentFunc")
+	responsibleComponentFunc = ResponsibleComponent
+	assert.Equal(t, responsibleComponentFuncExpected, responsibleComponentFuncCalled, "Unexpected number of calls to responsibleComponentFunc")
+	stringsContainsRune = strings.ContainsRune
+	assert.Equal(t, stringsContainsRuneExpected, stringsContainsRuneCalled, "Unexpected number of calls to stringsContainsRune")
+	setMessageFunc = setMessage
+	assert.Equal(t, setMessageFuncExpected, setMessageFuncCalled, "Unexpected number of calls to setMessageFunc")
+	formatExtraDataValueFunc = formatExtraDataValue
+	assert.Equal(t, formatExtraDataValueFuncExpected, formatExtraDataValueFuncCalled, "Unexpected number of calls to formatExtraDataValueFunc")
+	runtimeCallers = runtime.Callers
+	assert.Equal(t, runtimeCallersExpected, runtimeCallersCalled, "Unexpected number of calls to runtimeCallers")
+	runtimeCallersFrames = runtime.CallersFrames
+	assert.Equal(t, runtimeCallersFramesExpected, runtimeCallersFramesCalled, "Unexpected number of calls to runtimeCallersFrames")
+	formatStackFunc = formatStack
+	assert.Equal(t, formatStackFuncExpected, formatStackFuncCalled, "Unexpected number of calls to formatStackFunc")
 }
@@ -0,0 +1,441 @@
+// Package analyzer provides a go/analysis checker for common misuses of app errors:
+//
+//   - calling Wrap, Attach or AttachAttrs on a freshly created app error whose result is then discarded, and discarding the result of With or WithCause
+//   - comparing AppError.Code() to a string constant which is not a known code name
+//   - returning a possibly nil *BaseAppError variable as an error interface, which produces a non-nil error, unless an assignment on every path to the return sets it
+//   - passing a non-constant format string without parameters to NewBaseAppError, Collector.Build or the v2 Builder.Message
+//
+// It can be run through go vet -vettool (see cmd/apperror-vet) or loaded by golangci-lint through New.
+package analyzer
+
+import (
+	"go/ast"
+	"go/constant"
+	"go/token"
+	"go/types"
+	"strings"
+
+	apperror "github.com/zhongjie-cai/app-error"
+	"golang.org/x/tools/go/analysis"
+	"golang.org/x/tools/go/analysis/passes/inspect"
+	"golang.org/x/tools/go/ast/astutil"
+	"golang.org/x/tools/go/ast/inspector"
+)
+
+// These are the import paths of the checked packages
+const (
+	packagePath   = "github.com/zhongjie-cai/app-error"
+	packagePathV2 = "github.com/zhongjie-cai/app-error/v2"
+)
+
+// Analyzer is the app error misuse checker with the default settings
+var Analyzer = NewAnalyzer(Settings{})
+
+// Settings are the options of the checker
+type Settings struct {
+	// Codes are the code names, in addition to the built-in ones, that Code() may be compared to
+	Codes []string `json:"codes" mapstructure:"codes"`
+}
+
+// NewAnalyzer creates the checker with the given settings
+func NewAnalyzer(settings Settings) *analysis.Analyzer {
+	var checker = &checker{codes: map[string]bool{}}
+	for code := apperror.CodeGeneralFailure; code.String() != "Unknown"; code++ {
+		checker.codes[code.String()] = true
+	}
+	for _, code := range settings.Codes {
+		checker.codes[code] = true
+	}
+	var analyzer = &analysis.Analyzer{
+		Name:     "apperror",
+		Doc:      "check for common misuses of app errors",
+		URL:      "https://pkg.go.dev/github.com/zhongjie-cai/app-error/analyzer",
+		Requires: []*analysis.Analyzer{inspect.Analyzer},
+		Run:      checker.run,
+	}
+	analyzer.Flags.Func("codes", "comma separated code names, in addition to the built-in ones, that Code() may be compared to", func(value string) error {
+		for _, code := range strings.Split(value, ",") {
+			if code = strings.TrimSpace(code); code != "" {
+				checker.codes[code] = true
+			}
+		}
+		return nil
+	})
+	return analyzer
+}
+
+// New creates the checkers for golangci-lint's Go plugin API; conf holds the linter settings, e.g. {"codes": ["QuotaExceeded"]}
+func New(conf any) ([]*analysis.Analyzer, error) {
+	var settings Settings
+	if values, isMap := conf.(map[string]any); isMap {
+		if codes, isList := values["codes"].([]any); isList {
+			for _, code := range codes {
+				if name, isString := code.(string); isString {
+					settings.Codes = append(settings.Codes, name)
+				}
+			}
+		}
+	}
+	return []*analysis.Analyzer{NewAnalyzer(settings)}, nil
+}
+
+type checker struct {
+	codes map[string]bool
+}
+
+func (checker *checker) run(pass *analysis.Pass) (interface{}, error) {
+	var inspector = pass.ResultOf[inspect.Analyzer].(*inspector.Inspector)
+	var nodeFilter = []ast.Node{
+		(*ast.ExprStmt)(nil),
+		(*ast.BinaryExpr)(nil),
+		(*ast.SwitchStmt)(nil),
+		(*ast.CallExpr)(nil),
+		(*ast.FuncDecl)(nil),
+		(*ast.FuncLit)(nil),
+	}
+	inspector.Preorder(nodeFilter, func(node ast.Node) {
+		switch node := node.(type) {
+		case *ast.ExprStmt:
+			checkDiscarded(pass, node)
+		case *ast.BinaryExpr:
+			checker.checkCodeComparison(pass, node)
+		case *ast.SwitchStmt:
+			checker.checkCodeSwitch(pass, node)
+		case *ast.CallExpr:
+			checkFormat(pass, node)
+		case *ast.FuncDecl:
+			if node.Body != nil {
+				checkTypedNil(pass, node.Type, node.Body)
+			}
+		case *ast.FuncLit:
+			checkTypedNil(pass, node.Type, node.Body)
+		}
+	})
+	return nil, nil
+}
+
+// isNamed tells whether the type, or the type it points to, is the named type of the given package
+func isNamed(typ types.Type, path string, name string) bool {
+	if pointer, isPointer := typ.(*types.Pointer); isPointer {
+		typ = pointer.Elem()
+	}
+	var named, isNamedType = typ.(*types.Named)
+	return isNamedType &&
+		named.Obj().Pkg() != nil &&
+		named.Obj().Pkg().Path() == path &&
+		named.Obj().Name() == name
+}
+
+// isAppErrorType tells whether the type is one of the app error types of the checked packages
+func isAppErrorType(typ types.Type) bool {
+	return isNamed(typ, packagePath, "AppError") ||
+		isNamed(typ, packagePath, "BaseAppError") ||
+		isNamed(typ, packagePathV2, "Error")
+}
+
+// method returns the method called by the call expression, with its receiver expression
+func method(pass *analysis.Pass, call *ast.CallExpr) (*types.Func, ast.Expr) {
+	var selector, isSelector = astutil.Unparen(call.Fun).(*ast.SelectorExpr)
+	if !isSelector {
+		return nil, nil
+	}
+	var selection = pass.TypesInfo.Selections[selector]
+	if selection == nil || selection.Kind() != types.MethodVal {
+		return nil, nil
+	}
+	var function, isFunction = selection.Obj().(*types.Func)
+	if !isFunction {
+		return nil, nil
+	}
+	return function, selector.X
+}
+
+// function returns the package level function called by the call expression
+func function(pass *analysis.Pass, call *ast.CallExpr) *types.Func {
+	var identifier *ast.Ident
+	switch fun := astutil.Unparen(call.Fun).(type) {
+	case *ast.Ident:
+		identifier = fun
+	case *ast.SelectorExpr:
+		identifier = fun.Sel
+	default:
+		return nil
+	}
+	var function, isFunction = pass.TypesInfo.Uses[identifier].(*types.Func)
+	if !isFunction || function.Type().(*types.Signature).Recv() != nil {
+		return nil
+	}
+	return function
+}
+
+// checkDiscarded flags mutations of temporary app errors and discarded With/WithCause/WithComponent results
+func checkDiscarded(pass *analysis.Pass, statement *ast.ExprStmt) {
+	var call, isCall = astutil.Unparen(statement.X).(*ast.CallExpr)
+	if !isCall {
+		return
+	}
+	var called, receiver = method(pass, call)
+	if called == nil || !isAppErrorType(pass.TypesInfo.TypeOf(receiver)) {
+		return
+	}
+	switch called.Name() {
+	case "Wrap", "WrapFrom", "Attach", "AttachAttrs", "SetComponent":
+		if _, isTemporary := astutil.Unparen(receiver).(*ast.CallExpr); isTemporary {
+			pass.Reportf(call.Pos(), "result of %s on a temporary app error is discarded; assign the app error to a variable first", called.Name())
+		}
+	case "With", "WithCause", "WithComponent":
+		pass.Reportf(call.Pos(), "result of %s is discarded; it returns a new app error and leaves the receiver untouched", called.Name())
+	}
+}
+
+// isCodeCall tells whether the expression calls the string Code() method of a v1 app error
+func isCodeCall(pass *analysis.Pass, expression ast.Expr) bool {
+	var call, isCall = astutil.Unparen(expression).(*ast.CallExpr)
+	if !isCall {
+		return false
+	}
+	var called, receiver = method(pass, call)
+	return called != nil &&
+		called.Name() == "Code" &&
+		(isNamed(pass.TypesInfo.TypeOf(receiver), packagePath, "AppError") || isNamed(pass.TypesInfo.TypeOf(receiver), packagePath, "BaseAppError")) &&
+		types.Identical(called.Type().(*types.Signature).Results().At(0).Type(), types.Typ[types.String])
+}
+
+// checkCodeName flags the expression if it is a string constant which is not a known code name
+func (checker *checker) checkCodeName(pass *analysis.Pass, expression ast.Expr) {
+	var value = pass.TypesInfo.Types[expression].Value
+	if value == nil || value.Kind() != constant.String {
+		return
+	}
+	var name = constant.StringVal(value)
+	if !checker.codes[name] {
+		pass.Reportf(expression.Pos(), "Code() compared to %q, which is not a known code name", name)
+	}
+}
+
+func (checker *checker) checkCodeComparison(pass *analysis.Pass, expression *ast.BinaryExpr) {
+	if expression.Op != token.EQL && expression.Op != token.NEQ {
+		return
+	}
+	if isCodeCall(pass, expression.X) {
+		checker.checkCodeName(pass, expression.Y)
+	} else if isCodeCall(pass, expression.Y) {
+		checker.checkCodeName(pass, expression.X)
+	}
+}
+
+func (checker *checker) checkCodeSwitch(pass *analysis.Pass, statement *ast.SwitchStmt) {
+	if statement.Tag == nil || !isCodeCall(pass, statement.Tag) {
+		return
+	}
+	for _, clause := range statement.Body.List {
+		for _, expression := range clause.(*ast.CaseClause).List {
+			checker.checkCodeName(pass, expression)
+		}
+	}
+}
+
+// checkFormat flags non-constant format strings without parameters
+func checkFormat(pass *analysis.Pass, call *ast.CallExpr) {
+	var name string
+	var formatIndex int
+	if called := function(pass, call); called != nil && called.Pkg().Path() == packagePath && called.Name() == "NewBaseAppError" {
+		name, formatIndex = "NewBaseAppError", 1
+	} else if called, receiver := method(pass, call); called != nil {
+		var receiverType = pass.TypesInfo.TypeOf(receiver)
+		if called.Name() == "Build" && isNamed(receiverType, packagePath, "Collector") {
+			name, formatIndex = "Collector.Build", 1
+		} else if called.Name() == "Message" && isNamed(receiverType, packagePathV2, "Builder") {
+			name, formatIndex = "Builder.Message", 0
+		}
+	}
+	// like the printf check, only calls without parameters are flagged, so that wrappers forwarding their format are not
+	if name == "" || len(call.Args) != formatIndex+1 {
+		return
+	}
+	var format = call.Args[formatIndex]
+	if pass.TypesInfo.Types[format].Value == nil {
+		pass.Reportf(format.Pos(), "non-constant format string in call to %s; use \"%%s\" with the value as parameter", name)
+	}
+}
+
+// checkTypedNil flags returns of *BaseAppError variables declared without a value into error interface results, unless an assignment of a non-nil value to the variable dominates the return
+func checkTypedNil(pass *analysis.Pass, functionType *ast.FuncType, body *ast.BlockStmt) {
+	if functionType.Results == nil {
+		return
+	}
+	var resultTypes []types.Type
+	for _, field := range functionType.Results.List {
+		var count = len(field.Names)
+		if count == 0 {
+			count = 1
+		}
+		for index := 0; index < count; index++ {
+			resultTypes = append(resultTypes, pass.TypesInfo.TypeOf(field.Type))
+		}
+	}
+	var uninitialized = map[types.Object]bool{}
+	var escaped = map[types.Object]bool{}
+	var path []ast.Node
+	ast.Inspect(body, func(node ast.Node) bool {
+		switch node := node.(type) {
+		case nil:
+			path = path[:len(path)-1]
+			return true
+		case *ast.FuncLit:
+			// a variable assigned in a closure may be set on any path, so it is left alone
+			ast.Inspect(node.Body, func(node ast.Node) bool {
+				if assignment, isAssignment := node.(*ast.AssignStmt); isAssignment {
+					for _, object := range assignedObjects(pass, assignment) {
+						escaped[object] = true
+					}
+				}
+				return true
+			})
+			return false
+		case *ast.UnaryExpr:
+			// so is a variable whose address is taken, as in errors.As(err, &appError)
+			if identifier, isIdentifier := astutil.Unparen(node.X).(*ast.Ident); isIdentifier && node.Op == token.AND {
+				escaped[pass.TypesInfo.Uses[identifier]] = true
+			}
+		case *ast.ValueSpec:
+			if len(node.Values) == 0 {
+				for _, name := range node.Names {
+					var object = pass.TypesInfo.Defs[name]
+					if object != nil && isNamed(object.Type(), packagePath, "BaseAppError") {
+						if _, isPointer := object.Type().(*types.Pointer); isPointer {
+							uninitialized[object] = true
+						}
+					}
+				}
+			}
+		case *ast.ReturnStmt:
+			if len(node.Results) != len(resultTypes) {
+				break
+			}
+			for index, result := range node.Results {
+				if !types.IsInterface(resultTypes[index]) {
+					continue
+				}
+				if isTypedNil(pass, result, uninitialized, escaped) && !isAssignedBefore(pass, result, append(path, node)) {
+					pass.Reportf(result.Pos(), "possibly nil *BaseAppError returned as %s makes a non-nil interface; return nil explicitly", resultTypes[index])
+				}
+			}
+		}
+		path = append(path, node)
+		return true
+	})
+}
+
+// isAssignedBefore tells whether the last assignment to the returned variable preceding the return, in the return's own block or in one of its enclosing blocks, sets it to a value known not to be nil
+func isAssignedBefore(pass *analysis.Pass, result ast.Expr, path []ast.Node) bool {
+	var identifier, isIdentifier = astutil.Unparen(result).(*ast.Ident)
+	if !isIdentifier {
+		return false
+	}
+	var object = pass.TypesInfo.Uses[identifier]
+	for index := len(path) - 2; index >= 0; index-- {
+		var statements []ast.Stmt
+		switch node := path[index].(type) {
+		case *ast.BlockStmt:
+			statements = node.List
+		case *ast.CaseClause:
+			statements = node.Body
+		case *ast.CommClause:
+			statements = node.Body
+		}
+		var isAssigned, isNonNil = false, false
+		for _, statement := range statements {
+			if statement == path[index+1] {
+				break
+			}
+			if assignment, isAssignment := statement.(*ast.AssignStmt); isAssignment {
+				for position, left := range assignment.Lhs {
+					if identifier, isIdentifier := astutil.Unparen(left).(*ast.Ident); isIdentifier && pass.TypesInfo.ObjectOf(identifier) == object {
+						isAssigned = true
+						isNonNil = len(assignment.Lhs) == len(assignment.Rhs) && isNonNilValue(pass, assignment.Rhs[position])
+					}
+				}
+			}
+		}
+		if isAssigned {
+			return isNonNil
+		}
+	}
+	return false
+}
+
+// isNonNilValue tells whether the expression is known not to be nil: an address of a composite literal, a call to new, a sentinel, an app error built by NewBaseAppError or a GetXxxError function, or derived through With, WithCause or WithComponent
+func isNonNilValue(pass *analysis.Pass, expression ast.Expr) bool {
+	switch expression := astutil.Unparen(expression).(type) {
+	case *ast.UnaryExpr:
+		_, isCompositeLiteral := astutil.Unparen(expression.X).(*ast.CompositeLit)
+		return expression.Op == token.AND && isCompositeLiteral
+	case *ast.TypeAssertExpr:
+		// a failed single value type assertion panics rather than yielding nil
+		return isNonNilValue(pass, expression.X)
+	case *ast.Ident, *ast.SelectorExpr:
+		var variable, isVariable = pass.TypesInfo.Uses[identifierOf(expression)].(*types.Var)
+		return isVariable &&
+			variable.Pkg() != nil &&
+			variable.Pkg().Path() == packagePath &&
+			variable.Parent() == variable.Pkg().Scope() &&
+			strings.HasPrefix(variable.Name(), "Err") &&
+			isNamed(variable.Type(), packagePath, "BaseAppError")
+	case *ast.CallExpr:
+		if builtin, isBuiltin := pass.TypesInfo.Uses[identifierOf(expression.Fun)].(*types.Builtin); isBuiltin {
+			return builtin.Name() == "new"
+		}
+		if called := function(pass, expression); called != nil {
+			return called.Pkg().Path() == packagePath &&
+				(called.Name() == "NewBaseAppError" || strings.HasPrefix(called.Name(), "Get") && strings.HasSuffix(called.Name(), "Error"))
+		}
+		if called, receiver := method(pass, expression); called != nil {
+			switch called.Name() {
+			case "With", "WithCause", "WithComponent":
+				return isNamed(pass.TypesInfo.TypeOf(receiver), packagePath, "BaseAppError")
+			}
+		}
+	}
+	return false
+}
+
+// identifierOf returns the identifier naming the expression, if any
+func identifierOf(expression ast.Expr) *ast.Ident {
+	switch expression := astutil.Unparen(expression).(type) {
+	case *ast.Ident:
+		return expression
+	case *ast.SelectorExpr:
+		return expression.Sel
+	}
+	return nil
+}
+
+// assignedObjects returns the variables on the left hand side of the assignment
+func assignedObjects(pass *analysis.Pass, assignment *ast.AssignStmt) []types.Object {
+	var objects []types.Object
+	for _, left := range assignment.Lhs {
+		if identifier, isIdentifier := astutil.Unparen(left).(*ast.Ident); isIdentifier {
+			if object := pass.TypesInfo.ObjectOf(identifier); object != nil {
+				objects = append(objects, object)
+			}
+		}
+	}
+	return objects
+}
+
+// isTypedNil tells whether the expression is a nil *BaseAppError conversion or a variable declared without a value and never set through its address or from a closure
+func isTypedNil(pass *analysis.Pass, expression ast.Expr, uninitialized map[types.Object]bool, escaped map[types.Object]bool) bool {
+	switch expression := astutil.Unparen(expression).(type) {
+	case *ast.Ident:
+		var object = pass.TypesInfo.Uses[expression]
+		return uninitialized[object] && !escaped[object]
+	case *ast.CallExpr:
+		var typeAndValue = pass.TypesInfo.Types[expression.Fun]
+		return typeAndValue.IsType() &&
+			isNamed(typeAndValue.Type, packagePath, "BaseAppError") &&
+			len(expression.Args) == 1 &&
+			pass.TypesInfo.Types[expression.Args[0]].IsNil()
+	}
+	return false
+}
@@ -0,0 +1,38 @@
+package analyzer
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"golang.org/x/tools/go/analysis/analysistest"
+)
+
+func TestAnalyzer(t *testing.T) {
+	// arrange
+	var analyzer = NewAnalyzer(Settings{Codes: []string{"QuotaExceeded"}})
+
+	// act + assert
+	analysistest.Run(t, analysistest.TestData(), analyzer, "a")
+}
+
+func TestNew_Settings(t *testing.T) {
+	// act
+	var analyzers, err = New(map[string]any{"codes": []any{"QuotaExceeded", 5}})
+
+	// assert
+	assert.NoError(t, err)
+	assert.Len(t, analyzers, 1)
+	assert.Equal(t, "apperror", analyzers[0].Name)
+}
+
+func TestAnalyzer_CodesFlag(t *testing.T) {
+	// arrange
+	var analyzer = NewAnalyzer(Settings{})
+
+	// act
+	var err = analyzer.Flags.Set("codes", "QuotaExceeded, ")
+
+	// assert
+	assert.NoError(t, err)
+	analysistest.Run(t, analysistest.TestData(), analyzer, "a")
+}
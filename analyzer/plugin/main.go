@@ -0,0 +1,25 @@
+// Command plugin is the golangci-lint Go plugin of the app error misuse checker:
+//
+//	go build -buildmode=plugin -o apperror.so github.com/zhongjie-cai/app-error/analyzer/plugin
+//
+// and in .golangci.yml:
+//
+//	linters-settings:
+//	  custom:
+//	    apperror:
+//	      path: apperror.so
+//	      settings:
+//	        codes: [QuotaExceeded]
+package main
+
+import (
+	"github.com/zhongjie-cai/app-error/analyzer"
+	"golang.org/x/tools/go/analysis"
+)
+
+// New creates the checkers for golangci-lint
+func New(conf any) ([]*analysis.Analyzer, error) {
+	return analyzer.New(conf)
+}
+
+func main() {}
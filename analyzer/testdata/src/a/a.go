@@ -0,0 +1,168 @@
+package a
+
+import (
+	"errors"
+
+	apperror "github.com/zhongjie-cai/app-error"
+	v2 "github.com/zhongjie-cai/app-error/v2"
+)
+
+func discarded(err error) apperror.AppError {
+	apperror.GetNotFoundError().Wrap(err)             // want `result of Wrap on a temporary app error is discarded`
+	apperror.NewBaseAppError(0, "x").Attach("key", 1) // want `result of Attach on a temporary app error is discarded`
+	apperror.NewBaseAppError(0, "x").AttachAttrs()    // want `result of AttachAttrs on a temporary app error is discarded`
+	apperror.NewBaseAppError(0, "x").WrapFrom("db")   // want `result of WrapFrom on a temporary app error is discarded`
+	var appError = apperror.NewBaseAppError(0, "x")
+	appError.Wrap(err)
+	appError.Attach("key", 1)
+	appError.With("key", 1) // want `result of With is discarded`
+	appError.WithCause(err) // want `result of WithCause is discarded`
+	appError.SetComponent("db")
+	appError.WithComponent("db") // want `result of WithComponent is discarded`
+	return appError.With("key", 1)
+}
+
+func comparison(appError apperror.AppError) bool {
+	if appError.Code() == "NotFound" || "GeneralFailure" != appError.Code() {
+		return true
+	}
+	switch appError.Code() {
+	case "NotFound", "QuotaExceeded":
+	case "NotFond": // want `Code\(\) compared to "NotFond", which is not a known code name`
+	}
+	var name = "anything"
+	return appError.Code() == "Notfound" || appError.Code() == name // want `Code\(\) compared to "Notfound", which is not a known code name`
+}
+
+func typedNil(fail bool) error {
+	var appError *apperror.BaseAppError
+	if fail {
+		appError = apperror.NewBaseAppError(0, "x")
+	}
+	return appError // want `possibly nil \*BaseAppError returned as error makes a non-nil interface; return nil explicitly`
+}
+
+func typedNilConversion() (int, error) {
+	return 0, (*apperror.BaseAppError)(nil) // want `possibly nil \*BaseAppError returned as error makes a non-nil interface`
+}
+
+func concreteResult() *apperror.BaseAppError {
+	var appError *apperror.BaseAppError
+	return appError
+}
+
+func assignedBeforeReturn() error {
+	var appError *apperror.BaseAppError
+	appError = apperror.NewBaseAppError(0, "x")
+	return appError
+}
+
+func assignedInEnclosingBlock(fail bool) error {
+	var appError *apperror.BaseAppError
+	appError = apperror.NewBaseAppError(0, "x")
+	if fail {
+		return appError
+	}
+	switch {
+	case fail:
+		appError = apperror.NewBaseAppError(0, "y")
+		return appError
+	}
+	return nil
+}
+
+func assignedAfterReturn(fail bool) error {
+	var appError *apperror.BaseAppError
+	if fail {
+		return appError // want `possibly nil \*BaseAppError returned as error makes a non-nil interface`
+	}
+	appError = apperror.NewBaseAppError(0, "x")
+	return appError
+}
+
+func assignedNil() error {
+	var appError *apperror.BaseAppError
+	appError = nil
+	return appError // want `possibly nil \*BaseAppError returned as error makes a non-nil interface`
+}
+
+func lookup() *apperror.BaseAppError {
+	return nil
+}
+
+func assignedFromCall() error {
+	var appError *apperror.BaseAppError
+	appError = lookup()
+	return appError // want `possibly nil \*BaseAppError returned as error makes a non-nil interface`
+}
+
+func assignedNilAfterValue() error {
+	var appError *apperror.BaseAppError
+	appError = apperror.NewBaseAppError(0, "x")
+	appError = nil
+	return appError // want `possibly nil \*BaseAppError returned as error makes a non-nil interface`
+}
+
+func assignedNewValue() error {
+	var appError *apperror.BaseAppError
+	appError = &apperror.BaseAppError{}
+	return appError
+}
+
+func assignedSentinel() error {
+	var appError *apperror.BaseAppError
+	appError = apperror.ErrNotFound
+	return appError
+}
+
+func assignedDerived() error {
+	var appError *apperror.BaseAppError
+	appError = apperror.ErrNotFound.With("id", 5)
+	return appError
+}
+
+func assignedDerivedWithCause(err error) error {
+	var appError *apperror.BaseAppError
+	appError = apperror.ErrNotFound.WithCause(err).WithComponent("db")
+	return appError
+}
+
+func assignedFromGetter() error {
+	var appError *apperror.BaseAppError
+	appError = apperror.GetNotFoundError().(*apperror.BaseAppError)
+	return appError
+}
+
+func assignedThroughAddress(err error) error {
+	var appError *apperror.BaseAppError
+	if !errors.As(err, &appError) {
+		return nil
+	}
+	return appError
+}
+
+func assignedInClosure(run func(func())) error {
+	var appError *apperror.BaseAppError
+	run(func() {
+		appError = apperror.NewBaseAppError(0, "x")
+	})
+	return appError
+}
+
+func initialized() error {
+	var appError = apperror.NewBaseAppError(0, "x")
+	return appError
+}
+
+func formats(message string, parameters []interface{}) {
+	apperror.NewBaseAppError(0, "constant %v", 1)
+	apperror.NewBaseAppError(0, message) // want `non-constant format string in call to NewBaseAppError`
+	apperror.NewBaseAppError(0, "%s", message)
+	var collector = &apperror.Collector{}
+	collector.Build(0, message+"!") // want `non-constant format string in call to Collector.Build`
+	v2.New(0).Message(message)      // want `non-constant format string in call to Builder.Message`
+	v2.New(0).Message("%s", message)
+	apperror.NewBaseAppError(0, message, parameters...)
+	apperror.NewBaseAppError(0, message, 1)
+	_ = errors.New(message)
+}
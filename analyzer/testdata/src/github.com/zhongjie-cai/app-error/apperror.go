@@ -0,0 +1,45 @@
+// Package apperror is a stub of the app error package for the analyzer tests
+package apperror
+
+type Code int
+
+const (
+	CodeGeneralFailure Code = iota
+	CodeNotFound
+)
+
+type Attr struct{}
+
+type AppError interface {
+	error
+	Code() string
+	Wrap(innerErrors ...error)
+	Attach(name string, value interface{})
+}
+
+type BaseAppError struct{}
+
+func (baseAppError *BaseAppError) Error() string                                     { return "" }
+func (baseAppError *BaseAppError) Code() string                                      { return "" }
+func (baseAppError *BaseAppError) Wrap(innerErrors ...error)                         {}
+func (baseAppError *BaseAppError) Attach(name string, value interface{})             {}
+func (baseAppError *BaseAppError) AttachAttrs(attrs ...Attr)                         {}
+func (baseAppError *BaseAppError) With(name string, value interface{}) *BaseAppError { return nil }
+func (baseAppError *BaseAppError) WithCause(innerErrors ...error) *BaseAppError      { return nil }
+func (baseAppError *BaseAppError) WrapFrom(component string, innerErrors ...error)   {}
+func (baseAppError *BaseAppError) SetComponent(component string)                     {}
+func (baseAppError *BaseAppError) WithComponent(component string) *BaseAppError      { return nil }
+
+func NewBaseAppError(code Code, messageFormat string, parameters ...interface{}) *BaseAppError {
+	return nil
+}
+
+func GetNotFoundError(innerErrors ...error) AppError { return nil }
+
+var ErrNotFound = &BaseAppError{}
+
+type Collector struct{}
+
+func (collector *Collector) Build(code Code, messageFormat string, parameters ...interface{}) AppError {
+	return nil
+}
@@ -0,0 +1,8 @@
+// Package apperror is a stub of the v2 app error package for the analyzer tests
+package apperror
+
+type Builder struct{}
+
+func New(code int) *Builder { return nil }
+
+func (builder *Builder) Message(format string, parameters ...interface{}) *Builder { return builder }
@@ -1,5 +1,13 @@
 package apperror
 
+import (
+	"strings"
+	"sync/atomic"
+	"time"
+
+	"github.com/zhongjie-cai/app-error/internal/errortext"
+)
+
 // AppError is the error wrapper interface for all WebServiceTemplate service generated errors
 type AppError interface {
 	// Golang internal error interface
@@ -10,7 +18,7 @@ type AppError interface {
 	Code() string
 	// HTTPStatusCode returns the corresponding HTTP status code mapped to the error code value
 	HTTPStatusCode() int
-	// Contains checks if the current error object or any of its inner errors contains the given error object
+	// Contains checks if the current error object or any of its inner errors matches the given error object through errors.Is
 	Contains(err error) bool
 	// Wrap wraps the given list of inner errors into the current app error object
 	Wrap(innerErrors ...error)
@@ -20,80 +28,288 @@ type AppError interface {
 
 // These are print formatting related constants
 const (
-	errorMessageFormat   string = "(%v) %v%v" // (Code) Message [extra data]
-	errorExtraDataFormat string = "%v = %+v"  // name = value
-	errorJoiningFormat   string = " [ %v ]"   // [ content ]
-	errorPointer         string = " : "
-	errorSeparator       string = " | "
+	errorExtraDataFormat  string = "%v = %+v" // name = value
+	errorJoiningFormat    string = " [ %v ]"  // [ content ]
+	errorPointer          string = " : "
+	errorSeparator        string = " | "
+	errorCodePrefix       string = "("   // (Code) Message [extra data]
+	errorCodeSuffix       string = ") "  // (Code) Message [extra data]
+	errorJoiningPrefix    string = " [ " // [ content ]
+	errorJoiningSuffix    string = " ]"  // [ content ]
+	errorExtraDataPointer string = " = " // name = value
+)
+
+// These are the default messages of the built-in error codes
+const (
+	messageGeneralFailure   string = "An error occurred during execution"
+	messageUnauthorized     string = "Access denied due to authorization error"
+	messageInvalidOperation string = "Operation (method) not allowed"
+	messageBadRequest       string = "Request URI or body is invalid"
+	messageNotFound         string = "Requested resource is not found in the storage"
+	messageCircuitBreak     string = "Operation refused due to internal circuit break on correlation ID"
+	messageOperationLock    string = "Operation refused due to mutex lock on correlation ID or trip ID"
+	messageAccessForbidden  string = "Operation failed due to access forbidden"
+	messageDataCorruption   string = "Operation failed due to internal storage data corruption"
+	messageNotImplemented   string = "Operation failed due to internal business logic not implemented"
 )
 
 // BaseAppError instantiates the AppError interface and provides a base for inheritance
 type BaseAppError struct {
 	error
-	code        Code
-	innerErrors []error
-	extraData   map[string]interface{}
+	code          Code
+	messageFormat string
+	innerErrors   []error
+	extraData     map[string]interface{}
+	attrs         []Attr
+	frozen        bool
+	sentinel      bool
+	details       *errorDetails
+	message       messageText
+	cache         *errorCache
+}
+
+// errorCache holds the fields of an app error which are updated atomically; it sits behind a plain pointer, so that BaseAppError can still be copied and embedded by value
+type errorCache struct {
+	owner      *BaseAppError
+	instanceID atomic.Pointer[string]
+	version    atomic.Uint64
+	printed    atomic.Pointer[printedText]
+	rendered   atomic.Pointer[printedText]
+}
+
+// getCache returns the cache of the app error, allocating one for an app error not made by the constructors or copied by value; the allocation is not synchronized, so such an app error must be used once before it is shared between goroutines
+func (baseAppError *BaseAppError) getCache() *errorCache {
+	var current = baseAppError.cache
+	if current != nil && current.owner == baseAppError {
+		return current
+	}
+	var cache = &errorCache{owner: baseAppError}
+	if current != nil {
+		// a copy keeps the instance ID of the app error it was copied from
+		cache.instanceID.Store(current.instanceID.Load())
+	}
+	baseAppError.cache = cache
+	return cache
+}
+
+// errorDetails holds the rarely set fields of an app error; it is only allocated once one of them is set, so that a plain app error stays a single small allocation
+type errorDetails struct {
+	origin    *BaseAppError
+	createdAt time.Time
+	sequence  uint64
+	stack     []uintptr
+	component string
+}
+
+// noDetails is read in place of the details of an app error which has none
+var noDetails errorDetails
+
+// getDetails returns the details of the app error, or empty ones if it has none; they must not be modified
+func (baseAppError *BaseAppError) getDetails() *errorDetails {
+	if baseAppError.details == nil {
+		return &noDetails
+	}
+	return baseAppError.details
+}
+
+// setDetails returns the details of the app error for modification, allocating them if it has none
+func (baseAppError *BaseAppError) setDetails() *errorDetails {
+	if baseAppError.details == nil {
+		baseAppError.details = &errorDetails{}
+	}
+	return baseAppError.details
+}
+
+// messageText is the message error of an app error whose message format has neither parameters nor verbs; the app error points its error at its own message field, so no separate error value is allocated for it
+type messageText string
+
+func (text *messageText) Error() string {
+	return string(*text)
+}
+
+// printedText caches a rendering of an app error, along with the print settings, code registry generation and version it was rendered at
+type printedText struct {
+	isEscaped      bool
+	withInstanceID bool
+	generation     uint64
+	version        uint64
+	text           string
 }
 
 // NewBaseAppError creates an instance of BaseAppError object using given data
 func NewBaseAppError(code Code, messageFormat string, parameters ...interface{}) *BaseAppError {
-	return &BaseAppError{
-		fmtErrorf(
+	emitMetricsEvent(
+		MetricsEventCreated,
+		code,
+		code.HTTPStatusCode(),
+		"",
+	)
+	var baseAppError = &BaseAppError{
+		code:          code,
+		messageFormat: messageFormat,
+	}
+	baseAppError.getCache().instanceID.Store(&pendingInstanceID)
+	var createdAt, sequence = stampCreation()
+	var stack = captureStack()
+	if sequence != 0 || len(stack) > 0 {
+		baseAppError.details = &errorDetails{
+			createdAt: createdAt,
+			sequence:  sequence,
+			stack:     stack,
+		}
+	}
+	setMessage(
+		baseAppError,
+		messageFormat,
+		parameters,
+	)
+	notifyObservers(
+		LifecycleEvent{
+			Kind:  LifecycleEventCreated,
+			Error: baseAppError,
+		},
+	)
+	return baseAppError
+}
+
+// setMessage sets the message error of the app error; inner errors and extra data are only allocated once something is wrapped or attached
+func setMessage(baseAppError *BaseAppError, messageFormat string, parameters []interface{}) {
+	if len(parameters) > 0 ||
+		stringsContainsRune(messageFormat, '%') {
+		baseAppError.error = fmtErrorf(
 			messageFormat,
 			parameters...,
-		),
-		code,
-		[]error{},
-		map[string]interface{}{},
+		)
+		return
 	}
+	baseAppError.message = messageText(messageFormat)
+	baseAppError.error = &baseAppError.message
+}
+
+func formatExtraDataValue(value interface{}) string {
+	if text, isText := value.(string); isText {
+		return text
+	}
+	return fmtSprintf(
+		"%+v",
+		value,
+	)
 }
 
 func formatExtraData(extraData map[string]interface{}) string {
 	if len(extraData) == 0 {
 		return ""
 	}
-	var extraDataMessages = []string{}
-	for name, value := range extraData {
-		extraDataMessages = append(
-			extraDataMessages,
-			fmtSprintf(
-				errorExtraDataFormat,
-				name,
-				value,
-			),
+	var isEscaped = escapedPrintMode()
+	var builder strings.Builder
+	builder.WriteString(errorJoiningPrefix)
+	var isFirst = true
+	var writeEntry = func(name string, value interface{}) {
+		var valueText = formatExtraDataValueFunc(
+			value,
 		)
+		if isEscaped {
+			name = errortextEscape(
+				name,
+			)
+			valueText = errortextEscape(
+				valueText,
+			)
+		}
+		if !isFirst {
+			builder.WriteString(errorSeparator)
+		}
+		isFirst = false
+		builder.WriteString(name)
+		builder.WriteString(errorExtraDataPointer)
+		builder.WriteString(valueText)
 	}
-	return fmtSprintf(
-		errorJoiningFormat,
-		stringsJoin(
-			extraDataMessages,
-			errorSeparator,
-		),
-	)
+	for name, value := range extraData {
+		if name != InstanceIDDataName {
+			writeEntry(name, value)
+		}
+	}
+	if instanceID, isFound := extraData[InstanceIDDataName]; isFound {
+		// the instance ID always comes last, so that it is easy to spot
+		writeEntry(InstanceIDDataName, instanceID)
+	}
+	builder.WriteString(errorJoiningSuffix)
+	return builder.String()
 }
 
 // PrintError prints given error data to a string; override this if you want a different format than default style as "(Code) Message [Attached Data]"
 func (baseAppError *BaseAppError) PrintError(code Code, err error, extraData map[string]interface{}) string {
-	var extraDataMessage = formatExtraDataFunc(
+	var extraDataMessage = formatExtraData(
 		extraData,
 	)
-	return fmtSprintf(
-		errorMessageFormat,
-		code,
-		err,
-		extraDataMessage,
-	)
+	var message = nilErrorText
+	if err != nil {
+		message = getErrorMessageFunc(
+			err,
+		)
+		if escapedPrintMode() {
+			message = errortextEscape(
+				message,
+			)
+		}
+	}
+	var codeName = code.String()
+	var builder strings.Builder
+	builder.Grow(len(errorCodePrefix) + len(codeName) + len(errorCodeSuffix) + len(message) + len(extraDataMessage))
+	builder.WriteString(errorCodePrefix)
+	builder.WriteString(codeName)
+	builder.WriteString(errorCodeSuffix)
+	builder.WriteString(message)
+	builder.WriteString(extraDataMessage)
+	return builder.String()
 }
 
+// printBaseAppError prints the app error's own code, message and extra data, reusing the last rendering until the error changes or the print settings are switched
 func printBaseAppError(baseAppError *BaseAppError) string {
-	return baseAppError.PrintError(
+	var isEscaped = escapedPrintMode()
+	var withInstanceID = CurrentPrintInstanceID()
+	var generation = codeGeneration.Load()
+	var cache = baseAppError.getCache()
+	var version = cache.version.Load()
+	var printed = cache.printed.Load()
+	if printed != nil &&
+		printed.isEscaped == isEscaped &&
+		printed.withInstanceID == withInstanceID &&
+		printed.generation == generation &&
+		printed.version == version {
+		return printed.text
+	}
+	var extraData = getExtraData(
+		baseAppError,
+	)
+	if withInstanceID {
+		extraData = withInstanceIDData(
+			extraData,
+			baseAppError.InstanceID(),
+		)
+	}
+	var text = baseAppError.PrintError(
 		baseAppError.code,
 		baseAppError.error,
-		baseAppError.extraData,
+		extraData,
+	)
+	cache.printed.Store(
+		&printedText{
+			isEscaped:      isEscaped,
+			withInstanceID: withInstanceID,
+			generation:     generation,
+			version:        version,
+			text:           text,
+		},
 	)
+	return text
 }
 
 func getErrorMessage(err error) string {
+	if err == nil {
+		// an app error not made through the constructors, e.g. embedded by value, has no message error
+		return nilErrorText
+	}
 	return err.Error()
 }
 
@@ -101,46 +317,128 @@ func printInnerErrors(innerErrors []error) string {
 	if len(innerErrors) == 0 {
 		return ""
 	}
-	var innerErrorMessages = []string{}
+	var isEscaped = escapedPrintMode()
+	var builder strings.Builder
+	builder.WriteString(errorJoiningPrefix)
+	var isFirst = true
 	for _, innerError := range innerErrors {
-		if innerError != nil {
-			innerErrorMessages = append(
-				innerErrorMessages,
-				getErrorMessageFunc(
-					innerError,
-				),
+		if innerError == nil {
+			continue
+		}
+		var innerErrorMessage = getErrorMessageFunc(
+			innerError,
+		)
+		// app error inner errors of either version escape their own text
+		if isEscaped && !errortext.EscapesOwnText(innerError) {
+			innerErrorMessage = errortextEscape(
+				innerErrorMessage,
 			)
 		}
+		if !isFirst {
+			builder.WriteString(errorSeparator)
+		}
+		isFirst = false
+		builder.WriteString(innerErrorMessage)
 	}
-	return fmtSprintf(
-		errorJoiningFormat,
-		stringsJoin(
-			innerErrorMessages,
-			errorSeparator,
-		),
-	)
+	builder.WriteString(errorJoiningSuffix)
+	return builder.String()
 }
 
+// EscapesOwnText reports that the app error escapes its own text in escaped print mode, so errors wrapping it leave that text as is
+func (baseAppError *BaseAppError) EscapesOwnText() bool {
+	return true
+}
+
+// Error returns the one-line text of the app error and its inner errors, memoized until any app error in the tree changes or a code is registered
 func (baseAppError *BaseAppError) Error() string {
-	var baseErrorMessage = printBaseAppErrorFunc(
+	if baseAppError == nil {
+		return nilErrorText
+	}
+	var isEscaped = escapedPrintMode()
+	var withInstanceID = CurrentPrintInstanceID()
+	var generation = codeGeneration.Load()
+	var version, isVersioned = treeVersion(
+		baseAppError,
+	)
+	var cache = baseAppError.getCache()
+	var rendered = cache.rendered.Load()
+	if isVersioned &&
+		rendered != nil &&
+		rendered.isEscaped == isEscaped &&
+		rendered.withInstanceID == withInstanceID &&
+		rendered.generation == generation &&
+		rendered.version == version {
+		return rendered.text
+	}
+	var baseErrorMessage = printBaseAppError(
 		baseAppError,
 	)
 	var innerErrorMessage = printInnerErrorsFunc(
 		baseAppError.innerErrors,
 	)
-	return fmtSprint(
-		baseErrorMessage,
-		innerErrorMessage,
+	var text = baseErrorMessage + innerErrorMessage
+	cache.rendered.Store(
+		&printedText{
+			isEscaped:      isEscaped,
+			withInstanceID: withInstanceID,
+			generation:     generation,
+			version:        version,
+			text:           text,
+		},
 	)
+	return text
 }
 
 // Code returns string representation of the error code of the app error
 func (baseAppError *BaseAppError) Code() string {
+	if baseAppError == nil {
+		return CodeGeneralFailure.String()
+	}
 	return baseAppError.code.String()
 }
 
+// CodeEnum returns the error code enum of the app error, as opposed to Code which returns its string representation
+func (baseAppError *BaseAppError) CodeEnum() Code {
+	if baseAppError == nil {
+		return CodeGeneralFailure
+	}
+	return baseAppError.code
+}
+
+// Message returns the formatted message of the app error, without its code, extra data or inner errors
+func (baseAppError *BaseAppError) Message() string {
+	if baseAppError == nil {
+		return ""
+	}
+	return getErrorMessageFunc(
+		baseAppError.error,
+	)
+}
+
+// MessageTemplate returns the message format the app error was created with, before its parameters are applied; app errors parsed or converted from another representation take their message as their template
+func (baseAppError *BaseAppError) MessageTemplate() string {
+	if baseAppError == nil {
+		return ""
+	}
+	return baseAppError.messageFormat
+}
+
+// InnerErrors returns a copy of the list of inner errors wrapped in the app error
+func (baseAppError *BaseAppError) InnerErrors() []error {
+	if baseAppError == nil {
+		return nil
+	}
+	return append(
+		[]error{},
+		baseAppError.innerErrors...,
+	)
+}
+
 // HTTPStatusCode returns HTTP status code according to the error code of the app error
 func (baseAppError *BaseAppError) HTTPStatusCode() int {
+	if baseAppError == nil {
+		return CodeGeneralFailure.HTTPStatusCode()
+	}
 	return baseAppError.code.HTTPStatusCode()
 }
 
@@ -150,43 +448,67 @@ func equalsError(err, target error) bool {
 		errorsIs(err, target)
 }
 
-func appErrorContains(appError AppError, err error) bool {
-	return appError.Contains(err)
+// matchContainer is implemented by app errors supporting matching strategies, such as *BaseAppError; it is kept out of AppError so that existing implementations of AppError still satisfy it
+type matchContainer interface {
+	ContainsMatch(err error, matcher Matcher) bool
+}
+
+func appErrorContains(appError AppError, err error, matcher Matcher) bool {
+	if typedError, isTyped := appError.(matchContainer); isTyped {
+		return typedError.ContainsMatch(err, matcher)
+	}
+	// other app errors only know their own notion of containment
+	return matcher(appError, err) ||
+		appError.Contains(err)
 }
 
-func innerErrorContains(innerErrors []error, err error) bool {
+func innerErrorContains(innerErrors []error, err error, matcher Matcher) bool {
 	for _, innerError := range innerErrors {
 		var typedError, isTyped = innerError.(AppError)
 		if isTyped {
 			if appErrorContainsFunc(
 				typedError,
 				err,
+				matcher,
 			) {
 				return true
 			}
-		} else if equalsErrorFunc(innerError, err) {
+		} else if matcher(innerError, err) {
 			return true
 		}
 	}
 	return false
 }
 
-// Contains checks if the current error object or any of its inner errors contains the given error object
+// Contains checks if the current error object or any of its inner errors matches the given error object through errors.Is; see ContainsMatch for other matching strategies
 func (baseAppError *BaseAppError) Contains(err error) bool {
+	return baseAppError.ContainsMatch(
+		err,
+		MatchErrorsIs,
+	)
+}
+
+// ContainsMatch checks if the current error object, its message error or any of its inner errors matches the given error object using the given matcher
+func (baseAppError *BaseAppError) ContainsMatch(err error, matcher Matcher) bool {
+	if baseAppError == nil || err == nil {
+		return false
+	}
 	if baseAppError == err ||
-		equalsErrorFunc(baseAppError.error, err) {
+		matcher(baseAppError, err) ||
+		matcher(baseAppError.error, err) {
 		return true
 	}
 	return innerErrorContainsFunc(
 		baseAppError.innerErrors,
 		err,
+		matcher,
 	)
 }
 
 func cleanupInnerErrors(innerErrors []error) []error {
-	var cleanedInnerErrors = []error{}
+	var cleanedInnerErrors = make([]error, 0, len(innerErrors))
 	for _, innerError := range innerErrors {
-		if innerError != nil {
+		if !isNilFunc(innerError) {
 			cleanedInnerErrors = append(
 				cleanedInnerErrors,
 				innerError,
@@ -198,31 +520,79 @@ func cleanupInnerErrors(innerErrors []error) []error {
 
 // Wrap wraps the given list of inner errors into the current app error object
 func (baseAppError *BaseAppError) Wrap(innerErrors ...error) {
+	if baseAppError == nil || baseAppError.frozen {
+		return
+	}
 	var cleanedInnerErrors = cleanupInnerErrorsFunc(
 		innerErrors,
 	)
 	if len(cleanedInnerErrors) == 0 {
 		return
 	}
-	baseAppError.innerErrors = append(
-		baseAppError.innerErrors,
-		cleanedInnerErrors...,
+	if baseAppError.innerErrors == nil {
+		// the cleaned list is a fresh copy, so the first wrap keeps it instead of copying it again
+		baseAppError.innerErrors = cleanedInnerErrors
+	} else {
+		baseAppError.innerErrors = append(
+			baseAppError.innerErrors,
+			cleanedInnerErrors...,
+		)
+	}
+	baseAppError.getCache().version.Add(1)
+	notifyObserversFunc(
+		LifecycleEvent{
+			Kind:        LifecycleEventWrapped,
+			Error:       baseAppError,
+			InnerErrors: cleanedInnerErrors,
+		},
 	)
 }
 
-// Attach allows consumer to add/update a key-value pair to the app error
+// Attach allows consumer to add/update a key-value pair to the app error; the value is captured as it is when the error is first rendered
 func (baseAppError *BaseAppError) Attach(name string, value interface{}) {
+	if baseAppError == nil || baseAppError.frozen {
+		return
+	}
 	if baseAppError.extraData == nil {
 		baseAppError.extraData = map[string]interface{}{}
 	}
 	baseAppError.extraData[name] = value
+	baseAppError.attrs = removeAttrFunc(
+		baseAppError.attrs,
+		name,
+	)
+	baseAppError.getCache().version.Add(1)
+	notifyObserversFunc(
+		LifecycleEvent{
+			Kind:  LifecycleEventAttached,
+			Error: baseAppError,
+			Attr: Any(
+				name,
+				value,
+			),
+		},
+	)
 }
 
+// These are the immutable sentinel errors of the built-in error codes; derive from them with With or WithCause, and match any app error of their code against them with errors.Is
+var (
+	ErrGeneralFailure   *BaseAppError = newSentinel(CodeGeneralFailure, messageGeneralFailure)
+	ErrUnauthorized     *BaseAppError = newSentinel(CodeUnauthorized, messageUnauthorized)
+	ErrInvalidOperation *BaseAppError = newSentinel(CodeInvalidOperation, messageInvalidOperation)
+	ErrBadRequest       *BaseAppError = newSentinel(CodeBadRequest, messageBadRequest)
+	ErrNotFound         *BaseAppError = newSentinel(CodeNotFound, messageNotFound)
+	ErrCircuitBreak     *BaseAppError = newSentinel(CodeCircuitBreak, messageCircuitBreak)
+	ErrOperationLock    *BaseAppError = newSentinel(CodeOperationLock, messageOperationLock)
+	ErrAccessForbidden  *BaseAppError = newSentinel(CodeAccessForbidden, messageAccessForbidden)
+	ErrDataCorruption   *BaseAppError = newSentinel(CodeDataCorruption, messageDataCorruption)
+	ErrNotImplemented   *BaseAppError = newSentinel(CodeNotImplemented, messageNotImplemented)
+)
+
 // GetGeneralFailureError creates a generic error based on GeneralFailure
 func GetGeneralFailureError(innerErrors ...error) AppError {
 	var baseAppError = newBaseAppErrorFunc(
 		CodeGeneralFailure,
-		"An error occurred during execution",
+		messageGeneralFailure,
 	)
 	baseAppError.Wrap(
 		innerErrors...,
@@ -234,7 +604,7 @@ func GetGeneralFailureError(innerErrors ...error) AppError {
 func GetUnauthorized(innerErrors ...error) AppError {
 	var baseAppError = newBaseAppErrorFunc(
 		CodeUnauthorized,
-		"Access denied due to authorization error",
+		messageUnauthorized,
 	)
 	baseAppError.Wrap(
 		innerErrors...,
@@ -246,7 +616,7 @@ func GetUnauthorized(innerErrors ...error) AppError {
 func GetInvalidOperation(innerErrors ...error) AppError {
 	var baseAppError = newBaseAppErrorFunc(
 		CodeInvalidOperation,
-		"Operation (method) not allowed",
+		messageInvalidOperation,
 	)
 	baseAppError.Wrap(
 		innerErrors...,
@@ -258,7 +628,7 @@ func GetInvalidOperation(innerErrors ...error) AppError {
 func GetBadRequestError(innerErrors ...error) AppError {
 	var baseAppError = newBaseAppErrorFunc(
 		CodeBadRequest,
-		"Request URI or body is invalid",
+		messageBadRequest,
 	)
 	baseAppError.Wrap(
 		innerErrors...,
@@ -270,7 +640,7 @@ func GetBadRequestError(innerErrors ...error) AppError {
 func GetNotFoundError(innerErrors ...error) AppError {
 	var baseAppError = newBaseAppErrorFunc(
 		CodeNotFound,
-		"Requested resource is not found in the storage",
+		messageNotFound,
 	)
 	baseAppError.Wrap(
 		innerErrors...,
@@ -282,7 +652,7 @@ func GetNotFoundError(innerErrors ...error) AppError {
 func GetCircuitBreakError(innerErrors ...error) AppError {
 	var baseAppError = newBaseAppErrorFunc(
 		CodeCircuitBreak,
-		"Operation refused due to internal circuit break on correlation ID",
+		messageCircuitBreak,
 	)
 	baseAppError.Wrap(
 		innerErrors...,
@@ -294,7 +664,7 @@ func GetCircuitBreakError(innerErrors ...error) AppError {
 func GetOperationLockError(innerErrors ...error) AppError {
 	var baseAppError = newBaseAppErrorFunc(
 		CodeOperationLock,
-		"Operation refused due to mutex lock on correlation ID or trip ID",
+		messageOperationLock,
 	)
 	baseAppError.Wrap(
 		innerErrors...,
@@ -306,7 +676,7 @@ func GetOperationLockError(innerErrors ...error) AppError {
 func GetAccessForbiddenError(innerErrors ...error) AppError {
 	var baseAppError = newBaseAppErrorFunc(
 		CodeAccessForbidden,
-		"Operation failed due to access forbidden",
+		messageAccessForbidden,
 	)
 	baseAppError.Wrap(
 		innerErrors...,
@@ -318,7 +688,7 @@ func GetAccessForbiddenError(innerErrors ...error) AppError {
 func GetDataCorruptionError(innerErrors ...error) AppError {
 	var baseAppError = newBaseAppErrorFunc(
 		CodeDataCorruption,
-		"Operation failed due to internal storage data corruption",
+		messageDataCorruption,
 	)
 	baseAppError.Wrap(
 		innerErrors...,
@@ -330,7 +700,7 @@ func GetDataCorruptionError(innerErrors ...error) AppError {
 func GetNotImplementedError(innerErrors ...error) AppError {
 	var baseAppError = newBaseAppErrorFunc(
 		CodeNotImplemented,
-		"Operation failed due to internal business logic not implemented",
+		messageNotImplemented,
 	)
 	baseAppError.Wrap(
 		innerErrors...,
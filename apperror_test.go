@@ -3,7 +3,9 @@ package apperror
 import (
 	"errors"
 	"math/rand"
+	"strings"
 	"testing"
+	"time"
 
 	"github.com/google/uuid"
 	"github.com/stretchr/testify/assert"
@@ -12,26 +14,51 @@ import (
 func TestNewBaseAppError(t *testing.T) {
 	// arrange
 	var dummyCode = Code(rand.Intn(100))
-	var dummyMessageFormat = "some format"
+	var dummyMessageFormat = "some format %v %v %v"
 	var dummyParameter1 = "some parameter 2"
 	var dummyParameter2 = rand.Int()
 	var dummyParameter3 = errors.New("some error 3")
 	var dummyError = errors.New("some error")
+	var dummyCreatedAt = time.Unix(rand.Int63n(1e9), 0)
+	var dummyInstanceID = "some instance id"
+	var metricsEvents []MetricsEvent
+	var lifecycleEvents []LifecycleEvent
+	SetClock(func() time.Time { return dummyCreatedAt })
+	defer SetClock(nil)
+	SetStackCapture(true)
+	defer SetStackCapture(false)
+	SetMetricsHook(func(event MetricsEvent) { metricsEvents = append(metricsEvents, event) })
+	defer SetMetricsHook(nil)
+	var handle = RegisterObserver(func(event LifecycleEvent) {})
+	defer handle.Unregister()
 
 	// mock
 	createMock(t)
 
 	// expect
+	runtimeCallersExpected = 1
+	runtimeCallers = func(skip int, pc []uintptr) int {
+		runtimeCallersCalled++
+		pc[0], pc[1], pc[2] = 1, 2, 3
+		return 3
+	}
+	newInstanceIDFuncExpected = 1
+	newInstanceIDFunc = func() string {
+		newInstanceIDFuncCalled++
+		return dummyInstanceID
+	}
 	fmtErrorfExpected = 1
 	fmtErrorf = func(format string, a ...interface{}) error {
 		fmtErrorfCalled++
 		assert.Equal(t, dummyMessageFormat, format)
-		assert.Equal(t, 3, len(a))
-		assert.Equal(t, dummyParameter1, a[0])
-		assert.Equal(t, dummyParameter2, a[1])
-		assert.Equal(t, dummyParameter3, a[2])
+		assert.Equal(t, []interface{}{dummyParameter1, dummyParameter2, dummyParameter3}, a)
 		return dummyError
 	}
+	callObserverFuncExpected = 1
+	callObserverFunc = func(observer Observer, event LifecycleEvent) {
+		callObserverFuncCalled++
+		lifecycleEvents = append(lifecycleEvents, event)
+	}
 
 	// SUT + act
 	var err = NewBaseAppError(
@@ -45,8 +72,177 @@ func TestNewBaseAppError(t *testing.T) {
 	// assert
 	assert.Equal(t, dummyError, err.error)
 	assert.Equal(t, dummyCode, err.code)
-	assert.Empty(t, err.innerErrors)
-	assert.Empty(t, err.extraData)
+	assert.Equal(t, dummyMessageFormat, err.messageFormat)
+	assert.Nil(t, err.innerErrors)
+	assert.Nil(t, err.extraData)
+	assert.Equal(t, dummyCreatedAt, err.details.createdAt)
+	assert.NotZero(t, err.details.sequence)
+	assert.Equal(t, []uintptr{1, 2, 3}, err.details.stack)
+	assert.Equal(t, dummyInstanceID, err.InstanceID())
+	assert.Equal(t, []MetricsEvent{{Kind: MetricsEventCreated, Code: dummyCode, HTTPStatusCode: dummyCode.HTTPStatusCode()}}, metricsEvents)
+	assert.Equal(t, []LifecycleEvent{{Kind: LifecycleEventCreated, Error: err}}, lifecycleEvents)
+
+	// verify
+	verifyAll(t)
+}
+
+func TestSetMessage_WithParameters(t *testing.T) {
+	// arrange
+	var dummyMessageFormat = "some format"
+	var dummyParameter1 = "some parameter 1"
+	var dummyParameter2 = rand.Int()
+	var dummyError = errors.New("some error")
+	var dummyBaseAppError = &BaseAppError{}
+
+	// mock
+	createMock(t)
+
+	// expect
+	fmtErrorfExpected = 1
+	fmtErrorf = func(format string, a ...interface{}) error {
+		fmtErrorfCalled++
+		assert.Equal(t, dummyMessageFormat, format)
+		assert.Equal(t, 2, len(a))
+		assert.Equal(t, dummyParameter1, a[0])
+		assert.Equal(t, dummyParameter2, a[1])
+		return dummyError
+	}
+
+	// SUT + act
+	setMessage(
+		dummyBaseAppError,
+		dummyMessageFormat,
+		[]interface{}{
+			dummyParameter1,
+			dummyParameter2,
+		},
+	)
+
+	// assert
+	assert.Equal(t, dummyError, dummyBaseAppError.error)
+	assert.Zero(t, dummyBaseAppError.message)
+
+	// verify
+	verifyAll(t)
+}
+
+func TestSetMessage_WithVerbs(t *testing.T) {
+	// arrange
+	var dummyMessageFormat = "some 100%% format"
+	var dummyError = errors.New("some error")
+	var dummyBaseAppError = &BaseAppError{}
+
+	// mock
+	createMock(t)
+
+	// expect
+	stringsContainsRuneExpected = 1
+	stringsContainsRune = func(s string, r rune) bool {
+		stringsContainsRuneCalled++
+		assert.Equal(t, dummyMessageFormat, s)
+		assert.Equal(t, '%', r)
+		return true
+	}
+	fmtErrorfExpected = 1
+	fmtErrorf = func(format string, a ...interface{}) error {
+		fmtErrorfCalled++
+		assert.Equal(t, dummyMessageFormat, format)
+		assert.Empty(t, a)
+		return dummyError
+	}
+
+	// SUT + act
+	setMessage(
+		dummyBaseAppError,
+		dummyMessageFormat,
+		nil,
+	)
+
+	// assert
+	assert.Equal(t, dummyError, dummyBaseAppError.error)
+	assert.Zero(t, dummyBaseAppError.message)
+
+	// verify
+	verifyAll(t)
+}
+
+func TestSetMessage_PlainMessage(t *testing.T) {
+	// arrange
+	var dummyMessageFormat = "some format"
+	var dummyBaseAppError = &BaseAppError{}
+
+	// mock
+	createMock(t)
+
+	// expect
+	stringsContainsRuneExpected = 1
+	stringsContainsRune = func(s string, r rune) bool {
+		stringsContainsRuneCalled++
+		assert.Equal(t, dummyMessageFormat, s)
+		assert.Equal(t, '%', r)
+		return false
+	}
+
+	// SUT + act
+	setMessage(
+		dummyBaseAppError,
+		dummyMessageFormat,
+		nil,
+	)
+
+	// assert
+	assert.Equal(t, messageText(dummyMessageFormat), dummyBaseAppError.message)
+	assert.Same(t, &dummyBaseAppError.message, dummyBaseAppError.error)
+	assert.Equal(t, dummyMessageFormat, dummyBaseAppError.error.Error())
+
+	// verify
+	verifyAll(t)
+}
+
+func TestFormatExtraDataValue_String(t *testing.T) {
+	// arrange
+	var dummyValue = "some value"
+
+	// mock
+	createMock(t)
+
+	// SUT + act
+	var result = formatExtraDataValue(
+		dummyValue,
+	)
+
+	// assert
+	assert.Equal(t, dummyValue, result)
+
+	// verify
+	verifyAll(t)
+}
+
+func TestFormatExtraDataValue_Other(t *testing.T) {
+	// arrange
+	var dummyValue = rand.Int()
+	var dummyResult = "some result"
+
+	// mock
+	createMock(t)
+
+	// expect
+	fmtSprintfExpected = 1
+	fmtSprintf = func(format string, a ...interface{}) string {
+		fmtSprintfCalled++
+		assert.Equal(t, "%+v", format)
+		assert.Equal(t, 1, len(a))
+		assert.Equal(t, dummyValue, a[0])
+		return dummyResult
+	}
+
+	// SUT + act
+	var result = formatExtraDataValue(
+		dummyValue,
+	)
+
+	// assert
+	assert.Equal(t, dummyResult, result)
 
 	// verify
 	verifyAll(t)
@@ -103,48 +299,21 @@ func TestFormatExtraData_WithExtraData(t *testing.T) {
 		dummyName2: dummyValue2,
 		dummyName3: dummyValue3,
 	}
-	var dummyMessage1 = "some message 1"
-	var dummyMessage2 = "some message 2"
-	var dummyMessage3 = "some message 3"
-	var dummyJoinedMessage = "some joined message"
-	var dummyResult = "some result"
+	var dummyValueTexts = map[interface{}]string{
+		dummyValue1: "some message 1",
+		dummyValue2: "some message 2",
+		dummyValue3: "some message 3",
+	}
 
 	// mock
 	createMock(t)
 
 	// expect
-	fmtSprintfExpected = 4
-	fmtSprintf = func(format string, a ...interface{}) string {
-		fmtSprintfCalled++
-		if fmtSprintfCalled < 4 {
-			assert.Equal(t, errorExtraDataFormat, format)
-			assert.Equal(t, 2, len(a))
-			if dummyName1 == a[0] {
-				assert.Equal(t, dummyValue1, a[1])
-				return dummyMessage1
-			} else if dummyName2 == a[0] {
-				assert.Equal(t, dummyValue2, a[1])
-				return dummyMessage2
-			} else if dummyName3 == a[0] {
-				assert.Equal(t, dummyValue3, a[1])
-				return dummyMessage3
-			}
-		} else if fmtSprintfCalled == 4 {
-			assert.Equal(t, errorJoiningFormat, format)
-			assert.Equal(t, 1, len(a))
-			assert.Equal(t, dummyJoinedMessage, a[0])
-			return dummyResult
-		}
-		return ""
-	}
-	stringsJoinExpected = 1
-	stringsJoin = func(a []string, sep string) string {
-		stringsJoinCalled++
-		assert.Contains(t, a, dummyMessage1)
-		assert.Contains(t, a, dummyMessage2)
-		assert.Contains(t, a, dummyMessage3)
-		assert.Equal(t, errorSeparator, sep)
-		return dummyJoinedMessage
+	formatExtraDataValueFuncExpected = 3
+	formatExtraDataValueFunc = func(value interface{}) string {
+		formatExtraDataValueFuncCalled++
+		assert.Contains(t, dummyValueTexts, value)
+		return dummyValueTexts[value]
 	}
 
 	// SUT + act
@@ -153,7 +322,46 @@ func TestFormatExtraData_WithExtraData(t *testing.T) {
 	)
 
 	// assert
-	assert.Equal(t, dummyResult, result)
+	assert.True(t, strings.HasPrefix(result, " [ "))
+	assert.True(t, strings.HasSuffix(result, " ]"))
+	assert.ElementsMatch(
+		t,
+		[]string{
+			"some name 1 = some message 1",
+			"some name 2 = some message 2",
+			"some name 3 = some message 3",
+		},
+		strings.Split(
+			strings.TrimSuffix(strings.TrimPrefix(result, " [ "), " ]"),
+			" | ",
+		),
+	)
+
+	// verify
+	verifyAll(t)
+}
+
+func TestBaseAppError_PrintError_NilError(t *testing.T) {
+	// arrange
+	var dummyCode = CodeNotFound
+
+	// mock
+	createMock(t)
+
+	// expect
+
+	// SUT
+	var sut = &BaseAppError{}
+
+	// act
+	var result = sut.PrintError(
+		dummyCode,
+		nil,
+		nil,
+	)
+
+	// assert
+	assert.Equal(t, "(NotFound) <nil>", result)
 
 	// verify
 	verifyAll(t)
@@ -161,34 +369,29 @@ func TestFormatExtraData_WithExtraData(t *testing.T) {
 
 func TestBaseAppError_PrintError(t *testing.T) {
 	// arrange
-	var dummyCode = Code(rand.Intn(100))
+	var dummyCode = CodeNotFound
 	var dummyError = errors.New("some error")
 	var dummyExtraData = map[string]interface{}{
-		"foo":  "bar",
-		"test": rand.Int(),
+		"foo": rand.Int(),
 	}
-	var dummyExtraDataMessage = "some extra data message"
-	var dummyResult = "some result"
+	var dummyMessage = "some message"
+	var dummyResult = "(NotFound) some message [ foo = some value ]"
 
 	// mock
 	createMock(t)
 
 	// expect
-	formatExtraDataFuncExpected = 1
-	formatExtraDataFunc = func(extraData map[string]interface{}) string {
-		formatExtraDataFuncCalled++
-		assert.Equal(t, dummyExtraData, extraData)
-		return dummyExtraDataMessage
+	formatExtraDataValueFuncExpected = 1
+	formatExtraDataValueFunc = func(value interface{}) string {
+		formatExtraDataValueFuncCalled++
+		assert.Equal(t, dummyExtraData["foo"], value)
+		return "some value"
 	}
-	fmtSprintfExpected = 1
-	fmtSprintf = func(format string, a ...interface{}) string {
-		fmtSprintfCalled++
-		assert.Equal(t, errorMessageFormat, format)
-		assert.Equal(t, 3, len(a))
-		assert.Equal(t, dummyCode, a[0])
-		assert.Equal(t, dummyError, a[1])
-		assert.Equal(t, dummyExtraDataMessage, a[2])
-		return dummyResult
+	getErrorMessageFuncExpected = 1
+	getErrorMessageFunc = func(err error) string {
+		getErrorMessageFuncCalled++
+		assert.Equal(t, dummyError, err)
+		return dummyMessage
 	}
 
 	// SUT
@@ -210,42 +413,36 @@ func TestBaseAppError_PrintError(t *testing.T) {
 
 func TestPrintBaseAppError_HappyPath(t *testing.T) {
 	// arrange
-	var dummyCode = Code(rand.Intn(100))
+	var dummyCode = CodeNotFound
 	var dummyError = errors.New("some error")
 	var dummyExtraData = map[string]interface{}{
-		"foo":  "bar",
-		"test": rand.Int(),
+		"foo": rand.Int(),
 	}
-	var dummyExtraDataMessage = "some extra data message"
-	var dummyResult = "some result"
+	var dummyResult = "(NotFound) some error [ foo = some value ]"
 
 	// mock
 	createMock(t)
 
 	// expect
-	formatExtraDataFuncExpected = 1
-	formatExtraDataFunc = func(extraData map[string]interface{}) string {
-		formatExtraDataFuncCalled++
-		assert.Equal(t, dummyExtraData, extraData)
-		return dummyExtraDataMessage
+	formatExtraDataValueFuncExpected = 1
+	formatExtraDataValueFunc = func(value interface{}) string {
+		formatExtraDataValueFuncCalled++
+		assert.Equal(t, dummyExtraData["foo"], value)
+		return "some value"
 	}
-	fmtSprintfExpected = 1
-	fmtSprintf = func(format string, a ...interface{}) string {
-		fmtSprintfCalled++
-		assert.Equal(t, errorMessageFormat, format)
-		assert.Equal(t, 3, len(a))
-		assert.Equal(t, dummyCode, a[0])
-		assert.Equal(t, dummyError, a[1])
-		assert.Equal(t, dummyExtraDataMessage, a[2])
-		return dummyResult
+	getErrorMessageFuncExpected = 1
+	getErrorMessageFunc = func(err error) string {
+		getErrorMessageFuncCalled++
+		assert.Equal(t, dummyError, err)
+		return err.Error()
 	}
 
 	// SUT
 	var sut = &BaseAppError{
-		dummyError,
-		dummyCode,
-		nil,
-		dummyExtraData,
+		error:       dummyError,
+		code:        dummyCode,
+		innerErrors: nil,
+		extraData:   dummyExtraData,
 	}
 
 	// act
@@ -253,6 +450,40 @@ func TestPrintBaseAppError_HappyPath(t *testing.T) {
 		sut,
 	)
 
+	// assert
+	assert.Equal(t, dummyResult, result)
+	assert.Equal(t, &printedText{isEscaped: false, generation: codeGeneration.Load(), text: dummyResult}, sut.getCache().printed.Load())
+
+	// verify
+	verifyAll(t)
+}
+
+func TestPrintBaseAppError_Cached(t *testing.T) {
+	// arrange
+	var dummyResult = "some result"
+	SetPrintMode(PrintModeEscaped)
+	defer SetPrintMode(PrintModeLegacy)
+
+	// mock
+	createMock(t)
+
+	// expect
+
+	// SUT
+	var sut = &BaseAppError{}
+	sut.getCache().printed.Store(
+		&printedText{
+			isEscaped:  true,
+			generation: codeGeneration.Load(),
+			text:       dummyResult,
+		},
+	)
+
+	// act
+	var result = printBaseAppError(
+		sut,
+	)
+
 	// assert
 	assert.Equal(t, dummyResult, result)
 
@@ -260,6 +491,44 @@ func TestPrintBaseAppError_HappyPath(t *testing.T) {
 	verifyAll(t)
 }
 
+func TestPrintBaseAppError_CachedInOtherPrintMode(t *testing.T) {
+	// arrange
+	var dummyError = errors.New("some error")
+
+	// mock
+	createMock(t)
+
+	// expect
+	getErrorMessageFuncExpected = 1
+	getErrorMessageFunc = func(err error) string {
+		getErrorMessageFuncCalled++
+		return err.Error()
+	}
+
+	// SUT
+	var sut = &BaseAppError{
+		error: dummyError,
+		code:  CodeBadRequest,
+	}
+	sut.getCache().printed.Store(
+		&printedText{
+			isEscaped: true,
+			text:      "some escaped result",
+		},
+	)
+
+	// act
+	var result = printBaseAppError(
+		sut,
+	)
+
+	// assert
+	assert.Equal(t, "(BadRequest) some error", result)
+
+	// verify
+	verifyAll(t)
+}
+
 func TestGetErrorMessage_NormalError(t *testing.T) {
 	// arrange
 	var dummyErrorMessage = "some error message"
@@ -280,34 +549,46 @@ func TestGetErrorMessage_NormalError(t *testing.T) {
 	verifyAll(t)
 }
 
+func TestGetErrorMessage_NilError(t *testing.T) {
+	// mock
+	createMock(t)
+
+	// SUT + act
+	var result = getErrorMessage(
+		nil,
+	)
+
+	// assert
+	assert.Equal(t, nilErrorText, result)
+
+	// verify
+	verifyAll(t)
+}
+
 func TestGetErrorMessage_BaseAppError(t *testing.T) {
 	// arrange
-	var dummyCode = Code(rand.Intn(100))
 	var dummyError = errors.New("some error")
 	var dummyInnerErrors = []error{
 		errors.New("some inner error 1"),
 		errors.New("some inner error 2"),
-		errors.New("some inner error 3"),
 	}
 	var dummyBaseAppError = &BaseAppError{
-		dummyError,
-		dummyCode,
-		dummyInnerErrors,
-		nil,
+		error:       dummyError,
+		code:        CodeNotFound,
+		innerErrors: dummyInnerErrors,
 	}
-	var dummyBaseErrorMessage = "some base error message"
-	var dummyInnerErrorMessage = "some inner error message"
-	var dummyResult = "some result"
+	var dummyInnerErrorMessage = " [ some inner error message ]"
+	var dummyResult = "(NotFound) some error [ some inner error message ]"
 
 	// mock
 	createMock(t)
 
 	// expect
-	printBaseAppErrorFuncExpected = 1
-	printBaseAppErrorFunc = func(baseAppError *BaseAppError) string {
-		printBaseAppErrorFuncCalled++
-		assert.Equal(t, dummyBaseAppError, baseAppError)
-		return dummyBaseErrorMessage
+	getErrorMessageFuncExpected = 1
+	getErrorMessageFunc = func(err error) string {
+		getErrorMessageFuncCalled++
+		assert.Equal(t, dummyError, err)
+		return err.Error()
 	}
 	printInnerErrorsFuncExpected = 1
 	printInnerErrorsFunc = func(innerErrors []error) string {
@@ -315,14 +596,6 @@ func TestGetErrorMessage_BaseAppError(t *testing.T) {
 		assert.Equal(t, dummyInnerErrors, innerErrors)
 		return dummyInnerErrorMessage
 	}
-	fmtSprintExpected = 1
-	fmtSprint = func(a ...interface{}) string {
-		fmtSprintCalled++
-		assert.Equal(t, 2, len(a))
-		assert.Equal(t, dummyBaseErrorMessage, a[0])
-		assert.Equal(t, dummyInnerErrorMessage, a[1])
-		return dummyResult
-	}
 
 	// SUT + act
 	var result = getErrorMessage(
@@ -386,135 +659,294 @@ func TestPrintInnerErrors_WithErrors(t *testing.T) {
 		"some error message 2",
 		"some error message 3",
 	}
-	var dummyJoinedMessage = "some joined message"
+	var dummyResult = " [ some error message 1 | some error message 2 | some error message 3 ]"
+
+	// mock
+	createMock(t)
+
+	// expect
+	getErrorMessageFuncExpected = len(dummyInnerErrors)
+	getErrorMessageFunc = func(err error) string {
+		getErrorMessageFuncCalled++
+		assert.Equal(t, dummyInnerErrors[getErrorMessageFuncCalled-1], err)
+		return dummyErrorMessages[getErrorMessageFuncCalled-1]
+	}
+
+	// SUT + act
+	var result = printInnerErrors(
+		dummyInnerErrors,
+	)
+
+	// assert
+	assert.Equal(t, dummyResult, result)
+
+	// verify
+	verifyAll(t)
+}
+
+func TestBaseAppError_Error(t *testing.T) {
+	// arrange
+	var dummyError = errors.New("some error")
+	var dummyInnerErrors = []error{
+		errors.New("some inner error 1"),
+		errors.New("some inner error 2"),
+	}
+	var dummyBaseAppError = &BaseAppError{
+		error:       dummyError,
+		code:        CodeNotFound,
+		innerErrors: dummyInnerErrors,
+	}
+	var dummyVersion = uint64(rand.Intn(100))
+	dummyBaseAppError.getCache().version.Store(dummyVersion)
+	var dummyInnerErrorMessage = " [ some inner error message ]"
+	var dummyResult = "(NotFound) some error [ some inner error message ]"
+
+	// mock
+	createMock(t)
+
+	// expect
+	getErrorMessageFuncExpected = 1
+	getErrorMessageFunc = func(err error) string {
+		getErrorMessageFuncCalled++
+		assert.Equal(t, dummyError, err)
+		return err.Error()
+	}
+	printInnerErrorsFuncExpected = 1
+	printInnerErrorsFunc = func(innerErrors []error) string {
+		printInnerErrorsFuncCalled++
+		assert.Equal(t, dummyInnerErrors, innerErrors)
+		return dummyInnerErrorMessage
+	}
+
+	// SUT + act
+	var result = dummyBaseAppError.Error()
+
+	// assert
+	assert.Equal(t, dummyResult, result)
+	assert.Equal(t, &printedText{isEscaped: false, generation: codeGeneration.Load(), version: dummyVersion, text: dummyResult}, dummyBaseAppError.getCache().rendered.Load())
+
+	// verify
+	verifyAll(t)
+}
+
+func TestBaseAppError_Error_Memoized(t *testing.T) {
+	// arrange
+	var dummyVersion = uint64(rand.Intn(100))
 	var dummyResult = "some result"
+	var dummyBaseAppError = &BaseAppError{}
+	dummyBaseAppError.getCache().version.Store(dummyVersion)
+	dummyBaseAppError.getCache().rendered.Store(
+		&printedText{
+			isEscaped:  true,
+			generation: codeGeneration.Load(),
+			version:    dummyVersion,
+			text:       dummyResult,
+		},
+	)
+	SetPrintMode(PrintModeEscaped)
+	defer SetPrintMode(PrintModeLegacy)
+
+	// mock
+	createMock(t)
+
+	// SUT + act
+	var result = dummyBaseAppError.Error()
+
+	// assert
+	assert.Equal(t, dummyResult, result)
+
+	// verify
+	verifyAll(t)
+}
+
+func TestBaseAppError_Error_MemoizedAtOtherVersion(t *testing.T) {
+	// arrange
+	var dummyVersion = uint64(rand.Intn(100))
+	var dummyError = errors.New("some error")
+	var dummyBaseAppError = &BaseAppError{
+		error: dummyError,
+		code:  CodeNotFound,
+	}
+	dummyBaseAppError.getCache().version.Store(dummyVersion + 1)
+	dummyBaseAppError.getCache().rendered.Store(
+		&printedText{
+			isEscaped: false,
+			version:   dummyVersion,
+			text:      "some stale result",
+		},
+	)
+
+	// mock
+	createMock(t)
+
+	// expect
+	getErrorMessageFuncExpected = 1
+	getErrorMessageFunc = func(err error) string {
+		getErrorMessageFuncCalled++
+		return err.Error()
+	}
+	printInnerErrorsFuncExpected = 1
+	printInnerErrorsFunc = func(innerErrors []error) string {
+		printInnerErrorsFuncCalled++
+		return ""
+	}
+
+	// SUT + act
+	var result = dummyBaseAppError.Error()
+
+	// assert
+	assert.Equal(t, "(NotFound) some error", result)
+	assert.Equal(t, dummyVersion+1, dummyBaseAppError.getCache().rendered.Load().version)
+
+	// verify
+	verifyAll(t)
+}
+
+func TestBaseAppError_Code(t *testing.T) {
+	// arrange
+	var expectedError = errors.New("dummy error")
+	var expectedCode = CodeGeneralFailure
+	var expectedInnerError1 = errors.New("dummy inner error 1")
+	var expectedInnerError2 = errors.New("dummy inner error 2")
+	var expectedInnerError3 = errors.New("dummy inner error 3")
+	var expectedInnerErrors = []error{
+		expectedInnerError1,
+		expectedInnerError2,
+		expectedInnerError3,
+	}
+	var expectedExtraData = map[string]interface{}{
+		"foo":   "bar",
+		"test":  123,
+		"error": errors.New("me"),
+	}
+
+	// mock
+	createMock(t)
+
+	// SUT
+	var baseAppError = &BaseAppError{
+		error:       expectedError,
+		code:        expectedCode,
+		innerErrors: expectedInnerErrors,
+		extraData:   expectedExtraData,
+	}
+
+	// act
+	var code = baseAppError.Code()
+
+	// assert
+	assert.Equal(t, expectedCode.String(), code)
+
+	// verify
+	verifyAll(t)
+}
+
+func TestBaseAppError_CodeEnum(t *testing.T) {
+	// arrange
+	var dummyCode = Code(rand.Intn(100))
+
+	// mock
+	createMock(t)
+
+	// SUT
+	var sut = &BaseAppError{
+		code: dummyCode,
+	}
+
+	// act
+	var result = sut.CodeEnum()
+
+	// assert
+	assert.Equal(t, dummyCode, result)
+
+	// verify
+	verifyAll(t)
+}
+
+func TestBaseAppError_Message(t *testing.T) {
+	// arrange
+	var dummyError = errors.New("some error")
+	var dummyMessage = "some message"
 
 	// mock
 	createMock(t)
 
 	// expect
-	getErrorMessageFuncExpected = len(dummyInnerErrors)
+	getErrorMessageFuncExpected = 1
 	getErrorMessageFunc = func(err error) string {
 		getErrorMessageFuncCalled++
-		assert.Equal(t, dummyInnerErrors[getErrorMessageFuncCalled-1], err)
-		return dummyErrorMessages[getErrorMessageFuncCalled-1]
-	}
-	stringsJoinExpected = 1
-	stringsJoin = func(a []string, sep string) string {
-		stringsJoinCalled++
-		assert.ElementsMatch(t, dummyErrorMessages, a)
-		assert.Equal(t, errorSeparator, sep)
-		return dummyJoinedMessage
+		assert.Equal(t, dummyError, err)
+		return dummyMessage
 	}
-	fmtSprintfExpected = 1
-	fmtSprintf = func(format string, a ...interface{}) string {
-		fmtSprintfCalled++
-		assert.Equal(t, errorJoiningFormat, format)
-		assert.Equal(t, 1, len(a))
-		assert.Equal(t, dummyJoinedMessage, a[0])
-		return dummyResult
+
+	// SUT
+	var sut = &BaseAppError{
+		error: dummyError,
 	}
 
-	// SUT + act
-	var result = printInnerErrors(
-		dummyInnerErrors,
-	)
+	// act
+	var result = sut.Message()
 
 	// assert
-	assert.Equal(t, dummyResult, result)
+	assert.Equal(t, dummyMessage, result)
 
 	// verify
 	verifyAll(t)
 }
 
-func TestBaseAppError_Error(t *testing.T) {
-	// arrange
-	var dummyCode = Code(rand.Intn(100))
-	var dummyError = errors.New("some error")
-	var dummyInnerErrors = []error{
-		errors.New("some inner error 1"),
-		errors.New("some inner error 2"),
-		errors.New("some inner error 3"),
-	}
-	var dummyBaseAppError = &BaseAppError{
-		dummyError,
-		dummyCode,
-		dummyInnerErrors,
-		nil,
-	}
-	var dummyBaseErrorMessage = "some base error message"
-	var dummyInnerErrorMessage = "some inner error message"
-	var dummyResult = "some result"
-
+func TestBaseAppError_MessageTemplate(t *testing.T) {
 	// mock
 	createMock(t)
 
-	// expect
-	printBaseAppErrorFuncExpected = 1
-	printBaseAppErrorFunc = func(baseAppError *BaseAppError) string {
-		printBaseAppErrorFuncCalled++
-		assert.Equal(t, dummyBaseAppError, baseAppError)
-		return dummyBaseErrorMessage
-	}
-	printInnerErrorsFuncExpected = 1
-	printInnerErrorsFunc = func(innerErrors []error) string {
-		printInnerErrorsFuncCalled++
-		assert.Equal(t, dummyInnerErrors, innerErrors)
-		return dummyInnerErrorMessage
-	}
-	fmtSprintExpected = 1
-	fmtSprint = func(a ...interface{}) string {
-		fmtSprintCalled++
-		assert.Equal(t, 2, len(a))
-		assert.Equal(t, dummyBaseErrorMessage, a[0])
-		assert.Equal(t, dummyInnerErrorMessage, a[1])
-		return dummyResult
+	// SUT
+	var sut = &BaseAppError{
+		messageFormat: "some format %v",
+		error:         errors.New("some format 1"),
 	}
 
-	// SUT + act
-	var result = dummyBaseAppError.Error()
+	// act
+	var result = sut.MessageTemplate()
 
 	// assert
-	assert.Equal(t, dummyResult, result)
+	assert.Equal(t, "some format %v", result)
 
 	// verify
 	verifyAll(t)
 }
 
-func TestBaseAppError_Code(t *testing.T) {
+func TestBaseAppError_MessageTemplate_Nil(t *testing.T) {
+	// SUT
+	var sut *BaseAppError
+
+	// act
+	var result = sut.MessageTemplate()
+
+	// assert
+	assert.Empty(t, result)
+}
+
+func TestBaseAppError_InnerErrors(t *testing.T) {
 	// arrange
-	var expectedError = errors.New("dummy error")
-	var expectedCode = CodeGeneralFailure
-	var expectedInnerError1 = errors.New("dummy inner error 1")
-	var expectedInnerError2 = errors.New("dummy inner error 2")
-	var expectedInnerError3 = errors.New("dummy inner error 3")
-	var expectedInnerErrors = []error{
-		expectedInnerError1,
-		expectedInnerError2,
-		expectedInnerError3,
-	}
-	var expectedExtraData = map[string]interface{}{
-		"foo":   "bar",
-		"test":  123,
-		"error": errors.New("me"),
+	var dummyInnerErrors = []error{
+		errors.New("some inner error 1"),
+		errors.New("some inner error 2"),
 	}
 
 	// mock
 	createMock(t)
 
 	// SUT
-	var baseAppError = &BaseAppError{
-		expectedError,
-		expectedCode,
-		expectedInnerErrors,
-		expectedExtraData,
+	var sut = &BaseAppError{
+		innerErrors: dummyInnerErrors,
 	}
 
 	// act
-	var code = baseAppError.Code()
+	var result = sut.InnerErrors()
+	result[0] = nil
 
 	// assert
-	assert.Equal(t, expectedCode.String(), code)
+	assert.Equal(t, []error{nil, dummyInnerErrors[1]}, result)
+	assert.NotNil(t, sut.innerErrors[0])
 
 	// verify
 	verifyAll(t)
@@ -543,10 +975,10 @@ func TestBaseAppError_HTTPStatusCode(t *testing.T) {
 
 	// SUT
 	var baseAppError = &BaseAppError{
-		expectedError,
-		expectedCode,
-		expectedInnerErrors,
-		expectedExtraData,
+		error:       expectedError,
+		code:        expectedCode,
+		innerErrors: expectedInnerErrors,
+		extraData:   expectedExtraData,
 	}
 
 	// act
@@ -640,27 +1072,79 @@ func TestAppErrorContains_HappyPath(t *testing.T) {
 	var dummyBaseAppError = &BaseAppError{
 		error: dummyError,
 	}
-
-	// mock
-	createMock(t)
-
-	// expect
-	equalsErrorFuncExpected = 1
-	equalsErrorFunc = func(err, target error) bool {
-		equalsErrorFuncCalled++
-		assert.Equal(t, dummyBaseAppError.error, err)
+	var dummyMatcherExpected = 1
+	var dummyMatcherCalled = 0
+	var dummyMatcher = func(err, target error) bool {
+		dummyMatcherCalled++
+		assert.Equal(t, dummyBaseAppError, err)
 		assert.Equal(t, dummyError, target)
 		return true
 	}
 
+	// mock
+	createMock(t)
+
 	// SUT + act
 	var result = appErrorContains(
 		dummyBaseAppError,
 		dummyError,
+		dummyMatcher,
 	)
 
 	// assert
 	assert.True(t, result)
+	assert.Equal(t, dummyMatcherExpected, dummyMatcherCalled)
+
+	// verify
+	verifyAll(t)
+}
+
+// dummyLegacyAppError implements the AppError interface as it was before the optional typed attribute, derivation and matching methods, as outside implementations do
+type dummyLegacyAppError struct {
+	contained error
+}
+
+func (err dummyLegacyAppError) Error() string { return "some legacy error" }
+func (err dummyLegacyAppError) PrintError(code Code, e error, extraData map[string]interface{}) string {
+	return ""
+}
+func (err dummyLegacyAppError) Code() string                          { return "NotFound" }
+func (err dummyLegacyAppError) HTTPStatusCode() int                   { return 404 }
+func (err dummyLegacyAppError) Contains(target error) bool            { return target == err.contained }
+func (err dummyLegacyAppError) Wrap(innerErrors ...error)             {}
+func (err dummyLegacyAppError) Attach(name string, value interface{}) {}
+
+func TestAppErrorContains_LegacyAppError(t *testing.T) {
+	// arrange
+	var dummyContainedError = errors.New("some contained error")
+	var dummyOtherError = errors.New("some other error")
+	var dummyAppError AppError = dummyLegacyAppError{dummyContainedError}
+	var dummyMatcherCalled = 0
+	var dummyMatcher = func(err, target error) bool {
+		dummyMatcherCalled++
+		assert.Equal(t, dummyAppError, err)
+		return false
+	}
+
+	// mock
+	createMock(t)
+
+	// SUT + act
+	var result1 = appErrorContains(
+		dummyAppError,
+		dummyContainedError,
+		dummyMatcher,
+	)
+	var result2 = appErrorContains(
+		dummyAppError,
+		dummyOtherError,
+		dummyMatcher,
+	)
+
+	// assert
+	assert.True(t, result1)
+	assert.False(t, result2)
+	assert.Equal(t, 2, dummyMatcherCalled)
 
 	// verify
 	verifyAll(t)
@@ -678,6 +1162,7 @@ func TestInnerErrorContains_NilInnerErrors(t *testing.T) {
 	var result = innerErrorContains(
 		dummyInnerErrors,
 		dummyError,
+		MatchIdentity,
 	)
 
 	// assert
@@ -699,6 +1184,7 @@ func TestInnerErrorContains_EmptyInnerErrors(t *testing.T) {
 	var result = innerErrorContains(
 		dummyInnerErrors,
 		dummyError,
+		MatchIdentity,
 	)
 
 	// assert
@@ -715,27 +1201,28 @@ func TestInnerErrorContains_NormalInnerError(t *testing.T) {
 		dummyInnerError,
 	}
 	var dummyError = errors.New("some error")
-
-	// mock
-	createMock(t)
-
-	// expect
-	equalsErrorFuncExpected = 1
-	equalsErrorFunc = func(err, target error) bool {
-		equalsErrorFuncCalled++
+	var dummyMatcherExpected = 1
+	var dummyMatcherCalled = 0
+	var dummyMatcher = func(err, target error) bool {
+		dummyMatcherCalled++
 		assert.Equal(t, dummyInnerError, err)
 		assert.Equal(t, dummyError, target)
 		return true
 	}
 
+	// mock
+	createMock(t)
+
 	// SUT + act
 	var result = innerErrorContains(
 		dummyInnerErrors,
 		dummyError,
+		dummyMatcher,
 	)
 
 	// assert
 	assert.True(t, result)
+	assert.Equal(t, dummyMatcherExpected, dummyMatcherCalled)
 
 	// verify
 	verifyAll(t)
@@ -756,10 +1243,11 @@ func TestInnerErrorContains_BaseAppErrorInnerError(t *testing.T) {
 
 	// expect
 	appErrorContainsFuncExpected = 1
-	appErrorContainsFunc = func(appError AppError, err error) bool {
+	appErrorContainsFunc = func(appError AppError, err error, matcher Matcher) bool {
 		appErrorContainsFuncCalled++
 		assert.Equal(t, dummyInnerError, appError)
 		assert.Equal(t, dummyError, err)
+		assert.NotNil(t, matcher)
 		return true
 	}
 
@@ -767,6 +1255,7 @@ func TestInnerErrorContains_BaseAppErrorInnerError(t *testing.T) {
 	var result = innerErrorContains(
 		dummyInnerErrors,
 		dummyError,
+		MatchIdentity,
 	)
 
 	// assert
@@ -787,54 +1276,128 @@ func TestInnerErrorContains_NoMatchingErrors(t *testing.T) {
 		dummyInnerError2,
 	}
 	var dummyError = errors.New("some error")
+	var dummyMatcherExpected = 1
+	var dummyMatcherCalled = 0
+	var dummyMatcher = func(err, target error) bool {
+		dummyMatcherCalled++
+		assert.Equal(t, dummyInnerError2, err)
+		assert.Equal(t, dummyError, target)
+		return false
+	}
 
 	// mock
 	createMock(t)
 
 	// expect
 	appErrorContainsFuncExpected = 1
-	appErrorContainsFunc = func(appError AppError, err error) bool {
+	appErrorContainsFunc = func(appError AppError, err error, matcher Matcher) bool {
 		appErrorContainsFuncCalled++
 		assert.Equal(t, dummyInnerError1, appError)
 		assert.Equal(t, dummyError, err)
 		return false
 	}
-	equalsErrorFuncExpected = 1
-	equalsErrorFunc = func(err, target error) bool {
-		equalsErrorFuncCalled++
-		assert.Equal(t, dummyInnerError2, err)
-		assert.Equal(t, dummyError, target)
-		return false
-	}
 
 	// SUT + act
 	var result = innerErrorContains(
 		dummyInnerErrors,
 		dummyError,
+		dummyMatcher,
 	)
 
 	// assert
 	assert.False(t, result)
+	assert.Equal(t, dummyMatcherExpected, dummyMatcherCalled)
 
 	// verify
 	verifyAll(t)
 }
 
-func TestBaseAppError_Contains_DirectEqual(t *testing.T) {
+func TestBaseAppError_Contains_DefaultMatcher(t *testing.T) {
 	// arrange
+	var dummyInnerErrors = []error{
+		errors.New("some inner error"),
+	}
 	var dummyBaseAppError = &BaseAppError{
-		error: errors.New("some base app error"),
+		error:       errors.New("some base app error"),
+		innerErrors: dummyInnerErrors,
 	}
+	var dummyError = errors.New("some error")
+	var dummyResult = rand.Intn(100) > 50
 
 	// mock
 	createMock(t)
 
+	// expect
+	errorsIsExpected = 2
+	errorsIs = func(err, target error) bool {
+		errorsIsCalled++
+		if errorsIsCalled == 1 {
+			assert.Equal(t, dummyBaseAppError, err)
+		} else {
+			assert.Equal(t, dummyBaseAppError.error, err)
+		}
+		assert.Equal(t, dummyError, target)
+		return false
+	}
+	innerErrorContainsFuncExpected = 1
+	innerErrorContainsFunc = func(innerErrors []error, err error, matcher Matcher) bool {
+		innerErrorContainsFuncCalled++
+		assert.Equal(t, dummyInnerErrors, innerErrors)
+		assert.Equal(t, dummyError, err)
+		return dummyResult
+	}
+
 	// SUT
 	var sut = dummyBaseAppError
 
 	// act
 	var result = sut.Contains(
+		dummyError,
+	)
+
+	// assert
+	assert.Equal(t, dummyResult, result)
+
+	// verify
+	verifyAll(t)
+}
+
+func TestBaseAppError_ContainsMatch_NilError(t *testing.T) {
+	// mock
+	createMock(t)
+
+	// SUT
+	var sut = &BaseAppError{}
+
+	// act
+	var result = sut.ContainsMatch(
+		nil,
+		MatchLegacy,
+	)
+
+	// assert
+	assert.False(t, result)
+
+	// verify
+	verifyAll(t)
+}
+
+func TestBaseAppError_ContainsMatch_DirectEqual(t *testing.T) {
+	// arrange
+	var dummyBaseAppError = &BaseAppError{
+		error: errors.New("some base app error"),
+	}
+
+	// mock
+	createMock(t)
+
+	// SUT
+	var sut = dummyBaseAppError
+
+	// act
+	var result = sut.ContainsMatch(
 		dummyBaseAppError,
+		nil,
 	)
 
 	// assert
@@ -844,41 +1407,76 @@ func TestBaseAppError_Contains_DirectEqual(t *testing.T) {
 	verifyAll(t)
 }
 
-func TestBaseAppError_Contains_ErrorEqual(t *testing.T) {
+func TestBaseAppError_ContainsMatch_AppErrorMatch(t *testing.T) {
 	// arrange
 	var dummyBaseAppError = &BaseAppError{
 		error: errors.New("some base app error"),
 	}
 	var dummyError = errors.New("some error")
+	var dummyMatcherExpected = 1
+	var dummyMatcherCalled = 0
+	var dummyMatcher = func(err, target error) bool {
+		dummyMatcherCalled++
+		assert.Equal(t, dummyBaseAppError, err)
+		assert.Equal(t, dummyError, target)
+		return true
+	}
 
 	// mock
 	createMock(t)
 
-	// expect
-	equalsErrorFuncExpected = 1
-	equalsErrorFunc = func(err, target error) bool {
-		equalsErrorFuncCalled++
-		assert.Equal(t, dummyBaseAppError.error, err)
+	// SUT
+	var sut = dummyBaseAppError
+
+	// act
+	var result = sut.ContainsMatch(
+		dummyError,
+		dummyMatcher,
+	)
+
+	// assert
+	assert.True(t, result)
+	assert.Equal(t, dummyMatcherExpected, dummyMatcherCalled)
+
+	// verify
+	verifyAll(t)
+}
+
+func TestBaseAppError_ContainsMatch_ErrorMatch(t *testing.T) {
+	// arrange
+	var dummyBaseAppError = &BaseAppError{
+		error: errors.New("some base app error"),
+	}
+	var dummyError = errors.New("some error")
+	var dummyMatcherExpected = 2
+	var dummyMatcherCalled = 0
+	var dummyMatcher = func(err, target error) bool {
+		dummyMatcherCalled++
 		assert.Equal(t, dummyError, target)
-		return true
+		return err == dummyBaseAppError.error
 	}
 
+	// mock
+	createMock(t)
+
 	// SUT
 	var sut = dummyBaseAppError
 
 	// act
-	var result = sut.Contains(
+	var result = sut.ContainsMatch(
 		dummyError,
+		dummyMatcher,
 	)
 
 	// assert
 	assert.True(t, result)
+	assert.Equal(t, dummyMatcherExpected, dummyMatcherCalled)
 
 	// verify
 	verifyAll(t)
 }
 
-func TestBaseAppError_Contains_InnerErrorEqual(t *testing.T) {
+func TestBaseAppError_ContainsMatch_InnerErrorMatch(t *testing.T) {
 	// arrange
 	var dummyInnerErrors = []error{
 		errors.New("some inner error 1"),
@@ -891,22 +1489,22 @@ func TestBaseAppError_Contains_InnerErrorEqual(t *testing.T) {
 	}
 	var dummyError = errors.New("some error")
 	var dummyResult = rand.Intn(100) > 50
+	var dummyMatcherExpected = 2
+	var dummyMatcherCalled = 0
+	var dummyMatcher = func(err, target error) bool {
+		dummyMatcherCalled++
+		return false
+	}
 
 	// mock
 	createMock(t)
 
 	// expect
-	equalsErrorFuncExpected = 1
-	equalsErrorFunc = func(err, target error) bool {
-		equalsErrorFuncCalled++
-		assert.Equal(t, dummyBaseAppError.error, err)
-		assert.Equal(t, dummyError, target)
-		return false
-	}
 	innerErrorContainsFuncExpected = 1
-	innerErrorContainsFunc = func(innerErrors []error, err error) bool {
+	innerErrorContainsFunc = func(innerErrors []error, err error, matcher Matcher) bool {
 		innerErrorContainsFuncCalled++
 		assert.Equal(t, dummyInnerErrors, innerErrors)
+		assert.Equal(t, dummyError, err)
 		return dummyResult
 	}
 
@@ -914,12 +1512,14 @@ func TestBaseAppError_Contains_InnerErrorEqual(t *testing.T) {
 	var sut = dummyBaseAppError
 
 	// act
-	var result = sut.Contains(
+	var result = sut.ContainsMatch(
 		dummyError,
+		dummyMatcher,
 	)
 
 	// assert
 	assert.Equal(t, dummyResult, result)
+	assert.Equal(t, dummyMatcherExpected, dummyMatcherCalled)
 
 	// verify
 	verifyAll(t)
@@ -974,6 +1574,13 @@ func TestCleanupInnerErrors_NoValidInnerErrors(t *testing.T) {
 	// mock
 	createMock(t)
 
+	// expect
+	isNilFuncExpected = 3
+	isNilFunc = func(err error) bool {
+		isNilFuncCalled++
+		return err == nil
+	}
+
 	// SUT + act
 	var result = cleanupInnerErrors(
 		dummyInnerErrors,
@@ -1002,6 +1609,13 @@ func TestCleanupInnerErrors_HasValidInnerErrors(t *testing.T) {
 	// mock
 	createMock(t)
 
+	// expect
+	isNilFuncExpected = 5
+	isNilFunc = func(err error) bool {
+		isNilFuncCalled++
+		return err == nil
+	}
+
 	// SUT + act
 	var result = cleanupInnerErrors(
 		dummyInnerErrors,
@@ -1026,10 +1640,10 @@ func TestAppErrorWrap_NoInnerError(t *testing.T) {
 	var dummyInnerMostErrorMessage = "dummy inner most error"
 	var expectedInnerError1 = errors.New("dummy inner error 1")
 	var expectedInnerError2 = &BaseAppError{
-		errors.New(dummyInnerErrorMessage),
-		CodeGeneralFailure,
-		[]error{errors.New(dummyInnerMostErrorMessage)},
-		nil,
+		error:       errors.New(dummyInnerErrorMessage),
+		code:        CodeGeneralFailure,
+		innerErrors: []error{errors.New(dummyInnerMostErrorMessage)},
+		extraData:   nil,
 	}
 	var expectedInnerError3 = errors.New("dummy inner error 3")
 	var expectedInnerErrors = []error{
@@ -1062,10 +1676,10 @@ func TestAppErrorWrap_NoInnerError(t *testing.T) {
 
 	// SUT
 	var baseAppError = &BaseAppError{
-		expectedError,
-		expectedCode,
-		expectedInnerErrors,
-		expectedExtraData,
+		error:       expectedError,
+		code:        expectedCode,
+		innerErrors: expectedInnerErrors,
+		extraData:   expectedExtraData,
 	}
 
 	// act
@@ -1075,6 +1689,7 @@ func TestAppErrorWrap_NoInnerError(t *testing.T) {
 
 	// assert
 	assert.Equal(t, expectedInnerErrors, baseAppError.innerErrors)
+	assert.Zero(t, baseAppError.getCache().version.Load())
 
 	// verify
 	verifyAll(t)
@@ -1089,10 +1704,10 @@ func TestAppErrorWrap_HasInnerError(t *testing.T) {
 	var dummyInnerMostErrorMessage = "dummy inner most error"
 	var expectedInnerError1 = errors.New("dummy inner error 1")
 	var expectedInnerError2 = &BaseAppError{
-		errors.New(dummyInnerErrorMessage),
-		CodeGeneralFailure,
-		[]error{errors.New(dummyInnerMostErrorMessage)},
-		nil,
+		error:       errors.New(dummyInnerErrorMessage),
+		code:        CodeGeneralFailure,
+		innerErrors: []error{errors.New(dummyInnerMostErrorMessage)},
+		extraData:   nil,
 	}
 	var expectedInnerError3 = errors.New("dummy inner error 3")
 	var expectedInnerErrors = []error{
@@ -1131,13 +1746,19 @@ func TestAppErrorWrap_HasInnerError(t *testing.T) {
 		assert.Equal(t, dummyInnerErrors, innerErrors)
 		return cleanedInnerErrors
 	}
+	notifyObserversFuncExpected = 1
+	notifyObserversFunc = func(event LifecycleEvent) {
+		notifyObserversFuncCalled++
+		assert.Equal(t, LifecycleEventWrapped, event.Kind)
+		assert.Equal(t, cleanedInnerErrors, event.InnerErrors)
+	}
 
 	// SUT
 	var baseAppError = &BaseAppError{
-		expectedError,
-		expectedCode,
-		expectedInnerErrors,
-		expectedExtraData,
+		error:       expectedError,
+		code:        expectedCode,
+		innerErrors: expectedInnerErrors,
+		extraData:   expectedExtraData,
 	}
 
 	// act
@@ -1153,6 +1774,7 @@ func TestAppErrorWrap_HasInnerError(t *testing.T) {
 	assert.Equal(t, dummyInnerError1, baseAppError.innerErrors[3])
 	assert.Equal(t, dummyInnerError2, baseAppError.innerErrors[4])
 	assert.Equal(t, dummyInnerError3, baseAppError.innerErrors[5])
+	assert.Equal(t, uint64(1), baseAppError.getCache().version.Load())
 
 	// verify
 	verifyAll(t)
@@ -1167,10 +1789,10 @@ func TestAppErrorAttach_NilExtraData(t *testing.T) {
 	var dummyInnerMostErrorMessage = "dummy inner most error"
 	var expectedInnerError1 = errors.New("dummy inner error 1")
 	var expectedInnerError2 = &BaseAppError{
-		errors.New(dummyInnerErrorMessage),
-		CodeGeneralFailure,
-		[]error{errors.New(dummyInnerMostErrorMessage)},
-		nil,
+		error:       errors.New(dummyInnerErrorMessage),
+		code:        CodeGeneralFailure,
+		innerErrors: []error{errors.New(dummyInnerMostErrorMessage)},
+		extraData:   nil,
 	}
 	var expectedInnerError3 = errors.New("dummy inner error 3")
 	var expectedInnerErrors = []error{
@@ -1181,16 +1803,33 @@ func TestAppErrorAttach_NilExtraData(t *testing.T) {
 	var expectedExtraData map[string]interface{}
 	var dummyName = "some name"
 	var dummyValue = uuid.New()
+	var dummyAttrs = []Attr{String(dummyName, "some attr value")}
 
 	// mock
 	createMock(t)
 
+	// expect
+	removeAttrFuncExpected = 1
+	removeAttrFunc = func(attrs []Attr, key string) []Attr {
+		removeAttrFuncCalled++
+		assert.Equal(t, dummyAttrs, attrs)
+		assert.Equal(t, dummyName, key)
+		return nil
+	}
+	notifyObserversFuncExpected = 1
+	notifyObserversFunc = func(event LifecycleEvent) {
+		notifyObserversFuncCalled++
+		assert.Equal(t, LifecycleEventAttached, event.Kind)
+		assert.Equal(t, Any(dummyName, dummyValue), event.Attr)
+	}
+
 	// SUT
 	var baseAppError = &BaseAppError{
-		expectedError,
-		expectedCode,
-		expectedInnerErrors,
-		expectedExtraData,
+		error:       expectedError,
+		code:        expectedCode,
+		innerErrors: expectedInnerErrors,
+		extraData:   expectedExtraData,
+		attrs:       dummyAttrs,
 	}
 
 	// act
@@ -1201,6 +1840,8 @@ func TestAppErrorAttach_NilExtraData(t *testing.T) {
 
 	// assert
 	assert.Equal(t, dummyValue, baseAppError.extraData[dummyName])
+	assert.Empty(t, baseAppError.attrs)
+	assert.Equal(t, uint64(1), baseAppError.getCache().version.Load())
 
 	// verify
 	verifyAll(t)
@@ -1215,10 +1856,10 @@ func TestAppErrorAttach_WithExtraData(t *testing.T) {
 	var dummyInnerMostErrorMessage = "dummy inner most error"
 	var expectedInnerError1 = errors.New("dummy inner error 1")
 	var expectedInnerError2 = &BaseAppError{
-		errors.New(dummyInnerErrorMessage),
-		CodeGeneralFailure,
-		[]error{errors.New(dummyInnerMostErrorMessage)},
-		nil,
+		error:       errors.New(dummyInnerErrorMessage),
+		code:        CodeGeneralFailure,
+		innerErrors: []error{errors.New(dummyInnerMostErrorMessage)},
+		extraData:   nil,
 	}
 	var expectedInnerError3 = errors.New("dummy inner error 3")
 	var expectedInnerErrors = []error{
@@ -1231,16 +1872,33 @@ func TestAppErrorAttach_WithExtraData(t *testing.T) {
 		dummyName: rand.Int(),
 	}
 	var dummyValue = uuid.New()
+	var dummyAttrs = []Attr{String(dummyName, "some attr value")}
 
 	// mock
 	createMock(t)
 
+	// expect
+	removeAttrFuncExpected = 1
+	removeAttrFunc = func(attrs []Attr, key string) []Attr {
+		removeAttrFuncCalled++
+		assert.Equal(t, dummyAttrs, attrs)
+		assert.Equal(t, dummyName, key)
+		return nil
+	}
+	notifyObserversFuncExpected = 1
+	notifyObserversFunc = func(event LifecycleEvent) {
+		notifyObserversFuncCalled++
+		assert.Equal(t, LifecycleEventAttached, event.Kind)
+		assert.Equal(t, Any(dummyName, dummyValue), event.Attr)
+	}
+
 	// SUT
 	var baseAppError = &BaseAppError{
-		expectedError,
-		expectedCode,
-		expectedInnerErrors,
-		expectedExtraData,
+		error:       expectedError,
+		code:        expectedCode,
+		innerErrors: expectedInnerErrors,
+		extraData:   expectedExtraData,
+		attrs:       dummyAttrs,
 	}
 
 	// act
@@ -1251,6 +1909,8 @@ func TestAppErrorAttach_WithExtraData(t *testing.T) {
 
 	// assert
 	assert.Equal(t, dummyValue, baseAppError.extraData[dummyName])
+	assert.Empty(t, baseAppError.attrs)
+	assert.Equal(t, uint64(1), baseAppError.getCache().version.Load())
 
 	// verify
 	verifyAll(t)
@@ -1283,6 +1943,13 @@ func TestGetGeneralFailureError(t *testing.T) {
 		assert.Error(t, dummyInnerError3, innerErrors[2])
 		return innerErrors
 	}
+	notifyObserversFuncExpected = 1
+	notifyObserversFunc = func(event LifecycleEvent) {
+		notifyObserversFuncCalled++
+		assert.Equal(t, LifecycleEventWrapped, event.Kind)
+		assert.Equal(t, dummyResult, event.Error)
+		assert.Equal(t, 3, len(event.InnerErrors))
+	}
 
 	// SUT + act
 	var baseAppError, ok = GetGeneralFailureError(
@@ -1330,6 +1997,13 @@ func TestGetUnauthorized(t *testing.T) {
 		assert.Error(t, dummyInnerError3, innerErrors[2])
 		return innerErrors
 	}
+	notifyObserversFuncExpected = 1
+	notifyObserversFunc = func(event LifecycleEvent) {
+		notifyObserversFuncCalled++
+		assert.Equal(t, LifecycleEventWrapped, event.Kind)
+		assert.Equal(t, dummyResult, event.Error)
+		assert.Equal(t, 3, len(event.InnerErrors))
+	}
 
 	// SUT + act
 	var baseAppError, ok = GetUnauthorized(
@@ -1377,6 +2051,13 @@ func TestGetInvalidOperation(t *testing.T) {
 		assert.Error(t, dummyInnerError3, innerErrors[2])
 		return innerErrors
 	}
+	notifyObserversFuncExpected = 1
+	notifyObserversFunc = func(event LifecycleEvent) {
+		notifyObserversFuncCalled++
+		assert.Equal(t, LifecycleEventWrapped, event.Kind)
+		assert.Equal(t, dummyResult, event.Error)
+		assert.Equal(t, 3, len(event.InnerErrors))
+	}
 
 	// SUT + act
 	var baseAppError, ok = GetInvalidOperation(
@@ -1424,6 +2105,13 @@ func TestGetBadRequestError(t *testing.T) {
 		assert.Error(t, dummyInnerError3, innerErrors[2])
 		return innerErrors
 	}
+	notifyObserversFuncExpected = 1
+	notifyObserversFunc = func(event LifecycleEvent) {
+		notifyObserversFuncCalled++
+		assert.Equal(t, LifecycleEventWrapped, event.Kind)
+		assert.Equal(t, dummyResult, event.Error)
+		assert.Equal(t, 3, len(event.InnerErrors))
+	}
 
 	// SUT + act
 	var baseAppError, ok = GetBadRequestError(
@@ -1471,6 +2159,13 @@ func TestGetNotFoundError(t *testing.T) {
 		assert.Error(t, dummyInnerError3, innerErrors[2])
 		return innerErrors
 	}
+	notifyObserversFuncExpected = 1
+	notifyObserversFunc = func(event LifecycleEvent) {
+		notifyObserversFuncCalled++
+		assert.Equal(t, LifecycleEventWrapped, event.Kind)
+		assert.Equal(t, dummyResult, event.Error)
+		assert.Equal(t, 3, len(event.InnerErrors))
+	}
 
 	// SUT + act
 	var baseAppError, ok = GetNotFoundError(
@@ -1518,6 +2213,13 @@ func TestGetCircuitBreakError(t *testing.T) {
 		assert.Error(t, dummyInnerError3, innerErrors[2])
 		return innerErrors
 	}
+	notifyObserversFuncExpected = 1
+	notifyObserversFunc = func(event LifecycleEvent) {
+		notifyObserversFuncCalled++
+		assert.Equal(t, LifecycleEventWrapped, event.Kind)
+		assert.Equal(t, dummyResult, event.Error)
+		assert.Equal(t, 3, len(event.InnerErrors))
+	}
 
 	// SUT + act
 	var baseAppError, ok = GetCircuitBreakError(
@@ -1565,6 +2267,13 @@ func TestGetOperationLockError(t *testing.T) {
 		assert.Error(t, dummyInnerError3, innerErrors[2])
 		return innerErrors
 	}
+	notifyObserversFuncExpected = 1
+	notifyObserversFunc = func(event LifecycleEvent) {
+		notifyObserversFuncCalled++
+		assert.Equal(t, LifecycleEventWrapped, event.Kind)
+		assert.Equal(t, dummyResult, event.Error)
+		assert.Equal(t, 3, len(event.InnerErrors))
+	}
 
 	// SUT + act
 	var baseAppError, ok = GetOperationLockError(
@@ -1612,6 +2321,13 @@ func TestGetAccessForbiddenError(t *testing.T) {
 		assert.Error(t, dummyInnerError3, innerErrors[2])
 		return innerErrors
 	}
+	notifyObserversFuncExpected = 1
+	notifyObserversFunc = func(event LifecycleEvent) {
+		notifyObserversFuncCalled++
+		assert.Equal(t, LifecycleEventWrapped, event.Kind)
+		assert.Equal(t, dummyResult, event.Error)
+		assert.Equal(t, 3, len(event.InnerErrors))
+	}
 
 	// SUT + act
 	var baseAppError, ok = GetAccessForbiddenError(
@@ -1659,6 +2375,13 @@ func TestGetDataCorruptionError(t *testing.T) {
 		assert.Error(t, dummyInnerError3, innerErrors[2])
 		return innerErrors
 	}
+	notifyObserversFuncExpected = 1
+	notifyObserversFunc = func(event LifecycleEvent) {
+		notifyObserversFuncCalled++
+		assert.Equal(t, LifecycleEventWrapped, event.Kind)
+		assert.Equal(t, dummyResult, event.Error)
+		assert.Equal(t, 3, len(event.InnerErrors))
+	}
 
 	// SUT + act
 	var baseAppError, ok = GetDataCorruptionError(
@@ -1706,6 +2429,13 @@ func TestGetNotImplementedError(t *testing.T) {
 		assert.Error(t, dummyInnerError3, innerErrors[2])
 		return innerErrors
 	}
+	notifyObserversFuncExpected = 1
+	notifyObserversFunc = func(event LifecycleEvent) {
+		notifyObserversFuncCalled++
+		assert.Equal(t, LifecycleEventWrapped, event.Kind)
+		assert.Equal(t, dummyResult, event.Error)
+		assert.Equal(t, 3, len(event.InnerErrors))
+	}
 
 	// SUT + act
 	var baseAppError, ok = GetNotImplementedError(
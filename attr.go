@@ -0,0 +1,264 @@
+package apperror
+
+import (
+	"time"
+)
+
+// Kind is the kind of value held by an attribute
+type Kind int
+
+// These are the kinds of value an attribute can hold
+const (
+	KindAny Kind = iota
+	KindBool
+	KindDuration
+	KindInt64
+	KindString
+	KindTime
+)
+
+// String translates the enum
+func (kind Kind) String() string {
+	var names = []string{
+		"Any",
+		"Bool",
+		"Duration",
+		"Int64",
+		"String",
+		"Time",
+	}
+	if kind < 0 || int(kind) >= len(names) {
+		return "Unknown"
+	}
+	return names[kind]
+}
+
+// Value holds an attribute value; primitive kinds are stored inline so that creating them does not allocate
+type Value struct {
+	kind Kind
+	num  uint64
+	str  string
+	any  interface{}
+}
+
+// Attr is a typed name-value pair of app error extra data, modelled on slog.Attr
+type Attr struct {
+	Key   string
+	Value Value
+}
+
+// String creates an attribute holding a string value
+func String(key string, value string) Attr {
+	return Attr{key, Value{kind: KindString, str: value}}
+}
+
+// Int creates an attribute holding an integer value
+func Int(key string, value int) Attr {
+	return Int64(key, int64(value))
+}
+
+// Int64 creates an attribute holding an int64 value
+func Int64(key string, value int64) Attr {
+	return Attr{key, Value{kind: KindInt64, num: uint64(value)}}
+}
+
+// Bool creates an attribute holding a bool value
+func Bool(key string, value bool) Attr {
+	var num uint64
+	if value {
+		num = 1
+	}
+	return Attr{key, Value{kind: KindBool, num: num}}
+}
+
+// Duration creates an attribute holding a time.Duration value
+func Duration(key string, value time.Duration) Attr {
+	return Attr{key, Value{kind: KindDuration, num: uint64(value)}}
+}
+
+// Time creates an attribute holding a time.Time value; the monotonic clock reading is discarded
+func Time(key string, value time.Time) Attr {
+	var nanoseconds = value.UnixNano()
+	if !value.Equal(time.Unix(0, nanoseconds)) {
+		// UnixNano is only defined between the years 1678 and 2262, so other times, including the zero time, are kept as they are, like slog does
+		return Attr{key, Value{kind: KindTime, any: value.Round(0)}}
+	}
+	return Attr{key, Value{kind: KindTime, num: uint64(nanoseconds), any: value.Location()}}
+}
+
+// Any creates an attribute for the given value, using the typed representation when the value is of a supported primitive type
+func Any(key string, value interface{}) Attr {
+	switch typedValue := value.(type) {
+	case string:
+		return String(key, typedValue)
+	case int:
+		return Int(key, typedValue)
+	case int64:
+		return Int64(key, typedValue)
+	case bool:
+		return Bool(key, typedValue)
+	case time.Duration:
+		return Duration(key, typedValue)
+	case time.Time:
+		return Time(key, typedValue)
+	case Value:
+		return Attr{key, typedValue}
+	}
+	return Attr{key, Value{kind: KindAny, any: value}}
+}
+
+// Kind returns the kind of the value
+func (value Value) Kind() Kind {
+	return value.kind
+}
+
+// Any returns the value as an interface{}
+func (value Value) Any() interface{} {
+	switch value.kind {
+	case KindBool:
+		return value.Bool()
+	case KindDuration:
+		return value.Duration()
+	case KindInt64:
+		return value.Int64()
+	case KindString:
+		return value.str
+	case KindTime:
+		return value.Time()
+	}
+	return value.any
+}
+
+// String returns the value of a KindString value, or the printed form of any other kind
+func (value Value) String() string {
+	if value.kind == KindString {
+		return value.str
+	}
+	return fmtSprint(value.Any())
+}
+
+// Bool returns the value of a KindBool value; it panics for any other kind
+func (value Value) Bool() bool {
+	value.mustBe(KindBool)
+	return value.num == 1
+}
+
+// Duration returns the value of a KindDuration value; it panics for any other kind
+func (value Value) Duration() time.Duration {
+	value.mustBe(KindDuration)
+	return time.Duration(int64(value.num))
+}
+
+// Int64 returns the value of a KindInt64 value; it panics for any other kind
+func (value Value) Int64() int64 {
+	value.mustBe(KindInt64)
+	return int64(value.num)
+}
+
+// Time returns the value of a KindTime value; it panics for any other kind
+func (value Value) Time() time.Time {
+	value.mustBe(KindTime)
+	if outOfRange, isTime := value.any.(time.Time); isTime {
+		return outOfRange
+	}
+	var location, _ = value.any.(*time.Location)
+	return time.Unix(0, int64(value.num)).In(location)
+}
+
+func (value Value) mustBe(kind Kind) {
+	if value.kind != kind {
+		panic(fmtSprintf("apperror: Value kind is %v, not %v", value.kind, kind))
+	}
+}
+
+// AttachAttrs adds/updates the given typed attributes to the app error's extra data
+func (baseAppError *BaseAppError) AttachAttrs(attrs ...Attr) {
+	if baseAppError == nil || baseAppError.frozen {
+		return
+	}
+	for _, attr := range attrs {
+		delete(baseAppError.extraData, attr.Key)
+		var index = findAttrFunc(
+			baseAppError.attrs,
+			attr.Key,
+		)
+		if index < 0 {
+			baseAppError.attrs = append(
+				baseAppError.attrs,
+				attr,
+			)
+		} else {
+			baseAppError.attrs[index] = attr
+		}
+		notifyObserversFunc(
+			LifecycleEvent{
+				Kind:  LifecycleEventAttached,
+				Error: baseAppError,
+				Attr:  attr,
+			},
+		)
+	}
+	baseAppError.getCache().version.Add(1)
+}
+
+// Attrs calls f on each extra data attribute of the app error, typed ones first and then the ones added by Attach in name order, until f returns false
+func (baseAppError *BaseAppError) Attrs(f func(Attr) bool) {
+	if baseAppError == nil {
+		return
+	}
+	for _, attr := range baseAppError.attrs {
+		if !f(attr) {
+			return
+		}
+	}
+	var names = []string{}
+	for name := range baseAppError.extraData {
+		names = append(
+			names,
+			name,
+		)
+	}
+	sortStrings(names)
+	for _, name := range names {
+		if !f(Any(name, baseAppError.extraData[name])) {
+			return
+		}
+	}
+}
+
+func findAttr(attrs []Attr, key string) int {
+	for index, attr := range attrs {
+		if attr.Key == key {
+			return index
+		}
+	}
+	return -1
+}
+
+func removeAttr(attrs []Attr, key string) []Attr {
+	var index = findAttrFunc(
+		attrs,
+		key,
+	)
+	if index < 0 {
+		return attrs
+	}
+	return append(
+		attrs[:index:index],
+		attrs[index+1:]...,
+	)
+}
+
+func getExtraData(baseAppError *BaseAppError) map[string]interface{} {
+	if len(baseAppError.attrs) == 0 {
+		return baseAppError.extraData
+	}
+	var extraData = make(map[string]interface{}, len(baseAppError.extraData)+len(baseAppError.attrs))
+	for name, value := range baseAppError.extraData {
+		extraData[name] = value
+	}
+	for _, attr := range baseAppError.attrs {
+		extraData[attr.Key] = attr.Value.Any()
+	}
+	return extraData
+}
@@ -0,0 +1,473 @@
+package apperror
+
+import (
+	"errors"
+	"math"
+	"math/rand"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestKindString(t *testing.T) {
+	// mock
+	createMock(t)
+
+	// SUT + act + assert
+	assert.Equal(t, "Any", KindAny.String())
+	assert.Equal(t, "Bool", KindBool.String())
+	assert.Equal(t, "Duration", KindDuration.String())
+	assert.Equal(t, "Int64", KindInt64.String())
+	assert.Equal(t, "String", KindString.String())
+	assert.Equal(t, "Time", KindTime.String())
+	assert.Equal(t, "Unknown", Kind(-1).String())
+	assert.Equal(t, "Unknown", Kind(100).String())
+
+	// verify
+	verifyAll(t)
+}
+
+func TestAttrConstructors(t *testing.T) {
+	// arrange
+	var dummyInt = rand.Int()
+	var dummyDuration = time.Duration(rand.Int63())
+	var dummyLocation = time.FixedZone("some zone", 3600)
+	var dummyTime = time.Unix(0, rand.Int63()).In(dummyLocation)
+
+	// mock
+	createMock(t)
+
+	// SUT + act
+	var stringAttr = String("a", "some value")
+	var intAttr = Int("b", dummyInt)
+	var int64Attr = Int64("c", -1)
+	var trueAttr = Bool("d", true)
+	var falseAttr = Bool("e", false)
+	var durationAttr = Duration("f", dummyDuration)
+	var timeAttr = Time("g", dummyTime)
+	var zeroTimeAttr = Time("h", time.Time{})
+
+	// assert
+	assert.Equal(t, "a", stringAttr.Key)
+	assert.Equal(t, KindString, stringAttr.Value.Kind())
+	assert.Equal(t, "some value", stringAttr.Value.String())
+	assert.Equal(t, KindInt64, intAttr.Value.Kind())
+	assert.Equal(t, int64(dummyInt), intAttr.Value.Int64())
+	assert.Equal(t, int64(-1), int64Attr.Value.Int64())
+	assert.Equal(t, KindBool, trueAttr.Value.Kind())
+	assert.True(t, trueAttr.Value.Bool())
+	assert.False(t, falseAttr.Value.Bool())
+	assert.Equal(t, KindDuration, durationAttr.Value.Kind())
+	assert.Equal(t, dummyDuration, durationAttr.Value.Duration())
+	assert.Equal(t, KindTime, timeAttr.Value.Kind())
+	assert.True(t, dummyTime.Equal(timeAttr.Value.Time()))
+	assert.Equal(t, dummyLocation, timeAttr.Value.Time().Location())
+	assert.True(t, zeroTimeAttr.Value.Time().IsZero())
+
+	// verify
+	verifyAll(t)
+}
+
+func TestAny(t *testing.T) {
+	// arrange
+	var dummyTime = time.Now()
+	var dummyError = errors.New("some error")
+	var dummyValue = String("x", "some value").Value
+
+	// mock
+	createMock(t)
+
+	// SUT + act + assert
+	assert.Equal(t, String("a", "some value"), Any("a", "some value"))
+	assert.Equal(t, Int("a", 5), Any("a", 5))
+	assert.Equal(t, Int64("a", 5), Any("a", int64(5)))
+	assert.Equal(t, Bool("a", true), Any("a", true))
+	assert.Equal(t, Duration("a", time.Second), Any("a", time.Second))
+	assert.Equal(t, Time("a", dummyTime), Any("a", dummyTime))
+	assert.Equal(t, Attr{"a", dummyValue}, Any("a", dummyValue))
+	assert.Equal(t, Attr{"a", Value{kind: KindAny, any: dummyError}}, Any("a", dummyError))
+
+	// verify
+	verifyAll(t)
+}
+
+func TestValue_Any(t *testing.T) {
+	// arrange
+	var dummyTime = time.Unix(0, rand.Int63()).UTC()
+	var dummyError = errors.New("some error")
+
+	// mock
+	createMock(t)
+
+	// SUT + act + assert
+	assert.Equal(t, true, Bool("a", true).Value.Any())
+	assert.Equal(t, time.Minute, Duration("a", time.Minute).Value.Any())
+	assert.Equal(t, int64(5), Int("a", 5).Value.Any())
+	assert.Equal(t, "some value", String("a", "some value").Value.Any())
+	assert.Equal(t, dummyTime, Time("a", dummyTime).Value.Any())
+	assert.Equal(t, dummyError, Any("a", dummyError).Value.Any())
+
+	// verify
+	verifyAll(t)
+}
+
+func TestValue_String_NonString(t *testing.T) {
+	// arrange
+	var dummyValue = rand.Int()
+	var dummyResult = "some result"
+
+	// mock
+	createMock(t)
+
+	// expect
+	fmtSprintExpected = 1
+	fmtSprint = func(a ...interface{}) string {
+		fmtSprintCalled++
+		assert.Equal(t, []interface{}{int64(dummyValue)}, a)
+		return dummyResult
+	}
+
+	// SUT
+	var sut = Int("a", dummyValue).Value
+
+	// act
+	var result = sut.String()
+
+	// assert
+	assert.Equal(t, dummyResult, result)
+
+	// verify
+	verifyAll(t)
+}
+
+func TestValue_WrongKind(t *testing.T) {
+	// arrange
+	var dummyMessage = "some message"
+
+	// mock
+	createMock(t)
+
+	// expect
+	fmtSprintfExpected = 1
+	fmtSprintf = func(format string, a ...interface{}) string {
+		fmtSprintfCalled++
+		assert.Equal(t, "apperror: Value kind is %v, not %v", format)
+		assert.Equal(t, []interface{}{KindString, KindInt64}, a)
+		return dummyMessage
+	}
+
+	// SUT
+	var sut = String("a", "some value").Value
+
+	// act + assert
+	assert.PanicsWithValue(t, dummyMessage, func() { sut.Int64() })
+
+	// verify
+	verifyAll(t)
+}
+
+func TestBaseAppError_AttachAttrs(t *testing.T) {
+	// arrange
+	var dummyExistingAttr = String("a", "some existing value")
+	var dummyUpdatedAttr = String("a", "some updated value")
+	var dummyNewAttr = Int("b", rand.Int())
+
+	// mock
+	createMock(t)
+
+	// expect
+	findAttrFuncExpected = 2
+	findAttrFunc = func(attrs []Attr, key string) int {
+		findAttrFuncCalled++
+		if key == "a" {
+			return 0
+		}
+		assert.Equal(t, "b", key)
+		return -1
+	}
+	notifyObserversFuncExpected = 2
+	notifyObserversFunc = func(event LifecycleEvent) {
+		notifyObserversFuncCalled++
+		assert.Equal(t, LifecycleEventAttached, event.Kind)
+		if notifyObserversFuncCalled == 1 {
+			assert.Equal(t, dummyUpdatedAttr, event.Attr)
+		} else {
+			assert.Equal(t, dummyNewAttr, event.Attr)
+		}
+	}
+
+	// SUT
+	var sut = &BaseAppError{
+		extraData: map[string]interface{}{
+			"a": "some extra value",
+			"c": "some other value",
+		},
+		attrs: []Attr{dummyExistingAttr},
+	}
+
+	// act
+	sut.AttachAttrs(
+		dummyUpdatedAttr,
+		dummyNewAttr,
+	)
+
+	// assert
+	assert.Equal(t, []Attr{dummyUpdatedAttr, dummyNewAttr}, sut.attrs)
+	assert.Equal(t, map[string]interface{}{"c": "some other value"}, sut.extraData)
+	assert.Equal(t, uint64(1), sut.getCache().version.Load())
+
+	// verify
+	verifyAll(t)
+}
+
+func TestBaseAppError_Attrs(t *testing.T) {
+	// arrange
+	var dummyAttr1 = String("z", "some value")
+	var dummyAttr2 = Bool("y", true)
+	var results []Attr
+
+	// mock
+	createMock(t)
+
+	// expect
+	sortStringsExpected = 1
+	sortStrings = func(x []string) {
+		sortStringsCalled++
+		assert.ElementsMatch(t, []string{"a", "b"}, x)
+		x[0], x[1] = "a", "b"
+	}
+
+	// SUT
+	var sut = &BaseAppError{
+		extraData: map[string]interface{}{
+			"b": 2,
+			"a": 1,
+		},
+		attrs: []Attr{dummyAttr1, dummyAttr2},
+	}
+
+	// act
+	sut.Attrs(func(attr Attr) bool {
+		results = append(results, attr)
+		return true
+	})
+
+	// assert
+	assert.Equal(t, []Attr{dummyAttr1, dummyAttr2, Int("a", 1), Int("b", 2)}, results)
+
+	// verify
+	verifyAll(t)
+}
+
+func TestBaseAppError_Attrs_StopEarly(t *testing.T) {
+	// arrange
+	var results []Attr
+
+	// mock
+	createMock(t)
+
+	// expect
+	sortStringsExpected = 1
+	sortStrings = func(x []string) {
+		sortStringsCalled++
+	}
+
+	// SUT
+	var sut = &BaseAppError{
+		extraData: map[string]interface{}{"a": 1},
+		attrs:     []Attr{String("z", "some value"), Bool("y", true)},
+	}
+
+	// act
+	sut.Attrs(func(attr Attr) bool {
+		results = append(results, attr)
+		return false
+	})
+	sut.attrs = nil
+	sut.Attrs(func(attr Attr) bool {
+		results = append(results, attr)
+		return false
+	})
+
+	// assert
+	assert.Equal(t, []Attr{String("z", "some value"), Int("a", 1)}, results)
+
+	// verify
+	verifyAll(t)
+}
+
+func TestFindAttr(t *testing.T) {
+	// arrange
+	var dummyAttrs = []Attr{String("a", "x"), String("b", "y")}
+
+	// mock
+	createMock(t)
+
+	// SUT + act + assert
+	assert.Equal(t, 1, findAttr(dummyAttrs, "b"))
+	assert.Equal(t, -1, findAttr(dummyAttrs, "c"))
+
+	// verify
+	verifyAll(t)
+}
+
+func TestRemoveAttr_NotFound(t *testing.T) {
+	// arrange
+	var dummyAttrs = []Attr{String("a", "x")}
+
+	// mock
+	createMock(t)
+
+	// expect
+	findAttrFuncExpected = 1
+	findAttrFunc = func(attrs []Attr, key string) int {
+		findAttrFuncCalled++
+		assert.Equal(t, dummyAttrs, attrs)
+		assert.Equal(t, "b", key)
+		return -1
+	}
+
+	// SUT + act
+	var result = removeAttr(
+		dummyAttrs,
+		"b",
+	)
+
+	// assert
+	assert.Equal(t, dummyAttrs, result)
+
+	// verify
+	verifyAll(t)
+}
+
+func TestRemoveAttr_Found(t *testing.T) {
+	// arrange
+	var dummyAttrs = []Attr{String("a", "x"), String("b", "y"), String("c", "z")}
+
+	// mock
+	createMock(t)
+
+	// expect
+	findAttrFuncExpected = 1
+	findAttrFunc = func(attrs []Attr, key string) int {
+		findAttrFuncCalled++
+		return 1
+	}
+
+	// SUT + act
+	var result = removeAttr(
+		dummyAttrs,
+		"b",
+	)
+
+	// assert
+	assert.Equal(t, []Attr{String("a", "x"), String("c", "z")}, result)
+	assert.Equal(t, []Attr{String("a", "x"), String("b", "y"), String("c", "z")}, dummyAttrs)
+
+	// verify
+	verifyAll(t)
+}
+
+func TestGetExtraData_NoAttrs(t *testing.T) {
+	// arrange
+	var dummyExtraData = map[string]interface{}{"a": 1}
+
+	// mock
+	createMock(t)
+
+	// SUT + act
+	var result = getExtraData(
+		&BaseAppError{extraData: dummyExtraData},
+	)
+
+	// assert
+	assert.Equal(t, dummyExtraData, result)
+
+	// verify
+	verifyAll(t)
+}
+
+func TestGetExtraData_WithAttrs(t *testing.T) {
+	// arrange
+	var dummyExtraData = map[string]interface{}{"a": 1}
+
+	// mock
+	createMock(t)
+
+	// SUT + act
+	var result = getExtraData(
+		&BaseAppError{
+			extraData: dummyExtraData,
+			attrs:     []Attr{Duration("b", time.Second)},
+		},
+	)
+
+	// assert
+	assert.Equal(t, map[string]interface{}{"a": 1, "b": time.Second}, result)
+	assert.Equal(t, map[string]interface{}{"a": 1}, dummyExtraData)
+
+	// verify
+	verifyAll(t)
+}
+
+func TestAttachAttrs_NoAllocationForPrimitives(t *testing.T) {
+	// arrange
+	var sut = NewBaseAppError(CodeNotFound, "some message")
+	sut.AttachAttrs(String("a", ""), Int("b", 0), Bool("c", false), Duration("d", 0))
+	var dummyDuration = time.Duration(rand.Int63())
+
+	// act
+	var allocations = testing.AllocsPerRun(100, func() {
+		sut.AttachAttrs(
+			String("a", "some value"),
+			Int("b", 42),
+			Bool("c", true),
+			Duration("d", dummyDuration),
+		)
+	})
+
+	// assert
+	assert.Zero(t, allocations)
+	assert.Equal(t, "(NotFound) some message [ d = "+dummyDuration.String()+" ]", (&BaseAppError{
+		error:     sut.error,
+		code:      sut.code,
+		extraData: map[string]interface{}{},
+		attrs:     sut.attrs[3:],
+	}).Error())
+}
+
+func TestTime_ZeroAndMinusOneNanosecond(t *testing.T) {
+	// act
+	var zero = Time("a", time.Time{}).Value.Time()
+	var minusOne = Time("a", time.Unix(0, -1)).Value.Time()
+
+	// assert
+	assert.True(t, zero.IsZero())
+	assert.False(t, minusOne.IsZero())
+	assert.True(t, time.Unix(0, -1).Equal(minusOne))
+}
+
+func TestTime_OutOfUnixNanoRange(t *testing.T) {
+	// arrange
+	var dummyLocation = time.FixedZone("some zone", 3600)
+	var dummyTimes = []time.Time{
+		time.Date(3000, 1, 1, 0, 0, 0, 0, time.UTC),
+		time.Date(1500, 1, 1, 0, 0, 0, 0, dummyLocation),
+		time.Date(2262, 4, 11, 23, 47, 16, 854775807, time.UTC),
+		time.Date(2262, 4, 11, 23, 47, 16, 854775808, time.UTC),
+		time.Date(1677, 9, 21, 0, 12, 43, 145224192, time.UTC),
+		time.Date(1677, 9, 21, 0, 12, 43, 145224191, time.UTC),
+		time.Unix(0, math.MaxInt64),
+		time.Unix(0, math.MinInt64),
+	}
+
+	for _, dummyTime := range dummyTimes {
+		// act
+		var result = Time("a", dummyTime).Value.Time()
+
+		// assert
+		assert.True(t, dummyTime.Equal(result), "%v read back as %v", dummyTime, result)
+		assert.Equal(t, dummyTime.Location(), result.Location())
+	}
+}
@@ -0,0 +1,156 @@
+package apperror
+
+import (
+	"errors"
+	"strconv"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+// newDeepTree builds an app error with the given depth of nested app errors, each carrying extra data and a plain inner error, and returns it along with its innermost app error
+func newDeepTree(depth int) (*BaseAppError, *BaseAppError) {
+	var root = NewBaseAppError(CodeGeneralFailure, "some message %v", 0)
+	var current = root
+	for level := 1; level <= depth; level++ {
+		var child = NewBaseAppError(CodeNotFound, "some message %v", level)
+		child.Attach("level", level)
+		child.AttachAttrs(String("name", "some name "+strconv.Itoa(level)))
+		current.Wrap(errors.New("some plain error"), child)
+		current = child
+	}
+	return root, current
+}
+
+func TestNewBaseAppError_TwoAllocationsForPlainError(t *testing.T) {
+	// act
+	var allocations = testing.AllocsPerRun(100, func() {
+		_ = NewBaseAppError(CodeNotFound, "some message")
+	})
+
+	// assert
+	assert.Equal(t, float64(2), allocations)
+}
+
+func TestBaseAppError_CopiedByValue(t *testing.T) {
+	// arrange
+	var original = NewBaseAppError(CodeNotFound, "some message")
+	var before = original.Error()
+	var instanceID = original.InstanceID()
+
+	// act
+	var copied = *original
+	copied.Attach("id", 5)
+
+	// assert
+	assert.Equal(t, "(NotFound) some message [ id = 5 ]", copied.Error())
+	assert.Equal(t, before, original.Error())
+	assert.Equal(t, instanceID, copied.InstanceID())
+}
+
+func TestBaseAppError_Error_NoAllocationOnceRendered(t *testing.T) {
+	// arrange
+	var sut = NewBaseAppError(CodeNotFound, "some message")
+	var expected = sut.Error()
+
+	// act
+	var allocations = testing.AllocsPerRun(100, func() {
+		_ = sut.Error()
+	})
+
+	// assert
+	assert.Zero(t, allocations)
+	assert.Equal(t, "(NotFound) some message", expected)
+}
+
+func TestBaseAppError_Error_RenderedAgainAfterAttach(t *testing.T) {
+	// arrange
+	var sut = NewBaseAppError(CodeNotFound, "some message")
+	var before = sut.Error()
+
+	// act
+	sut.Attach("id", 5)
+	var afterAttach = sut.Error()
+	sut.AttachAttrs(Int("id", 6))
+	var afterAttachAttrs = sut.Error()
+
+	// assert
+	assert.Equal(t, "(NotFound) some message", before)
+	assert.Equal(t, "(NotFound) some message [ id = 5 ]", afterAttach)
+	assert.Equal(t, "(NotFound) some message [ id = 6 ]", afterAttachAttrs)
+}
+
+func BenchmarkNewBaseAppError_Plain(b *testing.B) {
+	b.ReportAllocs()
+	for i := 0; i < b.N; i++ {
+		_ = NewBaseAppError(CodeNotFound, "some message")
+	}
+}
+
+func BenchmarkNewBaseAppError_Formatted(b *testing.B) {
+	b.ReportAllocs()
+	for i := 0; i < b.N; i++ {
+		_ = NewBaseAppError(CodeNotFound, "some message %v", i)
+	}
+}
+
+func BenchmarkBaseAppError_Attach(b *testing.B) {
+	var sut = NewBaseAppError(CodeNotFound, "some message")
+	b.ReportAllocs()
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		sut.Attach("id", "some value")
+	}
+}
+
+func BenchmarkBaseAppError_AttachAttrs(b *testing.B) {
+	var sut = NewBaseAppError(CodeNotFound, "some message")
+	b.ReportAllocs()
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		sut.AttachAttrs(Int("id", i))
+	}
+}
+
+func BenchmarkBaseAppError_Wrap(b *testing.B) {
+	var dummyInnerError = errors.New("some inner error")
+	b.ReportAllocs()
+	for i := 0; i < b.N; i++ {
+		var sut = NewBaseAppError(CodeNotFound, "some message")
+		sut.Wrap(dummyInnerError)
+	}
+}
+
+func BenchmarkBaseAppError_Error_Plain(b *testing.B) {
+	var sut = NewBaseAppError(CodeNotFound, "some message")
+	b.ReportAllocs()
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		_ = sut.Error()
+	}
+}
+
+func BenchmarkBaseAppError_Error_DeepTree(b *testing.B) {
+	for _, depth := range []int{1, 8, 32} {
+		var sut, _ = newDeepTree(depth)
+		b.Run(strconv.Itoa(depth), func(b *testing.B) {
+			b.ReportAllocs()
+			for i := 0; i < b.N; i++ {
+				_ = sut.Error()
+			}
+		})
+	}
+}
+
+func BenchmarkBaseAppError_Error_DeepTreeLeafChanged(b *testing.B) {
+	for _, depth := range []int{1, 8, 32} {
+		var sut, leaf = newDeepTree(depth)
+		b.Run(strconv.Itoa(depth), func(b *testing.B) {
+			b.ReportAllocs()
+			for i := 0; i < b.N; i++ {
+				leaf.Attach("id", "some value")
+				_ = sut.Error()
+			}
+		})
+	}
+}
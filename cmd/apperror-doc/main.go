@@ -0,0 +1,69 @@
+// Command apperror-doc dumps the documentation of the built-in error codes, plus those of the given catalogs, as Markdown, a JSON schema or OpenAPI components.
+//
+//	apperror-doc -format openapi -catalog errors.yaml -output errors.openapi.json
+package main
+
+import (
+	"flag"
+	"fmt"
+	"io"
+	"os"
+	"strings"
+
+	"github.com/zhongjie-cai/app-error/internal/catalog"
+	apperror "github.com/zhongjie-cai/app-error/v2"
+)
+
+// catalogPaths collects the repeatable -catalog flag
+type catalogPaths []string
+
+func (paths *catalogPaths) String() string {
+	return strings.Join(*paths, ",")
+}
+
+func (paths *catalogPaths) Set(value string) error {
+	*paths = append(*paths, value)
+	return nil
+}
+
+func main() {
+	if err := run(os.Args[1:], os.Stdout); err != nil {
+		fmt.Fprintln(os.Stderr, "apperror-doc:", err)
+		os.Exit(1)
+	}
+}
+
+// run parses the command line, registers the catalogs and writes the documentation
+func run(args []string, stdout io.Writer) error {
+	var flags = flag.NewFlagSet("apperror-doc", flag.ContinueOnError)
+	var format = flags.String("format", string(apperror.DocFormatMarkdown), "output format: markdown, jsonschema or openapi")
+	var output = flags.String("output", "", "path of the output file; defaults to stdout")
+	var paths catalogPaths
+	flags.Var(&paths, "catalog", "path of a YAML or JSON error catalog to include; can be repeated")
+	if err := flags.Parse(args); err != nil {
+		return err
+	}
+	for _, path := range paths {
+		var errorCatalog, err = catalog.Load(path)
+		if err != nil {
+			return err
+		}
+		for _, definition := range errorCatalog.Definitions() {
+			if err := apperror.Register(definition); err != nil {
+				return fmt.Errorf("%s: %w", path, err)
+			}
+		}
+	}
+	if *output == "" {
+		return apperror.WriteDocs(stdout, apperror.DocFormat(*format))
+	}
+	var file, err = os.Create(*output)
+	if err != nil {
+		return err
+	}
+	if err := apperror.WriteDocs(file, apperror.DocFormat(*format)); err != nil {
+		file.Close()
+		return err
+	}
+	return file.Close()
+}
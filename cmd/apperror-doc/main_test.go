@@ -0,0 +1,67 @@
+package main
+
+import (
+	"bytes"
+	"os"
+	"path/filepath"
+	"strconv"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+// testRuns counts the runs of the tests registering catalogs into the process-global code registry, which cannot be undone, so that each run uses its own codes and names
+var testRuns int
+
+func TestRun_DefaultMarkdown(t *testing.T) {
+	// arrange
+	var stdout bytes.Buffer
+
+	// act
+	var err = run(nil, &stdout)
+
+	// assert
+	assert.NoError(t, err)
+	assert.Contains(t, stdout.String(), "| Name | Code | HTTP Status | Message | Severity | Retryable |\n")
+	assert.Contains(t, stdout.String(), "| NotFound | 4 | 404 Not Found |")
+}
+
+func TestRun_CatalogToFile(t *testing.T) {
+	// arrange
+	var directory = t.TempDir()
+	var catalogPath = filepath.Join(directory, "errors.json")
+	var output = filepath.Join(directory, "errors.openapi.json")
+	testRuns++
+	var dummyName = "DocQuotaExceeded" + strconv.Itoa(testRuns)
+	os.WriteFile(catalogPath, []byte(`{"base": `+strconv.Itoa(3100+testRuns)+`, "codes": [{"name": "`+dummyName+`", "http_status": 429, "message": "Quota exceeded"}]}`), 0o600)
+
+	// act
+	var err = run([]string{"-format", "openapi", "-catalog", catalogPath, "-output", output}, &bytes.Buffer{})
+
+	// assert
+	assert.NoError(t, err)
+	var content, _ = os.ReadFile(output)
+	assert.Contains(t, string(content), `"Error429"`)
+	assert.Contains(t, string(content), `"const": "`+dummyName+`"`)
+}
+
+func TestRun_Errors(t *testing.T) {
+	// arrange
+	var directory = t.TempDir()
+	var catalogPath = filepath.Join(directory, "errors.json")
+	os.WriteFile(catalogPath, []byte(`{"base": 3200, "codes": [{"name": "NotFound", "message": "m"}]}`), 0o600)
+
+	// act
+	var flagErr = run([]string{"-unknown"}, &bytes.Buffer{})
+	var loadErr = run([]string{"-catalog", filepath.Join(directory, "missing.yaml")}, &bytes.Buffer{})
+	var duplicateErr = run([]string{"-catalog", catalogPath}, &bytes.Buffer{})
+	var formatErr = run([]string{"-format", "html"}, &bytes.Buffer{})
+	var outputErr = run([]string{"-output", filepath.Join(directory, "missing", "out.md")}, &bytes.Buffer{})
+
+	// assert
+	assert.Error(t, flagErr)
+	assert.Error(t, loadErr)
+	assert.EqualError(t, duplicateErr, catalogPath+`: apperror: name "NotFound" is already registered for code 4`)
+	assert.EqualError(t, formatErr, `apperror: unknown doc format "html"`)
+	assert.Error(t, outputErr)
+}
@@ -0,0 +1,236 @@
+package main
+
+import (
+	"bytes"
+	"go/format"
+	"strconv"
+	"text/template"
+
+	"github.com/zhongjie-cai/app-error/internal/catalog"
+)
+
+// templateData is what the source and test templates are executed against
+type templateData struct {
+	Source  string
+	Package string
+	Codes   []templateCode
+}
+
+// templateCode is a catalog entry with its gRPC code resolved
+type templateCode struct {
+	catalog.Entry
+	GRPCValue uint32
+}
+
+var funcs = template.FuncMap{
+	"quote": func(value string) string {
+		return strconv.Quote(value)
+	},
+}
+
+var sourceTemplate = template.Must(template.New("source").Funcs(funcs).Parse(`// Code generated by apperror-gen from {{.Source}}; DO NOT EDIT.
+
+package {{.Package}}
+
+import (
+	apperror "github.com/zhongjie-cai/app-error/v2"
+)
+
+// Code is the error code enum of package {{.Package}}
+type Code int
+
+// These are the error codes of package {{.Package}}
+const (
+{{- range .Codes}}
+	Code{{.Name}} Code = {{.Code}}
+{{- end}}
+)
+
+var codeNames = map[Code]string{
+{{- range .Codes}}
+	Code{{.Name}}: "{{.Name}}",
+{{- end}}
+}
+
+var codeHTTPStatusCodes = map[Code]int{
+{{- range .Codes}}
+	Code{{.Name}}: {{.HTTPStatus}},
+{{- end}}
+}
+
+var codeGRPCCodes = map[Code]uint32{
+{{- range .Codes}}
+	Code{{.Name}}: {{.GRPCValue}}, // {{.GRPCCode}}
+{{- end}}
+}
+
+var codeMessages = map[Code]string{
+{{- range .Codes}}
+	Code{{.Name}}: {{quote .Message}},
+{{- end}}
+}
+
+var codeSeverities = map[Code]string{
+{{- range .Codes}}
+	Code{{.Name}}: {{quote .Severity}},
+{{- end}}
+}
+
+var codeRetryables = map[Code]bool{
+{{- range .Codes}}
+	Code{{.Name}}: {{.Retryable}},
+{{- end}}
+}
+
+func init() {
+	for code, name := range codeNames {
+		apperror.MustRegister(
+			apperror.Definition{
+				Code:           code.AppCode(),
+				Name:           name,
+				HTTPStatusCode: codeHTTPStatusCodes[code],
+				Message:        codeMessages[code],
+				GRPCCode:       codeGRPCCodes[code],
+				Severity:       codeSeverities[code],
+				Retryable:      codeRetryables[code],
+			},
+		)
+	}
+}
+
+// String translates the error code to its string representation
+func (code Code) String() string {
+	if name, isKnown := codeNames[code]; isKnown {
+		return name
+	}
+	return "Unknown"
+}
+
+// HTTPStatusCode converts the error code to its corresponding HTTP status code
+func (code Code) HTTPStatusCode() int {
+	if status, isKnown := codeHTTPStatusCodes[code]; isKnown {
+		return status
+	}
+	return 500
+}
+
+// GRPCCode converts the error code to its corresponding gRPC status code
+func (code Code) GRPCCode() uint32 {
+	if grpcCode, isKnown := codeGRPCCodes[code]; isKnown {
+		return grpcCode
+	}
+	return 2
+}
+
+// Severity returns the severity label of the error code
+func (code Code) Severity() string {
+	return codeSeverities[code]
+}
+
+// Retryable tells whether operations failing with the error code may be retried
+func (code Code) Retryable() bool {
+	return codeRetryables[code]
+}
+
+// AppCode converts the error code to the app error code it is registered as
+func (code Code) AppCode() apperror.Code {
+	return apperror.Code(code)
+}
+{{range .Codes}}
+// New{{.Name}}Error creates an error of code {{.Name}} with its default message and the given causes
+func New{{.Name}}Error(causes ...error) *apperror.Error {
+	return apperror.New(Code{{.Name}}.AppCode()).Cause(causes...).Build()
+}
+{{end}}`))
+
+var testTemplate = template.Must(template.New("test").Funcs(funcs).Parse(`// Code generated by apperror-gen from {{.Source}}; DO NOT EDIT.
+
+package {{.Package}}
+
+import (
+	"errors"
+	"testing"
+)
+
+func TestCodeUnknown(t *testing.T) {
+	var code = Code(-1)
+	if code.String() != "Unknown" {
+		t.Errorf("String() = %q, want %q", code.String(), "Unknown")
+	}
+	if code.HTTPStatusCode() != 500 {
+		t.Errorf("HTTPStatusCode() = %d, want %d", code.HTTPStatusCode(), 500)
+	}
+	if code.GRPCCode() != 2 {
+		t.Errorf("GRPCCode() = %d, want %d", code.GRPCCode(), 2)
+	}
+}
+{{range .Codes}}
+func TestCode{{.Name}}(t *testing.T) {
+	var code = Code{{.Name}}
+	if code.String() != "{{.Name}}" {
+		t.Errorf("String() = %q, want %q", code.String(), "{{.Name}}")
+	}
+	if code.HTTPStatusCode() != {{.HTTPStatus}} {
+		t.Errorf("HTTPStatusCode() = %d, want %d", code.HTTPStatusCode(), {{.HTTPStatus}})
+	}
+	if code.GRPCCode() != {{.GRPCValue}} {
+		t.Errorf("GRPCCode() = %d, want %d", code.GRPCCode(), {{.GRPCValue}})
+	}
+	if code.Severity() != {{quote .Severity}} {
+		t.Errorf("Severity() = %q, want %q", code.Severity(), {{quote .Severity}})
+	}
+	if code.Retryable() != {{.Retryable}} {
+		t.Errorf("Retryable() = %v, want %v", code.Retryable(), {{.Retryable}})
+	}
+}
+
+func TestNew{{.Name}}Error(t *testing.T) {
+	var cause = errors.New("some cause")
+	var err = New{{.Name}}Error(cause)
+	if err.Code() != Code{{.Name}}.AppCode() {
+		t.Errorf("Code() = %d, want %d", err.Code(), Code{{.Name}}.AppCode())
+	}
+	if err.Name() != "{{.Name}}" {
+		t.Errorf("Name() = %q, want %q", err.Name(), "{{.Name}}")
+	}
+	if err.HTTPStatusCode() != {{.HTTPStatus}} {
+		t.Errorf("HTTPStatusCode() = %d, want %d", err.HTTPStatusCode(), {{.HTTPStatus}})
+	}
+	if err.Message() != {{quote .Message}} {
+		t.Errorf("Message() = %q, want %q", err.Message(), {{quote .Message}})
+	}
+	if !errors.Is(err, cause) {
+		t.Errorf("errors.Is(err, cause) = false, want true")
+	}
+}
+{{end}}`))
+
+// generate renders the formatted source and test files for the given catalog
+func generate(source string, packageName string, errorCatalog *catalog.Catalog) ([]byte, []byte, error) {
+	var data = templateData{
+		Source:  source,
+		Package: packageName,
+	}
+	for _, entry := range errorCatalog.Codes {
+		var grpcValue, _ = catalog.GRPCCodeValue(entry.GRPCCode)
+		data.Codes = append(data.Codes, templateCode{Entry: entry, GRPCValue: grpcValue})
+	}
+	var sourceFile, sourceErr = render(sourceTemplate, data)
+	if sourceErr != nil {
+		return nil, nil, sourceErr
+	}
+	var testFile, testErr = render(testTemplate, data)
+	if testErr != nil {
+		return nil, nil, testErr
+	}
+	return sourceFile, testFile, nil
+}
+
+// render executes the given template and gofmt's the result
+func render(fileTemplate *template.Template, data templateData) ([]byte, error) {
+	var buffer bytes.Buffer
+	if err := fileTemplate.Execute(&buffer, data); err != nil {
+		return nil, err
+	}
+	return format.Source(buffer.Bytes())
+}
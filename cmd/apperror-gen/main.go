@@ -0,0 +1,71 @@
+// Command apperror-gen generates the error codes, tables, constructors and tests of a package from its error catalog.
+//
+// Typical usage is through go generate:
+//
+//	//go:generate go run github.com/zhongjie-cai/app-error/cmd/apperror-gen -catalog errors.yaml
+//
+// which writes apperror_gen.go and apperror_gen_test.go next to the catalog file.
+// The catalog lists, for each code, its name, HTTP status, gRPC code, default message, severity and whether it is retryable:
+//
+//	package: billing
+//	base: 1000
+//	codes:
+//	  - name: QuotaExceeded
+//	    http_status: 429
+//	    grpc_code: ResourceExhausted
+//	    message: The quota is exceeded
+//	    severity: warning
+//	    retryable: true
+package main
+
+import (
+	"flag"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/zhongjie-cai/app-error/internal/catalog"
+)
+
+func main() {
+	if err := run(os.Args[1:], os.Getenv("GOPACKAGE")); err != nil {
+		fmt.Fprintln(os.Stderr, "apperror-gen:", err)
+		os.Exit(1)
+	}
+}
+
+// run parses the command line, then loads the catalog and writes the generated files
+func run(args []string, goPackage string) error {
+	var flags = flag.NewFlagSet("apperror-gen", flag.ContinueOnError)
+	var catalogPath = flags.String("catalog", "errors.yaml", "path of the YAML or JSON error catalog")
+	var output = flags.String("output", "", "path of the generated source file; defaults to apperror_gen.go next to the catalog")
+	var packageName = flags.String("package", "", "name of the generated package; defaults to the catalog package, then $GOPACKAGE")
+	if err := flags.Parse(args); err != nil {
+		return err
+	}
+	var errorCatalog, err = catalog.Load(*catalogPath)
+	if err != nil {
+		return err
+	}
+	if *packageName == "" {
+		*packageName = errorCatalog.Package
+	}
+	if *packageName == "" {
+		*packageName = goPackage
+	}
+	if *packageName == "" {
+		return fmt.Errorf("package name is unknown; set it in the catalog or with -package")
+	}
+	if *output == "" {
+		*output = filepath.Join(filepath.Dir(*catalogPath), "apperror_gen.go")
+	}
+	sourceFile, testFile, err := generate(filepath.Base(*catalogPath), *packageName, errorCatalog)
+	if err != nil {
+		return err
+	}
+	if err := os.WriteFile(*output, sourceFile, 0o644); err != nil {
+		return err
+	}
+	return os.WriteFile(strings.TrimSuffix(*output, ".go")+"_test.go", testFile, 0o644)
+}
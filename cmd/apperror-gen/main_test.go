@@ -0,0 +1,75 @@
+package main
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func copyCatalog(t *testing.T) string {
+	var content, err = os.ReadFile(filepath.Join("testdata", "errors.yaml"))
+	assert.NoError(t, err)
+	var path = filepath.Join(t.TempDir(), "errors.yaml")
+	assert.NoError(t, os.WriteFile(path, content, 0o600))
+	return path
+}
+
+func TestRun_MatchesGolden(t *testing.T) {
+	// arrange
+	var catalogPath = copyCatalog(t)
+	var expectedSource, _ = os.ReadFile(filepath.Join("testdata", "apperror_gen.go.golden"))
+	var expectedTest, _ = os.ReadFile(filepath.Join("testdata", "apperror_gen_test.go.golden"))
+
+	// act
+	var err = run([]string{"-catalog", catalogPath}, "")
+
+	// assert
+	assert.NoError(t, err)
+	var source, _ = os.ReadFile(filepath.Join(filepath.Dir(catalogPath), "apperror_gen.go"))
+	var test, _ = os.ReadFile(filepath.Join(filepath.Dir(catalogPath), "apperror_gen_test.go"))
+	assert.Equal(t, string(expectedSource), string(source))
+	assert.Equal(t, string(expectedTest), string(test))
+}
+
+func TestRun_PackageOverride(t *testing.T) {
+	// arrange
+	var catalogPath = copyCatalog(t)
+	var output = filepath.Join(filepath.Dir(catalogPath), "codes.go")
+
+	// act
+	var err = run([]string{"-catalog", catalogPath, "-package", "payments", "-output", output}, "ignored")
+
+	// assert
+	assert.NoError(t, err)
+	var source, _ = os.ReadFile(output)
+	assert.Contains(t, string(source), "\npackage payments\n")
+	assert.FileExists(t, filepath.Join(filepath.Dir(catalogPath), "codes_test.go"))
+}
+
+func TestRun_GoPackageFallback(t *testing.T) {
+	// arrange
+	var catalogPath = filepath.Join(t.TempDir(), "errors.json")
+	os.WriteFile(catalogPath, []byte(`{"base": 100, "codes": [{"name": "A", "message": "m"}]}`), 0o600)
+
+	// act
+	var err = run([]string{"-catalog", catalogPath}, "fromenv")
+	var missingErr = run([]string{"-catalog", catalogPath}, "")
+
+	// assert
+	assert.NoError(t, err)
+	var source, _ = os.ReadFile(filepath.Join(filepath.Dir(catalogPath), "apperror_gen.go"))
+	assert.Contains(t, string(source), "\npackage fromenv\n")
+	assert.EqualError(t, missingErr, "package name is unknown; set it in the catalog or with -package")
+}
+
+func TestRun_Errors(t *testing.T) {
+	// act
+	var flagErr = run([]string{"-unknown"}, "")
+	var loadErr = run([]string{"-catalog", filepath.Join(t.TempDir(), "missing.yaml")}, "")
+
+	// assert
+	assert.Error(t, flagErr)
+	assert.Error(t, loadErr)
+}
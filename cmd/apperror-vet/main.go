@@ -0,0 +1,14 @@
+// Command apperror-vet runs the app error misuse checker, standalone or through go vet:
+//
+//	go install github.com/zhongjie-cai/app-error/cmd/apperror-vet
+//	go vet -vettool=$(which apperror-vet) ./...
+package main
+
+import (
+	"github.com/zhongjie-cai/app-error/analyzer"
+	"golang.org/x/tools/go/analysis/singlechecker"
+)
+
+func main() {
+	singlechecker.Main(analyzer.Analyzer)
+}
@@ -0,0 +1,124 @@
+// Command apperror parses the one-line "(Code) Message [ data ] [ inner errors ]" text of app errors found in log lines and prints it as an indented tree or as JSON.
+//
+//	apperror [-json] [-code NotFound,BadRequest] [-color auto|always|never] [file ...]
+//
+// Lines are read from the given files, or from stdin when none is given.
+// Lines without an app error are echoed unchanged in tree mode, and skipped in JSON mode or when filtering by code.
+package main
+
+import (
+	"bufio"
+	"encoding/json"
+	"flag"
+	"fmt"
+	"io"
+	"os"
+	"strings"
+
+	"github.com/zhongjie-cai/app-error/internal/errortext"
+)
+
+// options are the parsed command line flags
+type options struct {
+	json  bool
+	codes []string
+	color bool
+}
+
+// jsonLine is the JSON output of a single line
+type jsonLine struct {
+	Prefix string          `json:"prefix,omitempty"`
+	Error  *errortext.Node `json:"error"`
+}
+
+func main() {
+	if err := run(os.Args[1:], os.Stdin, os.Stdout, isTerminal(os.Stdout) && os.Getenv("NO_COLOR") == ""); err != nil {
+		fmt.Fprintln(os.Stderr, "apperror:", err)
+		os.Exit(1)
+	}
+}
+
+func isTerminal(file *os.File) bool {
+	var info, err = file.Stat()
+	return err == nil && info.Mode()&os.ModeCharDevice != 0
+}
+
+// run parses the command line and processes the inputs; autoColor tells whether colors are enabled in "auto" mode
+func run(args []string, stdin io.Reader, stdout io.Writer, autoColor bool) error {
+	var flags = flag.NewFlagSet("apperror", flag.ContinueOnError)
+	var jsonOutput = flags.Bool("json", false, "print each app error as a JSON object per line")
+	var codes = flags.String("code", "", "comma separated codes; only print app errors containing one of them")
+	var color = flags.String("color", "auto", "colorize the tree output: auto, always or never")
+	if err := flags.Parse(args); err != nil {
+		return err
+	}
+	var opts = options{json: *jsonOutput}
+	switch *color {
+	case "auto":
+		opts.color = autoColor
+	case "always":
+		opts.color = true
+	case "never":
+	default:
+		return fmt.Errorf("invalid -color %q", *color)
+	}
+	for _, code := range strings.Split(*codes, ",") {
+		if code = strings.TrimSpace(code); code != "" {
+			opts.codes = append(opts.codes, code)
+		}
+	}
+	var writer = bufio.NewWriter(stdout)
+	defer writer.Flush()
+	if flags.NArg() == 0 {
+		return process(stdin, writer, opts)
+	}
+	for _, path := range flags.Args() {
+		var file, err = os.Open(path)
+		if err != nil {
+			return err
+		}
+		err = process(file, writer, opts)
+		file.Close()
+		if err != nil {
+			return fmt.Errorf("%s: %w", path, err)
+		}
+	}
+	return nil
+}
+
+func matches(node *errortext.Node, codes []string) bool {
+	if len(codes) == 0 {
+		return true
+	}
+	for _, code := range codes {
+		if node.HasCode(code) {
+			return true
+		}
+	}
+	return false
+}
+
+// process reads the input line by line and writes the parsed app errors
+func process(input io.Reader, output io.Writer, opts options) error {
+	var scanner = bufio.NewScanner(input)
+	scanner.Buffer(make([]byte, 64*1024), 16*1024*1024)
+	var encoder = json.NewEncoder(output)
+	for scanner.Scan() {
+		var prefix, node, isFound = errortext.Find(scanner.Text())
+		if !isFound || !matches(node, opts.codes) {
+			if !opts.json && len(opts.codes) == 0 {
+				fmt.Fprintln(output, scanner.Text())
+			}
+			continue
+		}
+		if opts.json {
+			if err := encoder.Encode(jsonLine{Prefix: prefix, Error: node}); err != nil {
+				return err
+			}
+			continue
+		}
+		io.WriteString(output, prefix)
+		writeTree(output, node, "", opts.color)
+	}
+	return scanner.Err()
+}
@@ -0,0 +1,137 @@
+package main
+
+import (
+	"bytes"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+const testLog = `2024-01-02 INFO started
+2024-01-02 ERROR (NotFound) user 5 not found [ id = 5 ] [ (GeneralFailure) boom [ disk full ] | plain ]
+2024-01-02 ERROR (BadRequest) bad [ field = name ]
+`
+
+func TestRun_Tree(t *testing.T) {
+	// arrange
+	var stdout bytes.Buffer
+
+	// act
+	var err = run(nil, strings.NewReader(testLog), &stdout, false)
+
+	// assert
+	assert.NoError(t, err)
+	assert.Equal(t, `2024-01-02 INFO started
+2024-01-02 ERROR (NotFound) user 5 not found
+    id = 5
+    (GeneralFailure) boom
+        disk full
+    plain
+2024-01-02 ERROR (BadRequest) bad
+    field = name
+`, stdout.String())
+}
+
+func TestRun_TreeColored(t *testing.T) {
+	// arrange
+	var stdout bytes.Buffer
+
+	// act
+	var err = run([]string{"-color", "always"}, strings.NewReader("(BadRequest) bad [ field = name ]\n"), &stdout, false)
+
+	// assert
+	assert.NoError(t, err)
+	assert.Equal(t, "\x1b[1;31m(BadRequest)\x1b[0m bad\n    \x1b[36mfield\x1b[0m = name\n", stdout.String())
+}
+
+func TestRun_ColorAutoAndNever(t *testing.T) {
+	// arrange
+	var autoOutput, neverOutput bytes.Buffer
+
+	// act
+	run(nil, strings.NewReader("(BadRequest) bad\n"), &autoOutput, true)
+	run([]string{"-color", "never"}, strings.NewReader("(BadRequest) bad\n"), &neverOutput, true)
+
+	// assert
+	assert.Contains(t, autoOutput.String(), colorCode)
+	assert.Equal(t, "(BadRequest) bad\n", neverOutput.String())
+}
+
+func TestRun_JSONFilteredByCode(t *testing.T) {
+	// arrange
+	var stdout bytes.Buffer
+
+	// act
+	var err = run([]string{"-json", "-code", "GeneralFailure,Unauthorized"}, strings.NewReader(testLog), &stdout, false)
+
+	// assert
+	assert.NoError(t, err)
+	assert.JSONEq(t, `{
+		"prefix": "2024-01-02 ERROR ",
+		"error": {
+			"code": "NotFound",
+			"message": "user 5 not found",
+			"data": [{"key": "id", "value": "5"}],
+			"innerErrors": [
+				{"code": "GeneralFailure", "message": "boom", "innerErrors": [{"message": "disk full"}]},
+				{"message": "plain"}
+			]
+		}
+	}`, stdout.String())
+}
+
+func TestRun_FilterTreeSkipsOtherLines(t *testing.T) {
+	// arrange
+	var stdout bytes.Buffer
+
+	// act
+	var err = run([]string{"-code", "BadRequest"}, strings.NewReader(testLog), &stdout, false)
+
+	// assert
+	assert.NoError(t, err)
+	assert.Equal(t, "2024-01-02 ERROR (BadRequest) bad\n    field = name\n", stdout.String())
+}
+
+func TestRun_FilterTrimsCodes(t *testing.T) {
+	// arrange
+	var stdout bytes.Buffer
+
+	// act
+	var err = run([]string{"-code", "Foo, BadRequest,"}, strings.NewReader(testLog), &stdout, false)
+
+	// assert
+	assert.NoError(t, err)
+	assert.Equal(t, "2024-01-02 ERROR (BadRequest) bad\n    field = name\n", stdout.String())
+}
+
+func TestRun_Files(t *testing.T) {
+	// arrange
+	var directory = t.TempDir()
+	var first = filepath.Join(directory, "first.log")
+	var second = filepath.Join(directory, "second.log")
+	os.WriteFile(first, []byte("(NotFound) a\n"), 0o600)
+	os.WriteFile(second, []byte("(BadRequest) b\n"), 0o600)
+	var stdout bytes.Buffer
+
+	// act
+	var err = run([]string{first, second}, strings.NewReader("(Unauthorized) ignored\n"), &stdout, false)
+
+	// assert
+	assert.NoError(t, err)
+	assert.Equal(t, "(NotFound) a\n(BadRequest) b\n", stdout.String())
+}
+
+func TestRun_Errors(t *testing.T) {
+	// act
+	var flagErr = run([]string{"-unknown"}, strings.NewReader(""), &bytes.Buffer{}, false)
+	var colorErr = run([]string{"-color", "sometimes"}, strings.NewReader(""), &bytes.Buffer{}, false)
+	var fileErr = run([]string{filepath.Join(t.TempDir(), "missing.log")}, strings.NewReader(""), &bytes.Buffer{}, false)
+
+	// assert
+	assert.Error(t, flagErr)
+	assert.EqualError(t, colorErr, `invalid -color "sometimes"`)
+	assert.Error(t, fileErr)
+}
@@ -0,0 +1,44 @@
+package main
+
+import (
+	"io"
+	"strings"
+
+	"github.com/zhongjie-cai/app-error/internal/errortext"
+)
+
+// These are the tree layout and ANSI color constants; the layout matches the Detail() output of app errors
+const (
+	treeIndent = "    "
+	colorCode  = "\x1b[1;31m"
+	colorKey   = "\x1b[36m"
+	colorReset = "\x1b[0m"
+)
+
+func paint(text string, color string, isColored bool) string {
+	if !isColored {
+		return text
+	}
+	return color + text + colorReset
+}
+
+// writeTree writes the node and its descendants, one per line, each level indented further
+func writeTree(w io.Writer, node *errortext.Node, indent string, isColored bool) {
+	var builder strings.Builder
+	if node.Code != "" {
+		builder.WriteString(paint("("+node.Code+")", colorCode, isColored))
+		builder.WriteString(" ")
+	}
+	builder.WriteString(node.Message)
+	builder.WriteString("\n")
+	for _, field := range node.Data {
+		builder.WriteString(indent + treeIndent)
+		builder.WriteString(paint(field.Key, colorKey, isColored))
+		builder.WriteString(" = " + field.Value + "\n")
+	}
+	io.WriteString(w, builder.String())
+	for _, innerError := range node.InnerErrors {
+		io.WriteString(w, indent+treeIndent)
+		writeTree(w, innerError, indent+treeIndent, isColored)
+	}
+}
@@ -1,6 +1,10 @@
 package apperror
 
-import "net/http"
+import (
+	"net/http"
+	"sync"
+	"sync/atomic"
+)
 
 // Code are codes returned by service indicating operation results; it is an integer value of the enum that corresponds to a given error
 type Code int
@@ -20,7 +24,7 @@ const (
 	codeMaxCount
 )
 
-// String translates the enum
+// String translates the enum, giving the registered name of codes other than the built-in ones
 func (code Code) String() string {
 	var names = []string{
 		"GeneralFailure",
@@ -35,12 +39,62 @@ func (code Code) String() string {
 		"NotImplemented",
 	}
 	if code < 0 || code >= codeMaxCount {
+		if info, isRegistered := lookupCodeInfo(code); isRegistered {
+			return info.name
+		}
 		return "Unknown"
 	}
 	return names[code]
 }
 
-// HTTPStatusCode translates the built-in error Code to corresponding HTTP status code
+// codeInfo is the name and HTTP status code registered for a code other than the built-in ones
+type codeInfo struct {
+	name           string
+	httpStatusCode int
+}
+
+var (
+	// customCodes holds the codeInfo registered for codes other than the built-in ones
+	customCodes sync.Map
+	// customCodeNames holds the codes registered by their names
+	customCodeNames sync.Map
+	// codeGeneration counts the registrations, so that texts memoized with an older code name are rendered again
+	codeGeneration atomic.Uint64
+)
+
+// RegisterCode names the given code and maps it to the given HTTP status code; the built-in codes cannot be renamed
+func RegisterCode(code Code, name string, httpStatusCode int) {
+	if code >= 0 && code < codeMaxCount {
+		return
+	}
+	if previous, isRegistered := lookupCodeInfo(code); isRegistered {
+		customCodeNames.Delete(previous.name)
+	}
+	customCodes.Store(
+		code,
+		codeInfo{
+			name:           name,
+			httpStatusCode: httpStatusCode,
+		},
+	)
+	customCodeNames.Store(
+		name,
+		code,
+	)
+	codeGeneration.Add(1)
+}
+
+func lookupCodeInfo(code Code) (codeInfo, bool) {
+	var info, isRegistered = customCodes.Load(
+		code,
+	)
+	if !isRegistered {
+		return codeInfo{}, false
+	}
+	return info.(codeInfo), true
+}
+
+// HTTPStatusCode translates the error Code to corresponding HTTP status code, as registered for codes other than the built-in ones
 func (code Code) HTTPStatusCode() int {
 	var statusCode int
 	switch code {
@@ -66,6 +120,39 @@ func (code Code) HTTPStatusCode() int {
 		statusCode = http.StatusNotImplemented
 	default:
 		statusCode = http.StatusInternalServerError
+		if info, isRegistered := lookupCodeInfo(code); isRegistered {
+			statusCode = info.httpStatusCode
+		}
 	}
 	return statusCode
 }
+
+// Sentinel returns the immutable sentinel error of the Code; errors.Is(err, code.Sentinel()) reports whether err is, or has anywhere among its inner errors, an app error of the Code
+func (code Code) Sentinel() AppError {
+	var sentinel AppError
+	switch code {
+	case CodeGeneralFailure:
+		sentinel = ErrGeneralFailure
+	case CodeUnauthorized:
+		sentinel = ErrUnauthorized
+	case CodeInvalidOperation:
+		sentinel = ErrInvalidOperation
+	case CodeBadRequest:
+		sentinel = ErrBadRequest
+	case CodeNotFound:
+		sentinel = ErrNotFound
+	case CodeCircuitBreak:
+		sentinel = ErrCircuitBreak
+	case CodeOperationLock:
+		sentinel = ErrOperationLock
+	case CodeAccessForbidden:
+		sentinel = ErrAccessForbidden
+	case CodeDataCorruption:
+		sentinel = ErrDataCorruption
+	case CodeNotImplemented:
+		sentinel = ErrNotImplemented
+	default:
+		sentinel = getCustomSentinelFunc(code)
+	}
+	return sentinel
+}
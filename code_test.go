@@ -405,3 +405,108 @@ func TestCodeEnumHTTPStatusCode_OtherCode(t *testing.T) {
 	// verify
 	verifyAll(t)
 }
+
+func TestCodeEnumSentinel_BuiltInCodes(t *testing.T) {
+	// arrange
+	var expectedSentinels = []AppError{
+		ErrGeneralFailure,
+		ErrUnauthorized,
+		ErrInvalidOperation,
+		ErrBadRequest,
+		ErrNotFound,
+		ErrCircuitBreak,
+		ErrOperationLock,
+		ErrAccessForbidden,
+		ErrDataCorruption,
+		ErrNotImplemented,
+	}
+
+	// mock
+	createMock(t)
+
+	for code := CodeGeneralFailure; code < codeMaxCount; code++ {
+		// act
+		var sentinel = code.Sentinel()
+
+		// assert
+		assert.Same(t, expectedSentinels[code], sentinel)
+	}
+
+	// verify
+	verifyAll(t)
+}
+
+func TestCodeEnumSentinel_OtherCode(t *testing.T) {
+	// arrange
+	var testCode = codeMaxCount + Code(rand.Intn(100))
+	var dummySentinel = &BaseAppError{}
+
+	// mock
+	createMock(t)
+
+	// expect
+	getCustomSentinelFuncExpected = 1
+	getCustomSentinelFunc = func(code Code) AppError {
+		getCustomSentinelFuncCalled++
+		assert.Equal(t, testCode, code)
+		return dummySentinel
+	}
+
+	// act
+	var sentinel = testCode.Sentinel()
+
+	// assert
+	assert.Same(t, dummySentinel, sentinel)
+
+	// verify
+	verifyAll(t)
+}
+
+func TestRegisterCode(t *testing.T) {
+	// arrange
+	var dummyCode = Code(2000 + rand.Intn(1000))
+	defer customCodes.Delete(dummyCode)
+	defer customCodeNames.Delete("SomeRenamedCode")
+
+	// mock
+	createMock(t)
+
+	// act
+	RegisterCode(dummyCode, "SomeCode", http.StatusTooManyRequests)
+	RegisterCode(dummyCode, "SomeRenamedCode", http.StatusTooManyRequests)
+	RegisterCode(CodeBadRequest, "SomeBuiltInCode", http.StatusTeapot)
+
+	// assert
+	assert.Equal(t, "SomeRenamedCode", dummyCode.String())
+	assert.Equal(t, http.StatusTooManyRequests, dummyCode.HTTPStatusCode())
+	assert.Equal(t, "BadRequest", CodeBadRequest.String())
+	assert.Equal(t, http.StatusBadRequest, CodeBadRequest.HTTPStatusCode())
+	var code, isKnown = parseCode("SomeRenamedCode")
+	assert.True(t, isKnown)
+	assert.Equal(t, dummyCode, code)
+	_, isKnown = parseCode("SomeCode")
+	assert.False(t, isKnown)
+	_, isKnown = parseCode("SomeBuiltInCode")
+	assert.False(t, isKnown)
+
+	// verify
+	verifyAll(t)
+}
+
+func TestRegisterCode_RenameRendersAgain(t *testing.T) {
+	// arrange
+	var dummyCode = Code(3000 + rand.Intn(1000))
+	defer customCodes.Delete(dummyCode)
+	defer customCodeNames.Delete("SomeRenamedCode")
+	RegisterCode(dummyCode, "SomeCode", http.StatusTooManyRequests)
+	var sut = NewBaseAppError(dummyCode, "some message")
+	var before = sut.Error()
+
+	// act
+	RegisterCode(dummyCode, "SomeRenamedCode", http.StatusTooManyRequests)
+	var after = sut.Error()
+
+	// assert
+	assert.Equal(t, "(SomeCode) some message", before)
+	assert.Equal(t, "(SomeRenamedCode) some message", after)
+}
@@ -0,0 +1,94 @@
+package apperror
+
+import (
+	"strconv"
+	"sync"
+)
+
+// Collector gathers errors, e.g. from batch validation, safely across goroutines and produces a single app error only if anything was collected
+type Collector struct {
+	lock   sync.Mutex
+	errors []error
+}
+
+// NewCollector creates an instance of Collector object
+func NewCollector() *Collector {
+	return &Collector{}
+}
+
+func appendFieldName(path string, name string) string {
+	if path == "" {
+		return name
+	}
+	return path + "." + name
+}
+
+// FieldPath builds a field path from the given segments: strings are joined by dots and integers are rendered as indexes, e.g. FieldPath("items", 3, "price") returns "items[3].price"
+func FieldPath(segments ...interface{}) string {
+	var path = ""
+	for _, segment := range segments {
+		switch typedSegment := segment.(type) {
+		case int:
+			path += "[" + strconv.Itoa(typedSegment) + "]"
+		case string:
+			path = appendFieldNameFunc(path, typedSegment)
+		default:
+			path = appendFieldNameFunc(path, fmtSprint(typedSegment))
+		}
+	}
+	return path
+}
+
+// Add appends the given list of errors to the collector; nil errors are ignored
+func (collector *Collector) Add(errs ...error) {
+	var cleanedErrors = cleanupInnerErrorsFunc(
+		errs,
+	)
+	if len(cleanedErrors) == 0 {
+		return
+	}
+	collector.lock.Lock()
+	defer collector.lock.Unlock()
+	collector.errors = append(
+		collector.errors,
+		cleanedErrors...,
+	)
+}
+
+// AddField appends the given error to the collector as violations of the field at given path; nil error is ignored, and validation errors understood by NewFieldViolations are expanded under the path
+func (collector *Collector) AddField(path string, err error) {
+	var fieldViolations = convertFieldViolationsFunc(
+		path,
+		err,
+	)
+	for _, fieldViolation := range fieldViolations {
+		collector.Add(
+			fieldViolation,
+		)
+	}
+}
+
+// Len returns the number of errors collected so far
+func (collector *Collector) Len() int {
+	collector.lock.Lock()
+	defer collector.lock.Unlock()
+	return len(collector.errors)
+}
+
+// Build creates an app error of given code wrapping all collected errors, or returns nil if nothing was collected
+func (collector *Collector) Build(code Code, messageFormat string, parameters ...interface{}) AppError {
+	collector.lock.Lock()
+	defer collector.lock.Unlock()
+	if len(collector.errors) == 0 {
+		return nil
+	}
+	var baseAppError = newBaseAppErrorFunc(
+		code,
+		messageFormat,
+		parameters...,
+	)
+	baseAppError.Wrap(
+		collector.errors...,
+	)
+	return baseAppError
+}
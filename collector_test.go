@@ -0,0 +1,403 @@
+package apperror
+
+import (
+	"errors"
+	"math/rand"
+	"strconv"
+	"sync"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestNewCollector(t *testing.T) {
+	// mock
+	createMock(t)
+
+	// SUT + act
+	var collector = NewCollector()
+
+	// assert
+	assert.NotNil(t, collector)
+	assert.Empty(t, collector.errors)
+
+	// verify
+	verifyAll(t)
+}
+
+func TestAppendFieldName_EmptyPath(t *testing.T) {
+	// arrange
+	var dummyName = "some name"
+
+	// mock
+	createMock(t)
+
+	// SUT + act
+	var result = appendFieldName(
+		"",
+		dummyName,
+	)
+
+	// assert
+	assert.Equal(t, dummyName, result)
+
+	// verify
+	verifyAll(t)
+}
+
+func TestAppendFieldName_NonEmptyPath(t *testing.T) {
+	// arrange
+	var dummyPath = "some path"
+	var dummyName = "some name"
+
+	// mock
+	createMock(t)
+
+	// SUT + act
+	var result = appendFieldName(
+		dummyPath,
+		dummyName,
+	)
+
+	// assert
+	assert.Equal(t, dummyPath+"."+dummyName, result)
+
+	// verify
+	verifyAll(t)
+}
+
+func TestFieldPath_NoSegments(t *testing.T) {
+	// mock
+	createMock(t)
+
+	// SUT + act
+	var result = FieldPath()
+
+	// assert
+	assert.Zero(t, result)
+
+	// verify
+	verifyAll(t)
+}
+
+func TestFieldPath_MixedSegments(t *testing.T) {
+	// arrange
+	var dummyName1 = "items"
+	var dummyIndex = rand.Intn(100)
+	var dummyName2 = 1.5
+	var dummyName2String = "some name 2"
+
+	// mock
+	createMock(t)
+
+	// expect
+	fmtSprintExpected = 1
+	fmtSprint = func(a ...interface{}) string {
+		fmtSprintCalled++
+		assert.Equal(t, 1, len(a))
+		assert.Equal(t, dummyName2, a[0])
+		return dummyName2String
+	}
+	appendFieldNameFuncExpected = 2
+	appendFieldNameFunc = func(path string, name string) string {
+		appendFieldNameFuncCalled++
+		if appendFieldNameFuncCalled == 1 {
+			assert.Zero(t, path)
+			assert.Equal(t, dummyName1, name)
+		} else {
+			assert.Equal(t, dummyName1+"["+strconv.Itoa(dummyIndex)+"]", path)
+			assert.Equal(t, dummyName2String, name)
+		}
+		return path + name
+	}
+
+	// SUT + act
+	var result = FieldPath(
+		dummyName1,
+		dummyIndex,
+		dummyName2,
+	)
+
+	// assert
+	assert.Equal(t, dummyName1+"["+strconv.Itoa(dummyIndex)+"]"+dummyName2String, result)
+
+	// verify
+	verifyAll(t)
+}
+
+func TestCollector_Add_NoValidErrors(t *testing.T) {
+	// arrange
+	var dummyErrors = []error{nil, nil}
+
+	// mock
+	createMock(t)
+
+	// expect
+	cleanupInnerErrorsFuncExpected = 1
+	cleanupInnerErrorsFunc = func(innerErrors []error) []error {
+		cleanupInnerErrorsFuncCalled++
+		assert.Equal(t, dummyErrors, innerErrors)
+		return nil
+	}
+
+	// SUT
+	var sut = &Collector{}
+
+	// act
+	sut.Add(
+		dummyErrors...,
+	)
+
+	// assert
+	assert.Empty(t, sut.errors)
+
+	// verify
+	verifyAll(t)
+}
+
+func TestCollector_Add_HasValidErrors(t *testing.T) {
+	// arrange
+	var dummyExistingError = errors.New("some existing error")
+	var dummyError1 = errors.New("some error 1")
+	var dummyError2 = errors.New("some error 2")
+	var dummyErrors = []error{dummyError1, nil, dummyError2}
+	var cleanedErrors = []error{dummyError1, dummyError2}
+
+	// mock
+	createMock(t)
+
+	// expect
+	cleanupInnerErrorsFuncExpected = 1
+	cleanupInnerErrorsFunc = func(innerErrors []error) []error {
+		cleanupInnerErrorsFuncCalled++
+		assert.Equal(t, dummyErrors, innerErrors)
+		return cleanedErrors
+	}
+
+	// SUT
+	var sut = &Collector{
+		errors: []error{dummyExistingError},
+	}
+
+	// act
+	sut.Add(
+		dummyErrors...,
+	)
+
+	// assert
+	assert.Equal(t, []error{dummyExistingError, dummyError1, dummyError2}, sut.errors)
+
+	// verify
+	verifyAll(t)
+}
+
+func TestCollector_AddField_NoViolations(t *testing.T) {
+	// arrange
+	var dummyPath = "some path"
+
+	// mock
+	createMock(t)
+
+	// expect
+	convertFieldViolationsFuncExpected = 1
+	convertFieldViolationsFunc = func(prefix string, err error) []FieldViolation {
+		convertFieldViolationsFuncCalled++
+		assert.Equal(t, dummyPath, prefix)
+		assert.Nil(t, err)
+		return nil
+	}
+
+	// SUT
+	var sut = &Collector{}
+
+	// act
+	sut.AddField(
+		dummyPath,
+		nil,
+	)
+
+	// assert
+	assert.Empty(t, sut.errors)
+
+	// verify
+	verifyAll(t)
+}
+
+func TestCollector_AddField_HasViolations(t *testing.T) {
+	// arrange
+	var dummyPath = "some path"
+	var dummyError = errors.New("some error")
+	var dummyFieldViolations = []FieldViolation{
+		{Field: "some field 1", Message: "some message 1"},
+		{Field: "some field 2", Message: "some message 2"},
+	}
+
+	// mock
+	createMock(t)
+
+	// expect
+	convertFieldViolationsFuncExpected = 1
+	convertFieldViolationsFunc = func(prefix string, err error) []FieldViolation {
+		convertFieldViolationsFuncCalled++
+		assert.Equal(t, dummyPath, prefix)
+		assert.Equal(t, dummyError, err)
+		return dummyFieldViolations
+	}
+	cleanupInnerErrorsFuncExpected = 2
+	cleanupInnerErrorsFunc = func(innerErrors []error) []error {
+		cleanupInnerErrorsFuncCalled++
+		return innerErrors
+	}
+
+	// SUT
+	var sut = &Collector{}
+
+	// act
+	sut.AddField(
+		dummyPath,
+		dummyError,
+	)
+
+	// assert
+	assert.Equal(t, []error{dummyFieldViolations[0], dummyFieldViolations[1]}, sut.errors)
+
+	// verify
+	verifyAll(t)
+}
+
+func TestCollector_Len(t *testing.T) {
+	// arrange
+	var dummyErrors = []error{
+		errors.New("some error 1"),
+		errors.New("some error 2"),
+	}
+
+	// mock
+	createMock(t)
+
+	// SUT
+	var sut = &Collector{
+		errors: dummyErrors,
+	}
+
+	// act
+	var result = sut.Len()
+
+	// assert
+	assert.Equal(t, len(dummyErrors), result)
+
+	// verify
+	verifyAll(t)
+}
+
+func TestCollector_Build_NothingCollected(t *testing.T) {
+	// arrange
+	var dummyCode = Code(rand.Intn(100))
+	var dummyMessageFormat = "some message format"
+
+	// mock
+	createMock(t)
+
+	// SUT
+	var sut = &Collector{}
+
+	// act
+	var result = sut.Build(
+		dummyCode,
+		dummyMessageFormat,
+	)
+
+	// assert
+	assert.Nil(t, result)
+
+	// verify
+	verifyAll(t)
+}
+
+func TestCollector_Build_HasCollectedErrors(t *testing.T) {
+	// arrange
+	var dummyCode = Code(rand.Intn(100))
+	var dummyMessageFormat = "some message format"
+	var dummyParameter = rand.Int()
+	var dummyErrors = []error{
+		errors.New("some error 1"),
+		FieldViolation{Field: "some path", Message: "some error 2"},
+	}
+	var dummyResult = &BaseAppError{}
+
+	// mock
+	createMock(t)
+
+	// expect
+	newBaseAppErrorFuncExpected = 1
+	newBaseAppErrorFunc = func(code Code, messageFormat string, parameters ...interface{}) *BaseAppError {
+		newBaseAppErrorFuncCalled++
+		assert.Equal(t, dummyCode, code)
+		assert.Equal(t, dummyMessageFormat, messageFormat)
+		assert.Equal(t, []interface{}{dummyParameter}, parameters)
+		return dummyResult
+	}
+	cleanupInnerErrorsFuncExpected = 1
+	cleanupInnerErrorsFunc = func(innerErrors []error) []error {
+		cleanupInnerErrorsFuncCalled++
+		assert.Equal(t, dummyErrors, innerErrors)
+		return innerErrors
+	}
+	notifyObserversFuncExpected = 1
+	notifyObserversFunc = func(event LifecycleEvent) {
+		notifyObserversFuncCalled++
+		assert.Equal(t, LifecycleEventWrapped, event.Kind)
+		assert.Equal(t, dummyResult, event.Error)
+		assert.Equal(t, dummyErrors, event.InnerErrors)
+	}
+
+	// SUT
+	var sut = &Collector{
+		errors: dummyErrors,
+	}
+
+	// act
+	var result = sut.Build(
+		dummyCode,
+		dummyMessageFormat,
+		dummyParameter,
+	)
+
+	// assert
+	assert.Equal(t, dummyResult, result)
+	assert.Equal(t, dummyErrors, dummyResult.innerErrors)
+
+	// verify
+	verifyAll(t)
+}
+
+func TestCollector_Concurrency(t *testing.T) {
+	// arrange
+	var dummyCount = 100
+	var waitGroup sync.WaitGroup
+
+	// SUT
+	var sut = NewCollector()
+
+	// act
+	for index := 0; index < dummyCount; index++ {
+		waitGroup.Add(1)
+		go func(index int) {
+			defer waitGroup.Done()
+			sut.AddField(
+				FieldPath("items", index, "price"),
+				errors.New("must be positive"),
+			)
+		}(index)
+	}
+	waitGroup.Wait()
+	var result = sut.Build(
+		CodeBadRequest,
+		"Request body is invalid",
+	)
+
+	// assert
+	assert.Equal(t, dummyCount, sut.Len())
+	assert.Equal(t, CodeBadRequest.String(), result.Code())
+	assert.Contains(t, result.Error(), "items[42].price : must be positive")
+}
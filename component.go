@@ -0,0 +1,142 @@
+package apperror
+
+import (
+	"github.com/zhongjie-cai/app-error/internal/errortext"
+)
+
+// componentError attributes a plain error to the component it came from, without changing its text
+type componentError struct {
+	component string
+	err       error
+}
+
+func (componentError *componentError) Error() string {
+	return componentError.err.Error()
+}
+
+// Unwrap returns the attributed error, keeping errors.Is and errors.As working through the attribution
+func (componentError *componentError) Unwrap() error {
+	return componentError.err
+}
+
+// EscapesOwnText reports whether the attributed error escapes its own text, as app errors of either version do, so the attribution leaves the escaping to it
+func (componentError *componentError) EscapesOwnText() bool {
+	return errortext.EscapesOwnText(componentError.err)
+}
+
+// Component returns the component the error came from
+func (componentError *componentError) Component() string {
+	return componentError.component
+}
+
+// MarshalJSON renders the attributed error as a JSON object with its message and component
+func (componentError *componentError) MarshalJSON() ([]byte, error) {
+	return jsonMarshal(
+		componentErrorJSON{
+			Message:   componentError.err.Error(),
+			Component: componentError.component,
+		},
+	)
+}
+
+type componentErrorJSON struct {
+	Message   string `json:"message"`
+	Component string `json:"component"`
+}
+
+// componenter is implemented by errors attributed to a component, such as *BaseAppError and the v2 *Error
+type componenter interface {
+	Component() string
+}
+
+// FromComponent returns the given error attributed to the named component, or nil for a nil error
+func FromComponent(component string, err error) error {
+	if isNilFunc(err) {
+		return nil
+	}
+	if baseAppError, isBase := err.(*BaseAppError); isBase {
+		return baseAppError.WithComponent(component)
+	}
+	return &componentError{
+		component: component,
+		err:       err,
+	}
+}
+
+// Component returns the component the app error is attributed to, if any; see ResponsibleComponent for the component ultimately responsible for a whole error tree
+func (baseAppError *BaseAppError) Component() string {
+	if baseAppError == nil {
+		return ""
+	}
+	return baseAppError.getDetails().component
+}
+
+// SetComponent attributes the app error to the named component, such as a database or a partner API
+func (baseAppError *BaseAppError) SetComponent(component string) {
+	if baseAppError == nil || baseAppError.frozen {
+		return
+	}
+	baseAppError.setDetails().component = component
+}
+
+// WithComponent returns a new immutable app error sharing the current one's data, attributed to the named component
+func (baseAppError *BaseAppError) WithComponent(component string) *BaseAppError {
+	if baseAppError == nil {
+		return nil
+	}
+	var clonedAppError = cloneBaseAppErrorFunc(
+		baseAppError,
+	)
+	clonedAppError.setDetails().component = component
+	return clonedAppError
+}
+
+// WrapFrom wraps the given list of inner errors into the current app error object, attributing them to the named component through FromComponent
+func (baseAppError *BaseAppError) WrapFrom(component string, innerErrors ...error) {
+	if baseAppError == nil || baseAppError.frozen {
+		return
+	}
+	var attributedErrors = make([]error, 0, len(innerErrors))
+	for _, innerError := range innerErrors {
+		attributedErrors = append(
+			attributedErrors,
+			fromComponentFunc(
+				component,
+				innerError,
+			),
+		)
+	}
+	baseAppError.Wrap(
+		attributedErrors...,
+	)
+}
+
+// findComponent returns the component of the most deeply nested attributed error of the tree, the first one at equal depths, along with its depth
+func findComponent(err error, depth int) (string, int) {
+	var component, componentDepth = "", -1
+	if typedError, isTyped := err.(componenter); isTyped && typedError.Component() != "" {
+		component, componentDepth = typedError.Component(), depth
+	}
+	for _, innerError := range getInnerErrorsFunc(err) {
+		if isNilFunc(innerError) {
+			continue
+		}
+		var innerComponent, innerDepth = findComponent(innerError, depth+1)
+		if innerDepth > componentDepth {
+			component, componentDepth = innerComponent, innerDepth
+		}
+	}
+	return component, componentDepth
+}
+
+// ResponsibleComponent returns the component ultimately responsible for the error: the component of the most deeply nested error of the tree attributed to one, or an empty string if none is
+func ResponsibleComponent(err error) string {
+	if isNilFunc(err) {
+		return ""
+	}
+	var component, _ = findComponentFunc(
+		err,
+		0,
+	)
+	return component
+}
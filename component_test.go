@@ -0,0 +1,382 @@
+package apperror
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestComponentError(t *testing.T) {
+	// arrange
+	var sut = &componentError{
+		component: "some component",
+		err:       io.EOF,
+	}
+
+	// act
+	var body, err = json.Marshal(sut)
+
+	// assert
+	assert.Equal(t, "EOF", sut.Error())
+	assert.Equal(t, "some component", sut.Component())
+	assert.True(t, errors.Is(sut, io.EOF))
+	assert.NoError(t, err)
+	assert.JSONEq(t, `{"message": "EOF", "component": "some component"}`, string(body))
+}
+
+func TestFromComponent_Nil(t *testing.T) {
+	// mock
+	createMock(t)
+
+	// expect
+	isNilFuncExpected = 1
+	isNilFunc = func(err error) bool {
+		isNilFuncCalled++
+		return true
+	}
+
+	// SUT + act
+	var result = FromComponent("some component", nil)
+
+	// assert
+	assert.Nil(t, result)
+
+	// verify
+	verifyAll(t)
+}
+
+func TestFromComponent_BaseAppError(t *testing.T) {
+	// arrange
+	var dummyAppError = &BaseAppError{}
+	var dummyClone = &BaseAppError{}
+
+	// mock
+	createMock(t)
+
+	// expect
+	isNilFuncExpected = 1
+	isNilFunc = func(err error) bool {
+		isNilFuncCalled++
+		return false
+	}
+	cloneBaseAppErrorFuncExpected = 1
+	cloneBaseAppErrorFunc = func(baseAppError *BaseAppError) *BaseAppError {
+		cloneBaseAppErrorFuncCalled++
+		assert.Same(t, dummyAppError, baseAppError)
+		return dummyClone
+	}
+
+	// SUT + act
+	var result = FromComponent("some component", dummyAppError)
+
+	// assert
+	assert.Same(t, dummyClone, result)
+	assert.Equal(t, "some component", dummyClone.Component())
+	assert.Empty(t, dummyAppError.Component())
+
+	// verify
+	verifyAll(t)
+}
+
+func TestFromComponent_FrozenBaseAppError(t *testing.T) {
+	// arrange
+	var dummyAppError = &BaseAppError{frozen: true}
+	var dummyClone = &BaseAppError{}
+
+	// mock
+	createMock(t)
+
+	// expect
+	isNilFuncExpected = 1
+	isNilFunc = func(err error) bool {
+		isNilFuncCalled++
+		return false
+	}
+	cloneBaseAppErrorFuncExpected = 1
+	cloneBaseAppErrorFunc = func(baseAppError *BaseAppError) *BaseAppError {
+		cloneBaseAppErrorFuncCalled++
+		assert.Same(t, dummyAppError, baseAppError)
+		return dummyClone
+	}
+
+	// SUT + act
+	var result = FromComponent("some component", dummyAppError)
+
+	// assert
+	assert.Same(t, dummyClone, result)
+	assert.Equal(t, "some component", dummyClone.Component())
+	assert.Empty(t, dummyAppError.Component())
+
+	// verify
+	verifyAll(t)
+}
+
+func TestBaseAppError_WrapFrom_CallerErrorUntouched(t *testing.T) {
+	// arrange
+	var dummyInnerError = NewBaseAppError(CodeNotFound, "some inner error")
+	var sut = NewBaseAppError(CodeGeneralFailure, "some error")
+
+	// act
+	sut.WrapFrom("some component", dummyInnerError)
+	dummyInnerError.Attach("id", 5)
+
+	// assert
+	assert.Empty(t, dummyInnerError.Component())
+	assert.False(t, dummyInnerError.IsFrozen())
+	assert.Equal(t, "some component", ResponsibleComponent(sut))
+	assert.Equal(t, "(GeneralFailure) some error [ (NotFound) some inner error ]", sut.Error())
+	assert.True(t, errors.Is(sut.InnerErrors()[0], dummyInnerError))
+}
+
+func TestFromComponent_PlainError(t *testing.T) {
+	// arrange
+	var dummyError = errors.New("some error")
+
+	// mock
+	createMock(t)
+
+	// expect
+	isNilFuncExpected = 1
+	isNilFunc = func(err error) bool {
+		isNilFuncCalled++
+		return false
+	}
+
+	// SUT + act
+	var result = FromComponent("some component", dummyError)
+
+	// assert
+	assert.Equal(t, &componentError{component: "some component", err: dummyError}, result)
+
+	// verify
+	verifyAll(t)
+}
+
+func TestBaseAppError_Component_Nil(t *testing.T) {
+	// arrange
+	var sut *BaseAppError
+
+	// act
+	sut.SetComponent("some component")
+	sut.WrapFrom("some component", errors.New("some error"))
+
+	// assert
+	assert.Empty(t, sut.Component())
+	assert.Nil(t, sut.WithComponent("some component"))
+}
+
+func TestBaseAppError_SetComponent_Frozen(t *testing.T) {
+	// arrange
+	var sut = &BaseAppError{frozen: true}
+
+	// act
+	sut.SetComponent("some component")
+
+	// assert
+	assert.Empty(t, sut.Component())
+}
+
+func TestBaseAppError_WrapFrom(t *testing.T) {
+	// arrange
+	var dummyInnerError1 = errors.New("some inner error 1")
+	var dummyInnerError2 = errors.New("some inner error 2")
+	var dummyAttributedError1 = errors.New("some attributed error 1")
+	var dummyAttributedError2 = errors.New("some attributed error 2")
+	var sut = &BaseAppError{}
+
+	// mock
+	createMock(t)
+
+	// expect
+	fromComponentFuncExpected = 2
+	fromComponentFunc = func(component string, err error) error {
+		fromComponentFuncCalled++
+		assert.Equal(t, "some component", component)
+		if fromComponentFuncCalled == 1 {
+			assert.Equal(t, dummyInnerError1, err)
+			return dummyAttributedError1
+		}
+		assert.Equal(t, dummyInnerError2, err)
+		return dummyAttributedError2
+	}
+	cleanupInnerErrorsFuncExpected = 1
+	cleanupInnerErrorsFunc = func(innerErrors []error) []error {
+		cleanupInnerErrorsFuncCalled++
+		assert.Equal(t, []error{dummyAttributedError1, dummyAttributedError2}, innerErrors)
+		return innerErrors
+	}
+	notifyObserversFuncExpected = 1
+
+	// SUT + act
+	sut.WrapFrom("some component", dummyInnerError1, dummyInnerError2)
+
+	// assert
+	assert.Equal(t, []error{dummyAttributedError1, dummyAttributedError2}, sut.innerErrors)
+
+	// verify
+	verifyAll(t)
+}
+
+func TestFindComponent(t *testing.T) {
+	// arrange
+	var deepest = &componentError{component: "deepest", err: errors.New("some error")}
+	var sut = &BaseAppError{
+		details: &errorDetails{component: "outer"},
+		innerErrors: []error{
+			nil,
+			&BaseAppError{
+				details:     &errorDetails{component: "middle"},
+				innerErrors: []error{fmt.Errorf("some wrapper: %w", deepest)},
+			},
+			&BaseAppError{
+				innerErrors: []error{&componentError{component: "later", err: errors.New("some error")}},
+			},
+		},
+	}
+
+	// mock
+	createMock(t)
+
+	// expect
+	getInnerErrorsFuncExpected = 8
+	getInnerErrorsFunc = func(err error) []error {
+		getInnerErrorsFuncCalled++
+		return getInnerErrors(err)
+	}
+	isNilFuncExpected = 8
+	isNilFunc = func(err error) bool {
+		isNilFuncCalled++
+		return err == nil
+	}
+
+	// SUT + act
+	var component, depth = findComponent(sut, 0)
+
+	// assert
+	assert.Equal(t, "deepest", component)
+	assert.Equal(t, 3, depth)
+
+	// verify
+	verifyAll(t)
+}
+
+func TestResponsibleComponent_Nil(t *testing.T) {
+	// mock
+	createMock(t)
+
+	// expect
+	isNilFuncExpected = 1
+	isNilFunc = func(err error) bool {
+		isNilFuncCalled++
+		return true
+	}
+
+	// SUT + act
+	var result = ResponsibleComponent(nil)
+
+	// assert
+	assert.Empty(t, result)
+
+	// verify
+	verifyAll(t)
+}
+
+func TestResponsibleComponent_Found(t *testing.T) {
+	// arrange
+	var dummyError = errors.New("some error")
+
+	// mock
+	createMock(t)
+
+	// expect
+	isNilFuncExpected = 1
+	isNilFunc = func(err error) bool {
+		isNilFuncCalled++
+		return false
+	}
+	findComponentFuncExpected = 1
+	findComponentFunc = func(err error, depth int) (string, int) {
+		findComponentFuncCalled++
+		assert.Equal(t, dummyError, err)
+		assert.Zero(t, depth)
+		return "some component", 2
+	}
+
+	// SUT + act
+	var result = ResponsibleComponent(dummyError)
+
+	// assert
+	assert.Equal(t, "some component", result)
+
+	// verify
+	verifyAll(t)
+}
+
+func TestComponent_Lifecycle(t *testing.T) {
+	// arrange
+	SetInstanceIDGenerator(func() string { return "" })
+	defer SetInstanceIDGenerator(nil)
+	var buffer bytes.Buffer
+	var sut = GetGeneralFailureError()
+	sut.(*BaseAppError).WrapFrom("partner-api", GetCircuitBreakError(), io.ErrUnexpectedEOF)
+	sut.(*BaseAppError).SetComponent("orders")
+	var derived = ErrNotFound.WithComponent("users-db")
+
+	// act
+	var tree = sut.(*BaseAppError).Detail()
+	var body, err = json.Marshal(sut)
+	newTestLogger(&buffer).Error("failed", "error", sut)
+
+	// assert
+	assert.Equal(t, "(GeneralFailure) An error occurred during execution [ (CircuitBreak) Operation refused due to internal circuit break on correlation ID | unexpected EOF ]", sut.Error())
+	assert.Equal(t, "(GeneralFailure) An error occurred during execution\n    component: orders\n    (CircuitBreak) Operation refused due to internal circuit break on correlation ID\n        component: partner-api\n    unexpected EOF", tree)
+	assert.NoError(t, err)
+	assert.JSONEq(t, `{
+		"code": "GeneralFailure",
+		"message": "An error occurred during execution",
+		"component": "orders",
+		"innerErrors": [
+			{"code": "CircuitBreak", "message": "Operation refused due to internal circuit break on correlation ID", "component": "partner-api"},
+			{"message": "unexpected EOF", "component": "partner-api"}
+		]
+	}`, string(body))
+	assert.Contains(t, buffer.String(), "error.component=partner-api")
+	assert.Equal(t, "partner-api", ResponsibleComponent(sut))
+	assert.Equal(t, "users-db", derived.Component())
+	assert.Empty(t, ErrNotFound.Component())
+	assert.Equal(t, FaultDependency, ResolveFault(sut))
+}
+
+func TestComponentError_EscapesOwnText(t *testing.T) {
+	// arrange
+	var dummyPlain = &componentError{component: "some component", err: errors.New("some error")}
+	var dummyEscaping = &componentError{component: "some component", err: selfEscapingError{}}
+
+	// assert
+	assert.False(t, dummyPlain.EscapesOwnText())
+	assert.True(t, dummyEscaping.EscapesOwnText())
+}
+
+func TestWrapFrom_EscapedPrintMode_ParseRoundTrip(t *testing.T) {
+	// arrange
+	SetPrintMode(PrintModeEscaped)
+	defer SetPrintMode(PrintModeLegacy)
+	var dummyInner = embeddingAppError{NewBaseAppError(CodeNotFound, "a|b")}
+	var sut = NewBaseAppError(CodeBadRequest, "some message")
+	sut.WrapFrom("db", dummyInner, errors.New("c|d"))
+
+	// act
+	var text = sut.Error()
+	var parsed, err = Parse(text)
+
+	// assert
+	assert.Equal(t, `(BadRequest) some message [ (NotFound) a\|b | c\|d ]`, text)
+	assert.NoError(t, err)
+	assert.Equal(t, "NotFound", parsed.(*BaseAppError).InnerErrors()[0].(AppError).Code())
+	assert.Equal(t, text, parsed.Error())
+}
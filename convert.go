@@ -0,0 +1,48 @@
+package apperror
+
+import (
+	"github.com/zhongjie-cai/app-error/internal/convert"
+)
+
+func init() {
+	convert.NewV1AppError = newConvertedAppErrorFromData
+}
+
+// newConvertedAppErrorFromData creates an app error from the data of one converted by the v2 package
+func newConvertedAppErrorFromData(appError convert.AppError) error {
+	return newConvertedAppError(
+		Code(appError.Code),
+		appError.Message,
+		appError.InnerErrors,
+		appError.ExtraData,
+		appError.Component,
+		appError.InstanceID,
+	)
+}
+
+// newConvertedAppError creates an app error from one converted from another representation, such as a v2 error, without reporting it as created
+func newConvertedAppError(code Code, message string, innerErrors []error, extraData map[string]interface{}, component string, instanceID string) *BaseAppError {
+	var baseAppError = &BaseAppError{
+		code:          code,
+		messageFormat: message,
+		innerErrors:   cleanupInnerErrorsFunc(innerErrors),
+		extraData:     copyExtraData(extraData),
+	}
+	if component != "" {
+		baseAppError.setDetails().component = component
+	}
+	if instanceID != "" {
+		baseAppError.getCache().instanceID.Store(&instanceID)
+	}
+	if len(baseAppError.innerErrors) == 0 {
+		baseAppError.innerErrors = nil
+	}
+	setMessageFunc(
+		baseAppError,
+		"%s",
+		[]interface{}{
+			message,
+		},
+	)
+	return baseAppError
+}
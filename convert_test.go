@@ -0,0 +1,114 @@
+package apperror
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/zhongjie-cai/app-error/internal/convert"
+)
+
+func TestNewConvertedAppError(t *testing.T) {
+	// arrange
+	var dummyCode = CodeNotFound
+	var dummyMessage = "some message"
+	var dummyInnerError = errors.New("some inner error")
+	var dummyInnerErrors = []error{dummyInnerError, nil}
+	var dummyExtraData = map[string]interface{}{"id": 5}
+	var dummyMessageError = errors.New("some message error")
+
+	// mock
+	createMock(t)
+
+	// expect
+	cleanupInnerErrorsFuncExpected = 1
+	cleanupInnerErrorsFunc = func(innerErrors []error) []error {
+		cleanupInnerErrorsFuncCalled++
+		assert.Equal(t, dummyInnerErrors, innerErrors)
+		return []error{dummyInnerError}
+	}
+	setMessageFuncExpected = 1
+	setMessageFunc = func(baseAppError *BaseAppError, messageFormat string, parameters []interface{}) {
+		setMessageFuncCalled++
+		assert.Equal(t, "%s", messageFormat)
+		assert.Equal(t, []interface{}{dummyMessage}, parameters)
+		baseAppError.error = dummyMessageError
+	}
+
+	// SUT + act
+	var result = newConvertedAppError(
+		dummyCode,
+		dummyMessage,
+		dummyInnerErrors,
+		dummyExtraData,
+		"some component",
+		"some instance id",
+	)
+	dummyExtraData["id"] = 6
+
+	// assert
+	assert.Equal(t, dummyCode, result.code)
+	assert.Equal(t, dummyMessage, result.MessageTemplate())
+	assert.Equal(t, dummyMessageError, result.error)
+	assert.Equal(t, []error{dummyInnerError}, result.innerErrors)
+	assert.Equal(t, map[string]interface{}{"id": 5}, result.extraData)
+	assert.Equal(t, "some component", result.Component())
+	assert.Equal(t, "some instance id", result.InstanceID())
+	assert.False(t, result.frozen)
+
+	// verify
+	verifyAll(t)
+}
+
+func TestNewConvertedAppError_Empty(t *testing.T) {
+	// mock
+	createMock(t)
+
+	// expect
+	cleanupInnerErrorsFuncExpected = 1
+	cleanupInnerErrorsFunc = func(innerErrors []error) []error {
+		cleanupInnerErrorsFuncCalled++
+		return []error{}
+	}
+	setMessageFuncExpected = 1
+	setMessageFunc = func(baseAppError *BaseAppError, messageFormat string, parameters []interface{}) {
+		setMessageFuncCalled++
+	}
+
+	// SUT + act
+	var result = newConvertedAppError(CodeNotFound, "some message", nil, nil, "", "")
+
+	// assert
+	assert.Nil(t, result.innerErrors)
+	assert.Nil(t, result.extraData)
+
+	// verify
+	verifyAll(t)
+}
+
+func TestNewConvertedAppErrorFromData(t *testing.T) {
+	// arrange
+	var dummyInnerError = errors.New("some inner error")
+
+	// SUT + act
+	var result = convert.NewV1AppError(
+		convert.AppError{
+			Code:        int(CodeNotFound),
+			Message:     "some message",
+			InnerErrors: []error{dummyInnerError},
+			ExtraData:   map[string]interface{}{"id": 5},
+			Component:   "some component",
+			InstanceID:  "some instance id",
+		},
+	)
+
+	// assert
+	var baseAppError, isBase = result.(*BaseAppError)
+	assert.True(t, isBase)
+	assert.Equal(t, CodeNotFound, baseAppError.code)
+	assert.Equal(t, "some message", baseAppError.Message())
+	assert.Equal(t, []error{dummyInnerError}, baseAppError.innerErrors)
+	assert.Equal(t, map[string]interface{}{"id": 5}, baseAppError.extraData)
+	assert.Equal(t, "some component", baseAppError.Component())
+	assert.Equal(t, "some instance id", baseAppError.InstanceID())
+}
@@ -0,0 +1,47 @@
+package apperror
+
+import (
+	"sync/atomic"
+	"time"
+)
+
+// Clock returns the current time, such as time.Now
+type Clock func() time.Time
+
+var (
+	creationClock    atomic.Pointer[Clock]
+	creationSequence atomic.Uint64
+)
+
+// SetClock makes NewBaseAppError stamp app errors with their creation time, taken from the given clock such as time.Now, and a process-unique sequence number; pass nil to stop stamping, which is the default and keeps the output of app errors unchanged
+func SetClock(clock Clock) {
+	if clock == nil {
+		creationClock.Store(nil)
+		return
+	}
+	creationClock.Store(&clock)
+}
+
+func stampCreation() (time.Time, uint64) {
+	var clock = creationClock.Load()
+	if clock == nil {
+		return time.Time{}, 0
+	}
+	return (*clock)(), creationSequence.Add(1)
+}
+
+// CreatedAt returns when NewBaseAppError created the app error, or the zero time if it was not stamped (see SetClock); errors derived through With or WithCause keep the creation time of the error they derive from
+func (baseAppError *BaseAppError) CreatedAt() time.Time {
+	if baseAppError == nil {
+		return time.Time{}
+	}
+	return baseAppError.getDetails().createdAt
+}
+
+// Sequence returns the process-unique, increasing number NewBaseAppError assigned to the app error, or 0 if it was not stamped (see SetClock), for sentinels and for nil; errors derived through With or WithCause keep the sequence of the error they derive from
+func (baseAppError *BaseAppError) Sequence() uint64 {
+	if baseAppError == nil {
+		return 0
+	}
+	return baseAppError.getDetails().sequence
+}
@@ -0,0 +1,104 @@
+package apperror
+
+import (
+	"encoding/json"
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestStampCreation_NoClock(t *testing.T) {
+	// mock
+	createMock(t)
+
+	// SUT + act
+	SetClock(nil)
+	var createdAt, sequence = stampCreation()
+
+	// assert
+	assert.True(t, createdAt.IsZero())
+	assert.Zero(t, sequence)
+
+	// verify
+	verifyAll(t)
+}
+
+func TestStampCreation_WithClock(t *testing.T) {
+	// arrange
+	var dummyTime = time.Date(2026, 10, 18, 1, 2, 3, 4, time.UTC)
+
+	// mock
+	createMock(t)
+
+	// SUT + act
+	SetClock(func() time.Time { return dummyTime })
+	defer SetClock(nil)
+	var createdAt1, sequence1 = stampCreation()
+	var createdAt2, sequence2 = stampCreation()
+
+	// assert
+	assert.Equal(t, dummyTime, createdAt1)
+	assert.Equal(t, dummyTime, createdAt2)
+	assert.NotZero(t, sequence1)
+	assert.Equal(t, sequence1+1, sequence2)
+
+	// verify
+	verifyAll(t)
+}
+
+func TestCreatedAt_Sequence_Nil(t *testing.T) {
+	// arrange
+	var sut *BaseAppError
+
+	// assert
+	assert.True(t, sut.CreatedAt().IsZero())
+	assert.Zero(t, sut.Sequence())
+}
+
+func TestCreatedAt_Sequence_Stamped(t *testing.T) {
+	// arrange
+	var dummyTime = time.Date(2026, 10, 18, 1, 2, 3, 4, time.UTC)
+	SetClock(func() time.Time { return dummyTime })
+	defer SetClock(nil)
+
+	// act
+	var first = NewBaseAppError(CodeNotFound, "some message")
+	var second = NewBaseAppError(CodeNotFound, "some message")
+	var derived = first.With("some name", "some value")
+
+	// assert
+	assert.Equal(t, dummyTime, first.CreatedAt())
+	assert.Less(t, first.Sequence(), second.Sequence())
+	assert.Equal(t, first.CreatedAt(), derived.CreatedAt())
+	assert.Equal(t, first.Sequence(), derived.Sequence())
+	assert.Zero(t, ErrNotFound.Sequence())
+}
+
+func TestCreatedAt_Sequence_Output(t *testing.T) {
+	// arrange
+	var dummyTime = time.Date(2026, 10, 18, 1, 2, 3, 4, time.UTC)
+	var sut = &BaseAppError{
+		error:     fmt.Errorf("some message"),
+		code:      CodeNotFound,
+		extraData: map[string]interface{}{},
+		details: &errorDetails{
+			createdAt: dummyTime,
+			sequence:  42,
+		},
+	}
+	var decoded map[string]interface{}
+
+	// act
+	var tree = sut.Detail()
+	var body, err = json.Marshal(sut)
+
+	// assert
+	assert.Equal(t, "(NotFound) some message\n    sequence: 42\n    created: 2026-10-18T01:02:03.000000004Z", tree)
+	assert.NoError(t, err)
+	assert.NoError(t, json.Unmarshal(body, &decoded))
+	assert.Equal(t, float64(42), decoded["sequence"])
+	assert.Equal(t, "2026-10-18T01:02:03.000000004Z", decoded["createdAt"])
+	assert.Equal(t, "(NotFound) some message", sut.Error())
+}
@@ -0,0 +1,133 @@
+package apperror
+
+import (
+	"sync"
+)
+
+// Fault tells whose fault an error is, for SLO and error budget accounting
+type Fault int
+
+// These are the fault classifications
+const (
+	// FaultUnspecified is the classification of nil errors; registering it for a code leaves the code unclassified
+	FaultUnspecified Fault = iota
+	// FaultClient is the caller's fault, such as an invalid or unauthorized request, and does not count against the service's error budget
+	FaultClient
+	// FaultServer is the service's own fault
+	FaultServer
+	// FaultDependency is the fault of a downstream dependency of the service
+	FaultDependency
+)
+
+// String returns the lower case name of the fault
+func (fault Fault) String() string {
+	switch fault {
+	case FaultClient:
+		return "client"
+	case FaultServer:
+		return "server"
+	case FaultDependency:
+		return "dependency"
+	}
+	return "unspecified"
+}
+
+// customFaults holds the faults registered for codes other than the built-in ones
+var customFaults sync.Map
+
+// RegisterFault classifies the given code; the built-in codes cannot be reclassified
+func RegisterFault(code Code, fault Fault) {
+	if code >= 0 && code < codeMaxCount {
+		return
+	}
+	if fault == FaultUnspecified {
+		customFaults.Delete(code)
+		return
+	}
+	customFaults.Store(code, fault)
+}
+
+// Fault returns the classification of the Code; codes that are neither built-in nor registered are the service's fault
+func (code Code) Fault() Fault {
+	switch code {
+	case CodeUnauthorized,
+		CodeInvalidOperation,
+		CodeBadRequest,
+		CodeNotFound,
+		CodeOperationLock,
+		CodeAccessForbidden:
+		return FaultClient
+	case CodeCircuitBreak:
+		return FaultDependency
+	case CodeGeneralFailure,
+		CodeDataCorruption,
+		CodeNotImplemented:
+		return FaultServer
+	}
+	if fault, isRegistered := customFaults.Load(code); isRegistered {
+		return fault.(Fault)
+	}
+	return FaultServer
+}
+
+// Fault returns the classification of the code of the app error, regardless of its inner errors; see ResolveFault for the classification of a whole error tree
+func (baseAppError *BaseAppError) Fault() Fault {
+	if baseAppError == nil {
+		return CodeGeneralFailure.Fault()
+	}
+	return baseAppError.code.Fault()
+}
+
+// faulter is implemented by errors knowing their own fault, such as *BaseAppError and the v2 *Error
+type faulter interface {
+	Fault() Fault
+}
+
+func getInnerErrors(err error) []error {
+	switch typedError := err.(type) {
+	case interface{ InnerErrors() []error }:
+		return typedError.InnerErrors()
+	case interface{ Unwrap() []error }:
+		return typedError.Unwrap()
+	case interface{ Unwrap() error }:
+		if innerError := typedError.Unwrap(); innerError != nil {
+			return []error{innerError}
+		}
+	}
+	return nil
+}
+
+func hasDependencyFault(innerErrors []error) bool {
+	for _, innerError := range innerErrors {
+		if isNilFunc(innerError) {
+			continue
+		}
+		if typedError, isTyped := innerError.(faulter); isTyped && typedError.Fault() == FaultDependency {
+			return true
+		}
+		if hasDependencyFault(getInnerErrorsFunc(innerError)) {
+			return true
+		}
+	}
+	return false
+}
+
+// ResolveFault returns the fault of the whole error tree, counting a server fault caused by a dependency fault below it as the dependency's
+func ResolveFault(err error) Fault {
+	if isNilFunc(err) {
+		return FaultUnspecified
+	}
+	var typedError faulter
+	if !errorsAs(err, &typedError) {
+		return FaultServer
+	}
+	var fault = typedError.Fault()
+	if fault != FaultServer {
+		return fault
+	}
+	var faultError, _ = typedError.(error)
+	if hasDependencyFaultFunc(getInnerErrorsFunc(faultError)) {
+		return FaultDependency
+	}
+	return FaultServer
+}
@@ -0,0 +1,351 @@
+package apperror
+
+import (
+	"errors"
+	"fmt"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestFault_String(t *testing.T) {
+	// assert
+	assert.Equal(t, "unspecified", FaultUnspecified.String())
+	assert.Equal(t, "client", FaultClient.String())
+	assert.Equal(t, "server", FaultServer.String())
+	assert.Equal(t, "dependency", FaultDependency.String())
+	assert.Equal(t, "unspecified", Fault(99).String())
+}
+
+func TestCode_Fault_BuiltIn(t *testing.T) {
+	// arrange
+	var expectedFaults = map[Code]Fault{
+		CodeGeneralFailure:   FaultServer,
+		CodeUnauthorized:     FaultClient,
+		CodeInvalidOperation: FaultClient,
+		CodeBadRequest:       FaultClient,
+		CodeNotFound:         FaultClient,
+		CodeCircuitBreak:     FaultDependency,
+		CodeOperationLock:    FaultClient,
+		CodeAccessForbidden:  FaultClient,
+		CodeDataCorruption:   FaultServer,
+		CodeNotImplemented:   FaultServer,
+	}
+
+	// assert
+	for code := CodeGeneralFailure; code < codeMaxCount; code++ {
+		assert.Equal(t, expectedFaults[code], code.Fault(), code.String())
+	}
+}
+
+func TestCode_Fault_Custom(t *testing.T) {
+	// arrange
+	var dummyCode = Code(4700)
+
+	// act
+	var unregistered = dummyCode.Fault()
+	RegisterFault(dummyCode, FaultDependency)
+	var registered = dummyCode.Fault()
+	RegisterFault(CodeBadRequest, FaultServer)
+	RegisterFault(dummyCode, FaultUnspecified)
+	var unregisteredAgain = dummyCode.Fault()
+
+	// assert
+	assert.Equal(t, FaultServer, unregistered)
+	assert.Equal(t, FaultDependency, registered)
+	assert.Equal(t, FaultClient, CodeBadRequest.Fault())
+	assert.Equal(t, FaultServer, unregisteredAgain)
+}
+
+func TestBaseAppError_Fault(t *testing.T) {
+	// arrange
+	var nilAppError *BaseAppError
+
+	// assert
+	assert.Equal(t, FaultServer, nilAppError.Fault())
+	assert.Equal(t, FaultClient, (&BaseAppError{code: CodeNotFound}).Fault())
+}
+
+func TestGetInnerErrors(t *testing.T) {
+	// arrange
+	var dummyInnerError = errors.New("some inner error")
+
+	// mock
+	createMock(t)
+
+	// assert
+	assert.Equal(t, []error{dummyInnerError}, getInnerErrors(&BaseAppError{innerErrors: []error{dummyInnerError}}))
+	assert.Equal(t, []error{dummyInnerError}, getInnerErrors(errors.Join(dummyInnerError)))
+	assert.Equal(t, []error{dummyInnerError}, getInnerErrors(fmt.Errorf("some wrapper: %w", dummyInnerError)))
+	assert.Nil(t, getInnerErrors(fmt.Errorf("some wrapper: %v", dummyInnerError)))
+	assert.Nil(t, getInnerErrors(dummyInnerError))
+
+	// verify
+	verifyAll(t)
+}
+
+func TestHasDependencyFault_Direct(t *testing.T) {
+	// arrange
+	var dummyInnerErrors = []error{
+		nil,
+		&BaseAppError{code: CodeCircuitBreak},
+	}
+
+	// mock
+	createMock(t)
+
+	// expect
+	isNilFuncExpected = 2
+	isNilFunc = func(err error) bool {
+		isNilFuncCalled++
+		return err == nil
+	}
+
+	// SUT + act
+	var result = hasDependencyFault(
+		dummyInnerErrors,
+	)
+
+	// assert
+	assert.True(t, result)
+
+	// verify
+	verifyAll(t)
+}
+
+func TestHasDependencyFault_Nested(t *testing.T) {
+	// arrange
+	var dummyNestedErrors = []error{&BaseAppError{code: CodeCircuitBreak}}
+	var dummyInnerError = errors.New("some inner error")
+
+	// mock
+	createMock(t)
+
+	// expect
+	isNilFuncExpected = 2
+	isNilFunc = func(err error) bool {
+		isNilFuncCalled++
+		return false
+	}
+	getInnerErrorsFuncExpected = 1
+	getInnerErrorsFunc = func(err error) []error {
+		getInnerErrorsFuncCalled++
+		assert.Equal(t, dummyInnerError, err)
+		return dummyNestedErrors
+	}
+
+	// SUT + act
+	var result = hasDependencyFault(
+		[]error{dummyInnerError},
+	)
+
+	// assert
+	assert.True(t, result)
+
+	// verify
+	verifyAll(t)
+}
+
+func TestHasDependencyFault_None(t *testing.T) {
+	// mock
+	createMock(t)
+
+	// expect
+	isNilFuncExpected = 1
+	isNilFunc = func(err error) bool {
+		isNilFuncCalled++
+		return false
+	}
+	getInnerErrorsFuncExpected = 1
+	getInnerErrorsFunc = func(err error) []error {
+		getInnerErrorsFuncCalled++
+		return nil
+	}
+
+	// SUT + act
+	var result = hasDependencyFault(
+		[]error{&BaseAppError{code: CodeDataCorruption}},
+	)
+
+	// assert
+	assert.False(t, result)
+
+	// verify
+	verifyAll(t)
+}
+
+func TestResolveFault_Nil(t *testing.T) {
+	// mock
+	createMock(t)
+
+	// expect
+	isNilFuncExpected = 1
+	isNilFunc = func(err error) bool {
+		isNilFuncCalled++
+		return true
+	}
+
+	// SUT + act
+	var result = ResolveFault(nil)
+
+	// assert
+	assert.Equal(t, FaultUnspecified, result)
+
+	// verify
+	verifyAll(t)
+}
+
+func TestResolveFault_NoFaulter(t *testing.T) {
+	// arrange
+	var dummyError = errors.New("some error")
+
+	// mock
+	createMock(t)
+
+	// expect
+	isNilFuncExpected = 1
+	isNilFunc = func(err error) bool {
+		isNilFuncCalled++
+		return false
+	}
+	errorsAsExpected = 1
+	errorsAs = func(err error, target interface{}) bool {
+		errorsAsCalled++
+		assert.Equal(t, dummyError, err)
+		return false
+	}
+
+	// SUT + act
+	var result = ResolveFault(dummyError)
+
+	// assert
+	assert.Equal(t, FaultServer, result)
+
+	// verify
+	verifyAll(t)
+}
+
+func TestResolveFault_Client(t *testing.T) {
+	// arrange
+	var dummyError = &BaseAppError{code: CodeBadRequest}
+
+	// mock
+	createMock(t)
+
+	// expect
+	isNilFuncExpected = 1
+	isNilFunc = func(err error) bool {
+		isNilFuncCalled++
+		return false
+	}
+	errorsAsExpected = 1
+	errorsAs = func(err error, target interface{}) bool {
+		errorsAsCalled++
+		return errors.As(err, target)
+	}
+
+	// SUT + act
+	var result = ResolveFault(dummyError)
+
+	// assert
+	assert.Equal(t, FaultClient, result)
+
+	// verify
+	verifyAll(t)
+}
+
+func TestResolveFault_ServerCausedByDependency(t *testing.T) {
+	// arrange
+	var dummyInnerErrors = []error{errors.New("some inner error")}
+	var dummyError = &BaseAppError{code: CodeGeneralFailure, innerErrors: dummyInnerErrors}
+
+	// mock
+	createMock(t)
+
+	// expect
+	isNilFuncExpected = 1
+	isNilFunc = func(err error) bool {
+		isNilFuncCalled++
+		return false
+	}
+	errorsAsExpected = 1
+	errorsAs = func(err error, target interface{}) bool {
+		errorsAsCalled++
+		return errors.As(err, target)
+	}
+	getInnerErrorsFuncExpected = 1
+	getInnerErrorsFunc = func(err error) []error {
+		getInnerErrorsFuncCalled++
+		assert.Equal(t, dummyError, err)
+		return dummyInnerErrors
+	}
+	hasDependencyFaultFuncExpected = 1
+	hasDependencyFaultFunc = func(innerErrors []error) bool {
+		hasDependencyFaultFuncCalled++
+		assert.Equal(t, dummyInnerErrors, innerErrors)
+		return true
+	}
+
+	// SUT + act
+	var result = ResolveFault(dummyError)
+
+	// assert
+	assert.Equal(t, FaultDependency, result)
+
+	// verify
+	verifyAll(t)
+}
+
+func TestResolveFault_Server(t *testing.T) {
+	// arrange
+	var dummyError = &BaseAppError{code: CodeDataCorruption}
+
+	// mock
+	createMock(t)
+
+	// expect
+	isNilFuncExpected = 1
+	isNilFunc = func(err error) bool {
+		isNilFuncCalled++
+		return false
+	}
+	errorsAsExpected = 1
+	errorsAs = func(err error, target interface{}) bool {
+		errorsAsCalled++
+		return errors.As(err, target)
+	}
+	getInnerErrorsFuncExpected = 1
+	getInnerErrorsFunc = func(err error) []error {
+		getInnerErrorsFuncCalled++
+		return nil
+	}
+	hasDependencyFaultFuncExpected = 1
+	hasDependencyFaultFunc = func(innerErrors []error) bool {
+		hasDependencyFaultFuncCalled++
+		return false
+	}
+
+	// SUT + act
+	var result = ResolveFault(dummyError)
+
+	// assert
+	assert.Equal(t, FaultServer, result)
+
+	// verify
+	verifyAll(t)
+}
+
+func TestResolveFault_Tree(t *testing.T) {
+	// arrange
+	var dependencyError = GetGeneralFailureError(
+		fmt.Errorf("calling payments: %w", GetCircuitBreakError()),
+	)
+	var clientError = GetBadRequestError(GetCircuitBreakError())
+	var wrappedClientError = fmt.Errorf("handler: %w", GetNotFoundError())
+
+	// assert
+	assert.Equal(t, FaultDependency, ResolveFault(dependencyError))
+	assert.Equal(t, FaultClient, ResolveFault(clientError))
+	assert.Equal(t, FaultClient, ResolveFault(wrappedClientError))
+	assert.Equal(t, FaultServer, ResolveFault(GetDataCorruptionError(GetNotFoundError())))
+}
@@ -0,0 +1,216 @@
+package apperror
+
+import (
+	"time"
+)
+
+// These are tree formatting related constants
+const (
+	errorTreeIndent      string = "    "
+	errorTreeLineBreak   string = "\n"
+	errorGoSyntaxFormat  string = "&apperror.BaseAppError{code:%v, message:%q, innerErrors:[]error{%v}, extraData:%#v}"
+	errorSequenceFormat  string = "sequence: %d"
+	errorCreatedFormat   string = "created: %v"
+	errorInstanceFormat  string = "instance: %v"
+	errorComponentFormat string = "component: %v"
+)
+
+func indentLines(text string) string {
+	var lines = stringsSplit(
+		text,
+		errorTreeLineBreak,
+	)
+	for index, line := range lines {
+		lines[index] = errorTreeIndent + line
+	}
+	return stringsJoin(
+		lines,
+		errorTreeLineBreak,
+	)
+}
+
+func formatTree(baseAppError *BaseAppError) string {
+	var lines = []string{
+		baseAppError.PrintError(
+			baseAppError.code,
+			baseAppError.error,
+			nil,
+		),
+	}
+	var details = baseAppError.getDetails()
+	if instanceID := baseAppError.InstanceID(); instanceID != "" {
+		lines = append(
+			lines,
+			indentLinesFunc(
+				fmtSprintf(
+					errorInstanceFormat,
+					instanceID,
+				),
+			),
+		)
+	}
+	if details.component != "" {
+		lines = append(
+			lines,
+			indentLinesFunc(
+				fmtSprintf(
+					errorComponentFormat,
+					details.component,
+				),
+			),
+		)
+	}
+	if details.sequence != 0 {
+		lines = append(
+			lines,
+			indentLinesFunc(
+				fmtSprintf(
+					errorSequenceFormat,
+					details.sequence,
+				),
+			),
+		)
+	}
+	if !details.createdAt.IsZero() {
+		lines = append(
+			lines,
+			indentLinesFunc(
+				fmtSprintf(
+					errorCreatedFormat,
+					details.createdAt.Format(time.RFC3339Nano),
+				),
+			),
+		)
+	}
+	if len(details.stack) > 0 {
+		lines = append(
+			lines,
+			indentLinesFunc(
+				formatStackFunc(
+					details.stack,
+				),
+			),
+		)
+	}
+	var extraData = getExtraData(
+		baseAppError,
+	)
+	var names = []string{}
+	for name := range extraData {
+		names = append(
+			names,
+			name,
+		)
+	}
+	sortStrings(names)
+	for _, name := range names {
+		lines = append(
+			lines,
+			indentLinesFunc(
+				fmtSprintf(
+					errorExtraDataFormat,
+					name,
+					extraData[name],
+				),
+			),
+		)
+	}
+	for _, innerError := range baseAppError.innerErrors {
+		lines = append(
+			lines,
+			indentLinesFunc(
+				formatInnerErrorFunc(
+					innerError,
+				),
+			),
+		)
+	}
+	return stringsJoin(
+		lines,
+		errorTreeLineBreak,
+	)
+}
+
+// detailer is implemented by errors offering a multi-line detail, such as *BaseAppError
+type detailer interface {
+	Detail() string
+}
+
+// formatInnerError returns the detail of app inner errors and the %+v rendering of other inner errors, honouring their own fmt.Formatter
+func formatInnerError(innerError error) string {
+	var typedError, isTyped = innerError.(detailer)
+	if isTyped {
+		return typedError.Detail()
+	}
+	return fmtSprintf(
+		"%+v",
+		innerError,
+	)
+}
+
+func formatCodeGoSyntax(code Code) string {
+	if code < 0 || code >= codeMaxCount {
+		return fmtSprintf(
+			"apperror.Code(%d)",
+			int(code),
+		)
+	}
+	return fmtSprint(
+		"apperror.Code",
+		code,
+	)
+}
+
+func formatGoSyntax(baseAppError *BaseAppError) string {
+	var extraData = getExtraData(
+		baseAppError,
+	)
+	if extraData == nil {
+		// extra data is allocated lazily, but is still dumped as an empty map
+		extraData = map[string]interface{}{}
+	}
+	var innerErrors = []string{}
+	for _, innerError := range baseAppError.innerErrors {
+		innerErrors = append(
+			innerErrors,
+			fmtSprintf(
+				"%#v",
+				innerError,
+			),
+		)
+	}
+	return fmtSprintf(
+		errorGoSyntaxFormat,
+		formatCodeGoSyntaxFunc(
+			baseAppError.code,
+		),
+		getErrorMessageFunc(
+			baseAppError.error,
+		),
+		stringsJoin(
+			innerErrors,
+			", ",
+		),
+		extraData,
+	)
+}
+
+// Detail returns an indented multi-line tree of the message, instance ID, component, sequence, creation time, creation stack (see SetStackCapture), extra data and inner errors of the app error
+func (baseAppError *BaseAppError) Detail() string {
+	if baseAppError == nil {
+		return nilErrorText
+	}
+	return formatTreeFunc(
+		baseAppError,
+	)
+}
+
+// GoString returns a Go-syntax-like debug dump of the app error, which %#v prints
+func (baseAppError *BaseAppError) GoString() string {
+	if baseAppError == nil {
+		return nilErrorText
+	}
+	return formatGoSyntaxFunc(
+		baseAppError,
+	)
+}
@@ -0,0 +1,378 @@
+package apperror
+
+import (
+	"errors"
+	"fmt"
+	"io"
+	"math/rand"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+type dummyFormatterError struct{}
+
+func (dummyFormatterError dummyFormatterError) Error() string {
+	return "some formatter error"
+}
+
+func (dummyFormatterError dummyFormatterError) Format(state fmt.State, verb rune) {
+	if state.Flag('+') {
+		io.WriteString(state, "some formatter error\nsome formatter detail")
+		return
+	}
+	io.WriteString(state, dummyFormatterError.Error())
+}
+
+func TestIndentLines(t *testing.T) {
+	// arrange
+	var dummyText = "some text"
+	var dummyLines = []string{"some line 1", "some line 2"}
+	var dummyResult = "some result"
+
+	// mock
+	createMock(t)
+
+	// expect
+	stringsSplitExpected = 1
+	stringsSplit = func(s string, sep string) []string {
+		stringsSplitCalled++
+		assert.Equal(t, dummyText, s)
+		assert.Equal(t, errorTreeLineBreak, sep)
+		return dummyLines
+	}
+	stringsJoinExpected = 1
+	stringsJoin = func(a []string, sep string) string {
+		stringsJoinCalled++
+		assert.Equal(t, []string{errorTreeIndent + "some line 1", errorTreeIndent + "some line 2"}, a)
+		assert.Equal(t, errorTreeLineBreak, sep)
+		return dummyResult
+	}
+
+	// SUT + act
+	var result = indentLines(
+		dummyText,
+	)
+
+	// assert
+	assert.Equal(t, dummyResult, result)
+
+	// verify
+	verifyAll(t)
+}
+
+func TestFormatTree(t *testing.T) {
+	// arrange
+	var dummyCode = CodeNotFound
+	var dummyError = errors.New("some error")
+	var dummyInnerError1 = errors.New("some inner error 1")
+	var dummyInnerError2 = errors.New("some inner error 2")
+	var dummyBaseAppError = &BaseAppError{
+		error:       dummyError,
+		code:        dummyCode,
+		innerErrors: []error{dummyInnerError1, dummyInnerError2},
+		extraData: map[string]interface{}{
+			"b": 2,
+			"a": 1,
+		},
+	}
+	var dummyBaseMessage = "(NotFound) some error"
+	var dummyResult = "some result"
+
+	// mock
+	createMock(t)
+
+	// expect
+	getErrorMessageFuncExpected = 1
+	getErrorMessageFunc = func(err error) string {
+		getErrorMessageFuncCalled++
+		assert.Equal(t, dummyError, err)
+		return err.Error()
+	}
+	sortStringsExpected = 1
+	sortStrings = func(x []string) {
+		sortStringsCalled++
+		assert.ElementsMatch(t, []string{"a", "b"}, x)
+		x[0], x[1] = "a", "b"
+	}
+	fmtSprintfExpected = 2
+	fmtSprintf = func(format string, a ...interface{}) string {
+		fmtSprintfCalled++
+		assert.Equal(t, errorExtraDataFormat, format)
+		if fmtSprintfCalled == 1 {
+			assert.Equal(t, []interface{}{"a", 1}, a)
+			return "a line"
+		}
+		assert.Equal(t, []interface{}{"b", 2}, a)
+		return "b line"
+	}
+	formatInnerErrorFuncExpected = 2
+	formatInnerErrorFunc = func(innerError error) string {
+		formatInnerErrorFuncCalled++
+		if formatInnerErrorFuncCalled == 1 {
+			assert.Equal(t, dummyInnerError1, innerError)
+			return "inner line 1"
+		}
+		assert.Equal(t, dummyInnerError2, innerError)
+		return "inner line 2"
+	}
+	indentLinesFuncExpected = 4
+	indentLinesFunc = func(text string) string {
+		indentLinesFuncCalled++
+		return "indented " + text
+	}
+	stringsJoinExpected = 1
+	stringsJoin = func(a []string, sep string) string {
+		stringsJoinCalled++
+		assert.Equal(t, []string{
+			dummyBaseMessage,
+			"indented a line",
+			"indented b line",
+			"indented inner line 1",
+			"indented inner line 2",
+		}, a)
+		assert.Equal(t, errorTreeLineBreak, sep)
+		return dummyResult
+	}
+
+	// SUT + act
+	var result = formatTree(
+		dummyBaseAppError,
+	)
+
+	// assert
+	assert.Equal(t, dummyResult, result)
+
+	// verify
+	verifyAll(t)
+}
+
+func TestFormatInnerError_Detailer(t *testing.T) {
+	// arrange
+	var dummyInnerError = NewBaseAppError(CodeNotFound, "some inner error")
+	dummyInnerError.Attach("id", 5)
+
+	// mock
+	createMock(t)
+
+	// expect
+	formatTreeFuncExpected = 1
+	formatTreeFunc = func(baseAppError *BaseAppError) string {
+		formatTreeFuncCalled++
+		assert.Equal(t, dummyInnerError, baseAppError)
+		return "some detail"
+	}
+
+	// SUT + act
+	var result = formatInnerError(
+		dummyInnerError,
+	)
+
+	// assert
+	assert.Equal(t, "some detail", result)
+
+	// verify
+	verifyAll(t)
+}
+
+func TestFormatInnerError_Other(t *testing.T) {
+	// arrange
+	var dummyInnerError = dummyFormatterError{}
+
+	// mock
+	createMock(t)
+
+	// expect
+	fmtSprintfExpected = 1
+	fmtSprintf = func(format string, a ...interface{}) string {
+		fmtSprintfCalled++
+		assert.Equal(t, "%+v", format)
+		assert.Equal(t, []interface{}{dummyInnerError}, a)
+		return "some detail"
+	}
+
+	// SUT + act
+	var result = formatInnerError(
+		dummyInnerError,
+	)
+
+	// assert
+	assert.Equal(t, "some detail", result)
+
+	// verify
+	verifyAll(t)
+}
+
+func TestFormatCodeGoSyntax_KnownCode(t *testing.T) {
+	// arrange
+	var dummyResult = "some result"
+
+	// mock
+	createMock(t)
+
+	// expect
+	fmtSprintExpected = 1
+	fmtSprint = func(a ...interface{}) string {
+		fmtSprintCalled++
+		assert.Equal(t, []interface{}{"apperror.Code", CodeNotFound}, a)
+		return dummyResult
+	}
+
+	// SUT + act
+	var result = formatCodeGoSyntax(
+		CodeNotFound,
+	)
+
+	// assert
+	assert.Equal(t, dummyResult, result)
+
+	// verify
+	verifyAll(t)
+}
+
+func TestFormatCodeGoSyntax_UnknownCode(t *testing.T) {
+	// arrange
+	var dummyCode = codeMaxCount + Code(rand.Intn(100))
+	var dummyResult = "some result"
+
+	// mock
+	createMock(t)
+
+	// expect
+	fmtSprintfExpected = 1
+	fmtSprintf = func(format string, a ...interface{}) string {
+		fmtSprintfCalled++
+		assert.Equal(t, "apperror.Code(%d)", format)
+		assert.Equal(t, []interface{}{int(dummyCode)}, a)
+		return dummyResult
+	}
+
+	// SUT + act
+	var result = formatCodeGoSyntax(
+		dummyCode,
+	)
+
+	// assert
+	assert.Equal(t, dummyResult, result)
+
+	// verify
+	verifyAll(t)
+}
+
+func TestFormatGoSyntax(t *testing.T) {
+	// arrange
+	var dummyCode = Code(rand.Intn(100))
+	var dummyError = errors.New("some error")
+	var dummyInnerError = errors.New("some inner error")
+	var dummyExtraData = map[string]interface{}{"foo": "bar"}
+	var dummyBaseAppError = &BaseAppError{
+		error:       dummyError,
+		code:        dummyCode,
+		innerErrors: []error{dummyInnerError},
+		extraData:   dummyExtraData,
+	}
+	var dummyCodeString = "some code string"
+	var dummyMessage = "some message"
+	var dummyJoinedMessage = "some joined message"
+	var dummyResult = "some result"
+
+	// mock
+	createMock(t)
+
+	// expect
+	formatCodeGoSyntaxFuncExpected = 1
+	formatCodeGoSyntaxFunc = func(code Code) string {
+		formatCodeGoSyntaxFuncCalled++
+		assert.Equal(t, dummyCode, code)
+		return dummyCodeString
+	}
+	getErrorMessageFuncExpected = 1
+	getErrorMessageFunc = func(err error) string {
+		getErrorMessageFuncCalled++
+		assert.Equal(t, dummyError, err)
+		return dummyMessage
+	}
+	fmtSprintfExpected = 2
+	fmtSprintf = func(format string, a ...interface{}) string {
+		fmtSprintfCalled++
+		if fmtSprintfCalled == 1 {
+			assert.Equal(t, "%#v", format)
+			assert.Equal(t, []interface{}{dummyInnerError}, a)
+			return "some inner message"
+		}
+		assert.Equal(t, errorGoSyntaxFormat, format)
+		assert.Equal(t, []interface{}{dummyCodeString, dummyMessage, dummyJoinedMessage, dummyExtraData}, a)
+		return dummyResult
+	}
+	stringsJoinExpected = 1
+	stringsJoin = func(a []string, sep string) string {
+		stringsJoinCalled++
+		assert.Equal(t, []string{"some inner message"}, a)
+		assert.Equal(t, ", ", sep)
+		return dummyJoinedMessage
+	}
+
+	// SUT + act
+	var result = formatGoSyntax(
+		dummyBaseAppError,
+	)
+
+	// assert
+	assert.Equal(t, dummyResult, result)
+
+	// verify
+	verifyAll(t)
+}
+
+func TestBaseAppError_DetailAndVerbs(t *testing.T) {
+	// arrange
+	SetInstanceIDGenerator(func() string { return "" })
+	defer SetInstanceIDGenerator(nil)
+	var dummyInnerAppError = NewBaseAppError(CodeDataCorruption, "some data corruption")
+	dummyInnerAppError.Wrap(dummyFormatterError{})
+	var sut = NewBaseAppError(CodeNotFound, "some %v", "message")
+	sut.Attach("id", 5)
+	sut.Attach("name", "foo")
+	sut.Wrap(errors.New("some inner error"), dummyInnerAppError)
+
+	var dummyOneLiner = NewBaseAppError(CodeNotFound, "some \"quoted\" message")
+	dummyOneLiner.Attach("id", 5)
+	dummyOneLiner.Wrap(dummyInnerAppError)
+
+	// act + assert
+	assert.Equal(t, dummyOneLiner.Error(), fmt.Sprintf("%v", dummyOneLiner))
+	assert.Equal(t, dummyOneLiner.Error(), fmt.Sprintf("%s", dummyOneLiner))
+	assert.Equal(t, dummyOneLiner.Error(), fmt.Sprintf("%+v", dummyOneLiner))
+	assert.Equal(t, `"`+strings.ReplaceAll(dummyOneLiner.Error(), `"`, `\"`)+`"`, fmt.Sprintf("%q", dummyOneLiner))
+	assert.Equal(t, strings.Join([]string{
+		"(NotFound) some message",
+		"    id = 5",
+		"    name = foo",
+		"    some inner error",
+		"    (DataCorruption) some data corruption",
+		"        some formatter error",
+		"        some formatter detail",
+	}, "\n"), sut.Detail())
+	assert.Equal(t, `&apperror.BaseAppError{code:apperror.CodeNotFound, message:"some message", innerErrors:[]error{&errors.errorString{s:"some inner error"}, &apperror.BaseAppError{code:apperror.CodeDataCorruption, message:"some data corruption", innerErrors:[]error{some formatter error}, extraData:map[string]interface {}{}}}, extraData:map[string]interface {}{"id":5, "name":"foo"}}`, fmt.Sprintf("%#v", sut))
+}
+
+// detailedAppError overrides Error() of the app error it embeds
+type detailedAppError struct {
+	*BaseAppError
+}
+
+func (err detailedAppError) Error() string {
+	return "some overridden text"
+}
+
+func TestBaseAppError_Verbs_HonourEmbedderError(t *testing.T) {
+	// arrange
+	var sut = detailedAppError{NewBaseAppError(CodeNotFound, "some message")}
+
+	// act
+	var result = fmt.Sprintf("%v|%s|%+v", sut, sut, sut)
+
+	// assert
+	assert.Equal(t, "some overridden text|some overridden text|some overridden text", result)
+}
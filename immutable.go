@@ -0,0 +1,171 @@
+package apperror
+
+import (
+	"sync"
+)
+
+// customSentinels caches the sentinels of codes other than the built-in ones
+var customSentinels sync.Map
+
+// Freeze switches the app error to immutable mode and returns it: Wrap, Attach and AttachAttrs become no-ops, so the error can be shared safely, and With or WithCause should be used to derive new errors from it instead
+func (baseAppError *BaseAppError) Freeze() *BaseAppError {
+	if baseAppError == nil {
+		return nil
+	}
+	baseAppError.frozen = true
+	return baseAppError
+}
+
+// IsFrozen returns whether the app error is in immutable mode
+func (baseAppError *BaseAppError) IsFrozen() bool {
+	if baseAppError == nil {
+		return false
+	}
+	return baseAppError.frozen
+}
+
+func cloneBaseAppError(baseAppError *BaseAppError) *BaseAppError {
+	var clonedAppError = &BaseAppError{
+		error:         baseAppError.error,
+		code:          baseAppError.code,
+		messageFormat: baseAppError.messageFormat,
+		innerErrors:   baseAppError.innerErrors[:len(baseAppError.innerErrors):len(baseAppError.innerErrors)],
+		extraData:     baseAppError.extraData,
+		attrs:         baseAppError.attrs[:len(baseAppError.attrs):len(baseAppError.attrs)],
+		frozen:        true,
+		details: &errorDetails{
+			origin:    baseAppError,
+			createdAt: baseAppError.getDetails().createdAt,
+			sequence:  baseAppError.getDetails().sequence,
+			stack:     baseAppError.getDetails().stack,
+			component: baseAppError.getDetails().component,
+		},
+	}
+	clonedAppError.getCache().instanceID.Store(&pendingInstanceID)
+	if !baseAppError.frozen {
+		// a mutable source can still change its extra data in place through Attach or AttachAttrs, so the clone takes its own copy
+		clonedAppError.extraData = copyExtraData(
+			baseAppError.extraData,
+		)
+		clonedAppError.attrs = append(
+			[]Attr(nil),
+			baseAppError.attrs...,
+		)
+	}
+	return clonedAppError
+}
+
+func copyExtraData(extraData map[string]interface{}) map[string]interface{} {
+	if len(extraData) == 0 {
+		return nil
+	}
+	var copiedExtraData = make(map[string]interface{}, len(extraData))
+	for name, value := range extraData {
+		copiedExtraData[name] = value
+	}
+	return copiedExtraData
+}
+
+// With returns a new immutable app error sharing the current one's data, with the given value added/updated in its extra data by given name; the current app error is left untouched
+func (baseAppError *BaseAppError) With(name string, value interface{}) *BaseAppError {
+	if baseAppError == nil {
+		return nil
+	}
+	var clonedAppError = cloneBaseAppErrorFunc(
+		baseAppError,
+	)
+	var extraData = make(map[string]interface{}, len(baseAppError.extraData)+1)
+	for key, existingValue := range baseAppError.extraData {
+		extraData[key] = existingValue
+	}
+	extraData[name] = value
+	clonedAppError.extraData = extraData
+	clonedAppError.attrs = removeAttrFunc(
+		clonedAppError.attrs,
+		name,
+	)
+	return clonedAppError
+}
+
+// WithCause returns a new immutable app error sharing the current one's data, with the given list of inner errors wrapped in addition; the current app error is left untouched
+func (baseAppError *BaseAppError) WithCause(innerErrors ...error) *BaseAppError {
+	if baseAppError == nil {
+		return nil
+	}
+	var clonedAppError = cloneBaseAppErrorFunc(
+		baseAppError,
+	)
+	clonedAppError.innerErrors = append(
+		clonedAppError.innerErrors,
+		cleanupInnerErrorsFunc(
+			innerErrors,
+		)...,
+	)
+	return clonedAppError
+}
+
+// newSentinel builds the sentinel directly rather than through NewBaseAppError, so that sentinels are not reported as created errors
+func newSentinel(code Code, message string) *BaseAppError {
+	var sentinel = &BaseAppError{
+		code:          code,
+		messageFormat: message,
+		frozen:        true,
+		sentinel:      true,
+	}
+	setMessageFunc(
+		sentinel,
+		message,
+		nil,
+	)
+	return sentinel
+}
+
+func getCustomSentinel(code Code) AppError {
+	var sentinel, isLoaded = customSentinels.Load(
+		code,
+	)
+	if !isLoaded {
+		sentinel, _ = customSentinels.LoadOrStore(
+			code,
+			newSentinelFunc(
+				code,
+				code.String(),
+			),
+		)
+	}
+	return sentinel.(AppError)
+}
+
+func innerErrorIs(innerErrors []error, target error) bool {
+	for _, innerError := range innerErrors {
+		if errorsIs(innerError, target) {
+			return true
+		}
+	}
+	return false
+}
+
+// Is supports errors.Is for app errors:
+//   - an app error derived by With or WithCause matches the error it was derived from, and every error that one was derived from in turn;
+//   - a Code sentinel (see Code.Sentinel and the Err* variables) is matched by any app error of the same Code, and by any app error with such an error anywhere among its (nested) inner errors.
+//
+// Other targets are only matched by identity, as errors.Is does by default.
+func (baseAppError *BaseAppError) Is(target error) bool {
+	if baseAppError == nil {
+		return false
+	}
+	for origin := baseAppError.getDetails().origin; origin != nil; origin = origin.getDetails().origin {
+		if target == error(origin) {
+			return true
+		}
+	}
+	var typedTarget, isTyped = target.(*BaseAppError)
+	if !isTyped || typedTarget == nil || !typedTarget.sentinel {
+		return false
+	}
+	return baseAppError.code == typedTarget.code ||
+		innerErrorIsFunc(
+			baseAppError.innerErrors,
+			target,
+		)
+}
@@ -0,0 +1,616 @@
+package apperror
+
+import (
+	"errors"
+	"fmt"
+	"math/rand"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestBaseAppError_Freeze(t *testing.T) {
+	// mock
+	createMock(t)
+
+	// SUT
+	var sut = &BaseAppError{}
+
+	// act
+	var result = sut.Freeze()
+
+	// assert
+	assert.Equal(t, sut, result)
+	assert.True(t, sut.frozen)
+	assert.True(t, sut.IsFrozen())
+
+	// verify
+	verifyAll(t)
+}
+
+func TestCloneBaseAppError_NoOrigin(t *testing.T) {
+	// arrange
+	var dummyError = errors.New("some error")
+	var dummyCode = Code(rand.Intn(100))
+	var dummyInnerErrors = make([]error, 1, 10)
+	var dummyExtraData = map[string]interface{}{"foo": "bar"}
+	var dummyAttrs = make([]Attr, 2, 10)
+	var dummyInstanceID = "some instance id"
+	var dummyMessageFormat = "some message format %v"
+
+	// mock
+	createMock(t)
+
+	// expect
+	newInstanceIDFuncExpected = 1
+	newInstanceIDFunc = func() string {
+		newInstanceIDFuncCalled++
+		return dummyInstanceID
+	}
+
+	// SUT
+	var sut = &BaseAppError{
+		error:         dummyError,
+		code:          dummyCode,
+		messageFormat: dummyMessageFormat,
+		innerErrors:   dummyInnerErrors,
+		extraData:     dummyExtraData,
+		attrs:         dummyAttrs,
+	}
+	var dummyOriginInstanceID = "some origin instance id"
+	sut.getCache().instanceID.Store(&dummyOriginInstanceID)
+
+	// act
+	var result = cloneBaseAppError(
+		sut,
+	)
+
+	// assert
+	assert.Equal(t, dummyError, result.error)
+	assert.Equal(t, dummyCode, result.code)
+	assert.Equal(t, dummyMessageFormat, result.messageFormat)
+	assert.Equal(t, dummyInnerErrors, result.innerErrors)
+	assert.Equal(t, 1, cap(result.innerErrors))
+	assert.Equal(t, dummyExtraData, result.extraData)
+	assert.Equal(t, dummyAttrs, result.attrs)
+	assert.Equal(t, 2, cap(result.attrs))
+	assert.True(t, result.frozen)
+	assert.Same(t, sut, result.details.origin)
+	assert.Nil(t, sut.details)
+	assert.False(t, sut.frozen)
+	assert.Equal(t, dummyInstanceID, result.InstanceID())
+	assert.Equal(t, dummyOriginInstanceID, sut.InstanceID())
+	sut.extraData["foo"] = "changed"
+	sut.attrs[0] = Int("changed", 1)
+	assert.Equal(t, map[string]interface{}{"foo": "bar"}, result.extraData)
+	assert.Equal(t, Attr{}, result.attrs[0])
+
+	// verify
+	verifyAll(t)
+}
+
+func TestCloneBaseAppError_FrozenSource(t *testing.T) {
+	// arrange
+	var dummyExtraData = map[string]interface{}{"foo": "bar"}
+	var dummyAttrs = make([]Attr, 2, 10)
+
+	// mock
+	createMock(t)
+
+	// SUT
+	var sut = &BaseAppError{
+		extraData: dummyExtraData,
+		attrs:     dummyAttrs,
+		frozen:    true,
+	}
+
+	// act
+	var result = cloneBaseAppError(
+		sut,
+	)
+
+	// assert
+	assert.Equal(t, dummyExtraData, result.extraData)
+	assert.Same(t, &dummyAttrs[0], &result.attrs[0])
+	assert.Equal(t, 2, cap(result.attrs))
+
+	// verify
+	verifyAll(t)
+}
+
+func TestCopyExtraData(t *testing.T) {
+	// arrange
+	var dummyExtraData = map[string]interface{}{"foo": "bar"}
+
+	// mock
+	createMock(t)
+
+	// SUT + act
+	var result1 = copyExtraData(nil)
+	var result2 = copyExtraData(dummyExtraData)
+	dummyExtraData["foo"] = "changed"
+
+	// assert
+	assert.Nil(t, result1)
+	assert.Equal(t, map[string]interface{}{"foo": "bar"}, result2)
+
+	// verify
+	verifyAll(t)
+}
+
+func TestCloneBaseAppError_WithOrigin(t *testing.T) {
+	// arrange
+	var dummyOrigin = &BaseAppError{}
+
+	// mock
+	createMock(t)
+
+	// SUT
+	var sut = &BaseAppError{
+		details: &errorDetails{origin: dummyOrigin},
+	}
+
+	// act
+	var result = cloneBaseAppError(
+		sut,
+	)
+
+	// assert
+	assert.Same(t, sut, result.details.origin)
+	assert.Same(t, dummyOrigin, result.details.origin.details.origin)
+
+	// verify
+	verifyAll(t)
+}
+
+func TestBaseAppError_With(t *testing.T) {
+	// arrange
+	var dummyName = "some name"
+	var dummyValue = rand.Int()
+	var dummyAttrs = []Attr{String(dummyName, "some attr value")}
+	var dummyExtraData = map[string]interface{}{"foo": "bar"}
+	var dummyClone = &BaseAppError{
+		extraData: dummyExtraData,
+		attrs:     dummyAttrs,
+	}
+
+	// mock
+	createMock(t)
+
+	// SUT
+	var sut = &BaseAppError{
+		extraData: dummyExtraData,
+	}
+
+	// expect
+	cloneBaseAppErrorFuncExpected = 1
+	cloneBaseAppErrorFunc = func(baseAppError *BaseAppError) *BaseAppError {
+		cloneBaseAppErrorFuncCalled++
+		assert.Equal(t, sut, baseAppError)
+		return dummyClone
+	}
+	removeAttrFuncExpected = 1
+	removeAttrFunc = func(attrs []Attr, key string) []Attr {
+		removeAttrFuncCalled++
+		assert.Equal(t, dummyAttrs, attrs)
+		assert.Equal(t, dummyName, key)
+		return nil
+	}
+
+	// act
+	var result = sut.With(
+		dummyName,
+		dummyValue,
+	)
+
+	// assert
+	assert.Same(t, dummyClone, result)
+	assert.Equal(t, map[string]interface{}{"foo": "bar", dummyName: dummyValue}, dummyClone.extraData)
+	assert.Empty(t, dummyClone.attrs)
+	assert.Equal(t, map[string]interface{}{"foo": "bar"}, sut.extraData)
+
+	// verify
+	verifyAll(t)
+}
+
+func TestBaseAppError_WithCause(t *testing.T) {
+	// arrange
+	var dummyExistingError = errors.New("some existing error")
+	var dummyInnerError1 = errors.New("some inner error 1")
+	var dummyInnerError2 = errors.New("some inner error 2")
+	var dummyClone = &BaseAppError{
+		innerErrors: []error{dummyExistingError},
+	}
+
+	// mock
+	createMock(t)
+
+	// SUT
+	var sut = &BaseAppError{}
+
+	// expect
+	cloneBaseAppErrorFuncExpected = 1
+	cloneBaseAppErrorFunc = func(baseAppError *BaseAppError) *BaseAppError {
+		cloneBaseAppErrorFuncCalled++
+		assert.Equal(t, sut, baseAppError)
+		return dummyClone
+	}
+	cleanupInnerErrorsFuncExpected = 1
+	cleanupInnerErrorsFunc = func(innerErrors []error) []error {
+		cleanupInnerErrorsFuncCalled++
+		assert.Equal(t, []error{dummyInnerError1, nil, dummyInnerError2}, innerErrors)
+		return []error{dummyInnerError1, dummyInnerError2}
+	}
+
+	// act
+	var result = sut.WithCause(
+		dummyInnerError1,
+		nil,
+		dummyInnerError2,
+	)
+
+	// assert
+	assert.Same(t, dummyClone, result)
+	assert.Equal(t, []error{dummyExistingError, dummyInnerError1, dummyInnerError2}, dummyClone.innerErrors)
+
+	// verify
+	verifyAll(t)
+}
+
+func TestNewSentinel(t *testing.T) {
+	// arrange
+	var dummyCode = Code(rand.Intn(100))
+	var dummyMessage = "some message"
+	var dummyError = errors.New("some error")
+
+	// mock
+	createMock(t)
+
+	// expect
+	setMessageFuncExpected = 1
+	setMessageFunc = func(baseAppError *BaseAppError, messageFormat string, parameters []interface{}) {
+		setMessageFuncCalled++
+		assert.Equal(t, dummyMessage, messageFormat)
+		assert.Empty(t, parameters)
+		baseAppError.error = dummyError
+	}
+
+	// SUT + act
+	var result = newSentinel(
+		dummyCode,
+		dummyMessage,
+	)
+
+	// assert
+	assert.Equal(t, dummyError, result.error)
+	assert.Equal(t, dummyCode, result.code)
+	assert.True(t, result.frozen)
+	assert.True(t, result.sentinel)
+
+	// verify
+	verifyAll(t)
+}
+
+func TestGetCustomSentinel(t *testing.T) {
+	// arrange
+	var dummyCode = Code(1000 + rand.Intn(1000))
+	var dummySentinel = &BaseAppError{code: dummyCode}
+
+	// mock
+	createMock(t)
+
+	// expect
+	newSentinelFuncExpected = 1
+	newSentinelFunc = func(code Code, message string) *BaseAppError {
+		newSentinelFuncCalled++
+		assert.Equal(t, dummyCode, code)
+		assert.Equal(t, dummyCode.String(), message)
+		return dummySentinel
+	}
+
+	// SUT + act
+	var result1 = getCustomSentinel(
+		dummyCode,
+	)
+	var result2 = getCustomSentinel(
+		dummyCode,
+	)
+
+	// assert
+	assert.Same(t, dummySentinel, result1)
+	assert.Same(t, dummySentinel, result2)
+
+	// verify
+	verifyAll(t)
+}
+
+func TestInnerErrorIs_NoMatch(t *testing.T) {
+	// arrange
+	var dummyInnerErrors = []error{errors.New("some inner error 1"), errors.New("some inner error 2")}
+	var dummyTarget = errors.New("some target")
+
+	// mock
+	createMock(t)
+
+	// expect
+	errorsIsExpected = 2
+	errorsIs = func(err, target error) bool {
+		errorsIsCalled++
+		assert.Equal(t, dummyInnerErrors[errorsIsCalled-1], err)
+		assert.Equal(t, dummyTarget, target)
+		return false
+	}
+
+	// SUT + act
+	var result = innerErrorIs(
+		dummyInnerErrors,
+		dummyTarget,
+	)
+
+	// assert
+	assert.False(t, result)
+
+	// verify
+	verifyAll(t)
+}
+
+func TestInnerErrorIs_Match(t *testing.T) {
+	// arrange
+	var dummyInnerErrors = []error{errors.New("some inner error 1"), errors.New("some inner error 2")}
+	var dummyTarget = errors.New("some target")
+
+	// mock
+	createMock(t)
+
+	// expect
+	errorsIsExpected = 1
+	errorsIs = func(err, target error) bool {
+		errorsIsCalled++
+		return true
+	}
+
+	// SUT + act
+	var result = innerErrorIs(
+		dummyInnerErrors,
+		dummyTarget,
+	)
+
+	// assert
+	assert.True(t, result)
+
+	// verify
+	verifyAll(t)
+}
+
+func TestBaseAppError_Is_Origin(t *testing.T) {
+	// arrange
+	var dummyOrigin = &BaseAppError{}
+	var dummyOther = &BaseAppError{}
+
+	// mock
+	createMock(t)
+
+	// SUT
+	var sut = &BaseAppError{
+		details: &errorDetails{origin: dummyOrigin},
+	}
+
+	var derived = &BaseAppError{
+		details: &errorDetails{origin: sut},
+	}
+
+	// act + assert
+	assert.True(t, sut.Is(dummyOrigin))
+	assert.True(t, derived.Is(sut))
+	assert.True(t, derived.Is(dummyOrigin))
+	assert.False(t, sut.Is(derived))
+	assert.False(t, sut.Is(dummyOther))
+	assert.False(t, sut.Is(errors.New("some error")))
+	assert.False(t, dummyOrigin.Is(nil))
+
+	// verify
+	verifyAll(t)
+}
+
+func TestBaseAppError_Is_SentinelSameCode(t *testing.T) {
+	// mock
+	createMock(t)
+
+	// SUT
+	var sut = &BaseAppError{
+		code: CodeNotFound,
+	}
+
+	// act
+	var result = sut.Is(
+		&BaseAppError{code: CodeNotFound, sentinel: true},
+	)
+
+	// assert
+	assert.True(t, result)
+
+	// verify
+	verifyAll(t)
+}
+
+func TestBaseAppError_Is_SentinelInnerErrors(t *testing.T) {
+	// arrange
+	var dummyInnerErrors = []error{errors.New("some inner error")}
+	var dummyTarget = &BaseAppError{code: CodeNotFound, sentinel: true}
+	var dummyResult = rand.Intn(100) > 50
+
+	// mock
+	createMock(t)
+
+	// expect
+	innerErrorIsFuncExpected = 1
+	innerErrorIsFunc = func(innerErrors []error, target error) bool {
+		innerErrorIsFuncCalled++
+		assert.Equal(t, dummyInnerErrors, innerErrors)
+		assert.Equal(t, dummyTarget, target)
+		return dummyResult
+	}
+
+	// SUT
+	var sut = &BaseAppError{
+		code:        CodeBadRequest,
+		innerErrors: dummyInnerErrors,
+	}
+
+	// act
+	var result = sut.Is(
+		dummyTarget,
+	)
+
+	// assert
+	assert.Equal(t, dummyResult, result)
+
+	// verify
+	verifyAll(t)
+}
+
+func TestBaseAppError_Is_NonSentinelSameCode(t *testing.T) {
+	// mock
+	createMock(t)
+
+	// SUT
+	var sut = &BaseAppError{
+		code: CodeNotFound,
+	}
+
+	// act
+	var result = sut.Is(
+		&BaseAppError{code: CodeNotFound},
+	)
+
+	// assert
+	assert.False(t, result)
+
+	// verify
+	verifyAll(t)
+}
+
+func TestCodeSentinel_NestedWraps(t *testing.T) {
+	// arrange
+	var notFoundError = GetNotFoundError(errors.New("some cause"))
+	var nestedError = GetGeneralFailureError(
+		errors.New("some other error"),
+		fmt.Errorf("some wrapping: %w", GetBadRequestError(notFoundError)),
+	)
+	var customCode = Code(2000 + rand.Intn(1000))
+	var customError = NewBaseAppError(customCode, "some custom error")
+
+	// act + assert
+	assert.True(t, errors.Is(notFoundError, CodeNotFound.Sentinel()))
+	assert.True(t, errors.Is(nestedError, CodeGeneralFailure.Sentinel()))
+	assert.True(t, errors.Is(nestedError, CodeBadRequest.Sentinel()))
+	assert.True(t, errors.Is(nestedError, ErrNotFound))
+	assert.True(t, errors.Is(fmt.Errorf("some wrapping: %w", nestedError), ErrNotFound))
+	assert.False(t, errors.Is(nestedError, CodeDataCorruption.Sentinel()))
+	assert.False(t, errors.Is(notFoundError, GetNotFoundError()))
+	assert.True(t, nestedError.Contains(ErrNotFound))
+	assert.False(t, nestedError.Contains(ErrDataCorruption))
+	assert.True(t, errors.Is(GetGeneralFailureError(customError), customCode.Sentinel()))
+	assert.Same(t, customCode.Sentinel(), customCode.Sentinel())
+}
+
+func TestBaseAppError_FrozenMutations(t *testing.T) {
+	// mock
+	createMock(t)
+
+	// SUT
+	var sut = &BaseAppError{
+		frozen: true,
+	}
+
+	// act
+	sut.Wrap(errors.New("some error"))
+	sut.Attach("some name", "some value")
+	sut.AttachAttrs(String("some other name", "some value"))
+
+	// assert
+	assert.Empty(t, sut.innerErrors)
+	assert.Empty(t, sut.extraData)
+	assert.Empty(t, sut.attrs)
+
+	// verify
+	verifyAll(t)
+}
+
+func TestSentinels(t *testing.T) {
+	// arrange
+	var sentinels = map[Code]AppError{
+		CodeGeneralFailure:   ErrGeneralFailure,
+		CodeUnauthorized:     ErrUnauthorized,
+		CodeInvalidOperation: ErrInvalidOperation,
+		CodeBadRequest:       ErrBadRequest,
+		CodeNotFound:         ErrNotFound,
+		CodeCircuitBreak:     ErrCircuitBreak,
+		CodeOperationLock:    ErrOperationLock,
+		CodeAccessForbidden:  ErrAccessForbidden,
+		CodeDataCorruption:   ErrDataCorruption,
+		CodeNotImplemented:   ErrNotImplemented,
+	}
+
+	// assert
+	assert.Len(t, sentinels, int(codeMaxCount))
+	for code, sentinel := range sentinels {
+		assert.Equal(t, code.String(), sentinel.Code())
+		assert.True(t, sentinel.(*BaseAppError).IsFrozen())
+	}
+}
+
+func TestSentinels_NoLeakAcrossRequests(t *testing.T) {
+	// arrange
+	var causeError = errors.New("some cause")
+
+	// act
+	var request1Error = ErrNotFound.With("id", 1).WithCause(causeError)
+	var request2Error = ErrNotFound.With("id", 2)
+	ErrNotFound.Attach("id", 3)
+	ErrNotFound.Wrap(causeError)
+	var wrappedError = fmt.Errorf("some wrapping: %w", request1Error)
+
+	// assert
+	assert.Equal(t, "(NotFound) Requested resource is not found in the storage", ErrNotFound.Error())
+	assert.Equal(t, "(NotFound) Requested resource is not found in the storage [ id = 1 ] [ some cause ]", request1Error.Error())
+	assert.Equal(t, "(NotFound) Requested resource is not found in the storage [ id = 2 ]", request2Error.Error())
+	assert.True(t, errors.Is(request1Error, ErrNotFound))
+	assert.True(t, errors.Is(request2Error, ErrNotFound))
+	assert.True(t, errors.Is(wrappedError, ErrNotFound))
+	assert.False(t, errors.Is(request1Error, ErrBadRequest))
+}
+
+func TestWithCause_NoLeakFromMutableSource(t *testing.T) {
+	// arrange
+	var source = NewBaseAppError(CodeNotFound, "some message")
+	source.Attach("id", 5)
+	source.AttachAttrs(Int("n", 1))
+
+	// act
+	var derivedError = source.WithCause(errors.New("some cause"))
+	var before = derivedError.Detail()
+	source.Attach("leak", "yes")
+	source.AttachAttrs(Int("n", 99))
+
+	// assert
+	assert.Equal(t, before, derivedError.Detail())
+	assert.NotContains(t, derivedError.Detail(), "leak")
+	assert.Contains(t, derivedError.Error(), "n = 1")
+	assert.NotContains(t, derivedError.Error(), "leak")
+}
+
+func TestDerivedSentinels_ErrorsIs(t *testing.T) {
+	// arrange
+	var errQuota = ErrBadRequest.With("reason", "quota")
+	var errOther = ErrBadRequest.With("reason", "other")
+
+	// act
+	var derivedError = errQuota.With("user", 7)
+
+	// assert
+	assert.True(t, errors.Is(derivedError, errQuota))
+	assert.True(t, errors.Is(derivedError, ErrBadRequest))
+	assert.False(t, errors.Is(derivedError, errOther))
+	assert.False(t, errors.Is(errQuota, derivedError))
+}
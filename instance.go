@@ -0,0 +1,88 @@
+package apperror
+
+import (
+	"sync/atomic"
+)
+
+// HeaderInstanceID is the HTTP response header WriteProblem sets to the instance ID of the app error
+const HeaderInstanceID string = "X-Error-Instance-Id"
+
+// InstanceIDDataName is the reserved extra data name under which Error() prints the instance ID of the app error once SetPrintInstanceID is on
+const InstanceIDDataName string = "@instance"
+
+// pendingInstanceID marks the app errors whose instance ID is yet to be generated; the ID is only generated once asked for, so that creating an app error costs no more than its own allocation
+var pendingInstanceID = ""
+
+// InstanceIDGenerator returns a new unique ID for an app error instance, or an empty string for none
+type InstanceIDGenerator func() string
+
+var instanceIDGenerator atomic.Pointer[InstanceIDGenerator]
+
+// SetInstanceIDGenerator replaces the generator of app error instance IDs, or restores the default UUIDv7 one when given nil
+func SetInstanceIDGenerator(generator InstanceIDGenerator) {
+	if generator == nil {
+		instanceIDGenerator.Store(nil)
+		return
+	}
+	instanceIDGenerator.Store(&generator)
+}
+
+func newUUIDv7() string {
+	var id, idError = uuidNewV7()
+	if idError != nil {
+		// the random source failing leaves the app error without an ID rather than failing it
+		return ""
+	}
+	return id.String()
+}
+
+func newInstanceID() string {
+	var generator = instanceIDGenerator.Load()
+	if generator == nil {
+		return newUUIDv7Func()
+	}
+	return (*generator)()
+}
+
+// NewInstanceID returns a new instance ID from the generator set through SetInstanceIDGenerator, such as for app errors of the v2 package
+func NewInstanceID() string {
+	return newInstanceIDFunc()
+}
+
+// withInstanceIDData returns a copy of the extra data holding the instance ID under InstanceIDDataName, or the extra data as is if the ID is empty
+func withInstanceIDData(extraData map[string]interface{}, instanceID string) map[string]interface{} {
+	if instanceID == "" {
+		return extraData
+	}
+	var result = make(map[string]interface{}, len(extraData)+1)
+	for name, value := range extraData {
+		result[name] = value
+	}
+	result[InstanceIDDataName] = instanceID
+	return result
+}
+
+// instanceIDer is implemented by app errors carrying an instance ID, such as *BaseAppError
+type instanceIDer interface {
+	InstanceID() string
+}
+
+// InstanceID returns the unique ID of the app error instance, or an empty string if it has none
+func (baseAppError *BaseAppError) InstanceID() string {
+	if baseAppError == nil {
+		return ""
+	}
+	var cache = baseAppError.getCache()
+	var current = cache.instanceID.Load()
+	if current == nil {
+		return ""
+	}
+	if current != &pendingInstanceID {
+		return *current
+	}
+	var instanceID = newInstanceIDFunc()
+	if cache.instanceID.CompareAndSwap(current, &instanceID) {
+		return instanceID
+	}
+	return *cache.instanceID.Load()
+}
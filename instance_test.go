@@ -0,0 +1,199 @@
+package apperror
+
+import (
+	"errors"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestNewUUIDv7_Success(t *testing.T) {
+	// arrange
+	var dummyUUID = uuid.New()
+
+	// mock
+	createMock(t)
+
+	// expect
+	uuidNewV7Expected = 1
+	uuidNewV7 = func() (uuid.UUID, error) {
+		uuidNewV7Called++
+		return dummyUUID, nil
+	}
+
+	// SUT + act
+	var result = newUUIDv7()
+
+	// assert
+	assert.Equal(t, dummyUUID.String(), result)
+
+	// verify
+	verifyAll(t)
+}
+
+func TestNewUUIDv7_Error(t *testing.T) {
+	// mock
+	createMock(t)
+
+	// expect
+	uuidNewV7Expected = 1
+	uuidNewV7 = func() (uuid.UUID, error) {
+		uuidNewV7Called++
+		return uuid.Nil, errors.New("some error")
+	}
+
+	// SUT + act
+	var result = newUUIDv7()
+
+	// assert
+	assert.Empty(t, result)
+
+	// verify
+	verifyAll(t)
+}
+
+func TestNewInstanceID_Default(t *testing.T) {
+	// arrange
+	var dummyID = "some id"
+
+	// mock
+	createMock(t)
+
+	// expect
+	newUUIDv7FuncExpected = 1
+	newUUIDv7Func = func() string {
+		newUUIDv7FuncCalled++
+		return dummyID
+	}
+
+	// SUT + act
+	SetInstanceIDGenerator(nil)
+	var result = newInstanceID()
+
+	// assert
+	assert.Equal(t, dummyID, result)
+
+	// verify
+	verifyAll(t)
+}
+
+func TestNewInstanceID_Custom(t *testing.T) {
+	// mock
+	createMock(t)
+
+	// SUT + act
+	SetInstanceIDGenerator(func() string { return "some custom id" })
+	defer SetInstanceIDGenerator(nil)
+	var result = newInstanceID()
+
+	// assert
+	assert.Equal(t, "some custom id", result)
+
+	// verify
+	verifyAll(t)
+}
+
+func TestNewInstanceID_Exported(t *testing.T) {
+	// mock
+	createMock(t)
+
+	// expect
+	newInstanceIDFuncExpected = 1
+	newInstanceIDFunc = func() string {
+		newInstanceIDFuncCalled++
+		return "some instance id"
+	}
+
+	// SUT + act
+	var result = NewInstanceID()
+
+	// assert
+	assert.Equal(t, "some instance id", result)
+
+	// verify
+	verifyAll(t)
+}
+
+func TestInstanceID_Nil(t *testing.T) {
+	// arrange
+	var sut *BaseAppError
+
+	// assert
+	assert.Empty(t, sut.InstanceID())
+}
+
+func TestInstanceID_GeneratedOnFirstRead(t *testing.T) {
+	// mock
+	createMock(t)
+
+	// expect
+	newInstanceIDFuncExpected = 1
+	newInstanceIDFunc = func() string {
+		newInstanceIDFuncCalled++
+		return "some instance id"
+	}
+
+	// SUT
+	var sut = &BaseAppError{}
+	sut.getCache().instanceID.Store(&pendingInstanceID)
+
+	// act
+	var result1 = sut.InstanceID()
+	var result2 = sut.InstanceID()
+
+	// assert
+	assert.Equal(t, "some instance id", result1)
+	assert.Equal(t, "some instance id", result2)
+	assert.Empty(t, (&BaseAppError{}).InstanceID())
+
+	// verify
+	verifyAll(t)
+}
+
+func TestInstanceID_Lifecycle(t *testing.T) {
+	// arrange
+	var recorder = httptest.NewRecorder()
+
+	// act
+	var created = NewBaseAppError(CodeNotFound, "some message")
+	var derived = ErrNotFound.With("some name", "some value")
+	var err = WriteProblem(recorder, derived)
+
+	// assert
+	assert.NoError(t, err)
+	var createdID, createdError = uuid.Parse(created.InstanceID())
+	assert.NoError(t, createdError)
+	assert.Equal(t, uuid.Version(7), createdID.Version())
+	assert.Empty(t, ErrNotFound.InstanceID())
+	var derivedID = derived.InstanceID()
+	assert.NotEmpty(t, derivedID)
+	assert.NotEqual(t, created.InstanceID(), derivedID)
+	assert.Equal(t, derivedID, recorder.Header().Get(HeaderInstanceID))
+	assert.Contains(t, recorder.Body.String(), `"instance":"`+derivedID+`"`)
+}
+
+func TestBaseAppError_Error_PrintInstanceIDRoundTrip(t *testing.T) {
+	// arrange
+	SetInstanceIDGenerator(func() string { return "some instance id" })
+	defer SetInstanceIDGenerator(nil)
+	var sut = NewBaseAppError(CodeNotFound, "some message")
+	sut.Attach("id", 5)
+	var before = sut.Error()
+
+	// act
+	SetPrintInstanceID(true)
+	var text = sut.Error()
+	var result, err = Parse(text)
+	SetPrintInstanceID(false)
+	var after = sut.Error()
+
+	// assert
+	assert.Equal(t, "(NotFound) some message [ id = 5 ]", before)
+	assert.Equal(t, "(NotFound) some message [ id = 5 | @instance = some instance id ]", text)
+	assert.NoError(t, err)
+	assert.Equal(t, "some instance id", result.(*BaseAppError).InstanceID())
+	assert.Equal(t, map[string]interface{}{"id": "5"}, result.(*BaseAppError).extraData)
+	assert.Equal(t, before, after)
+}
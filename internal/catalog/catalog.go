@@ -0,0 +1,189 @@
+// Package catalog reads domain error catalogs, the YAML/JSON files listing the error codes of a package
+package catalog
+
+import (
+	"encoding/json"
+	"fmt"
+	"go/token"
+	"net/http"
+	"os"
+	"path/filepath"
+	"strings"
+
+	apperror "github.com/zhongjie-cai/app-error/v2"
+	"gopkg.in/yaml.v3"
+)
+
+// Entry is a single error code of a catalog
+type Entry struct {
+	// Name is the Go identifier of the error code, e.g. "QuotaExceeded"
+	Name string `json:"name" yaml:"name"`
+	// Code is the numeric value of the error code; when unset, the previous entry's code plus one (or the catalog base) is used
+	Code *int `json:"code,omitempty" yaml:"code,omitempty"`
+	// HTTPStatus is the HTTP status code; defaults to 500
+	HTTPStatus int `json:"http_status,omitempty" yaml:"http_status,omitempty"`
+	// GRPCCode is the gRPC status code name, e.g. "ResourceExhausted"; defaults to "Unknown"
+	GRPCCode string `json:"grpc_code,omitempty" yaml:"grpc_code,omitempty"`
+	// Message is the default message of the error code
+	Message string `json:"message" yaml:"message"`
+	// Severity is a free-form severity label; defaults to "error"
+	Severity string `json:"severity,omitempty" yaml:"severity,omitempty"`
+	// Retryable tells whether operations failing with the error code may be retried
+	Retryable bool `json:"retryable,omitempty" yaml:"retryable,omitempty"`
+}
+
+// Catalog is the content of a catalog file
+type Catalog struct {
+	// Package is the name of the Go package the catalog belongs to
+	Package string `json:"package,omitempty" yaml:"package,omitempty"`
+	// Base is the first code value used for entries without an explicit code; defaults to the first code after the built-in ones
+	Base int `json:"base,omitempty" yaml:"base,omitempty"`
+	// Codes are the error codes of the catalog
+	Codes []Entry `json:"codes" yaml:"codes"`
+}
+
+// grpcCodes maps the canonical gRPC status code names to their values
+var grpcCodes = map[string]uint32{
+	"OK":                 0,
+	"Canceled":           1,
+	"Unknown":            2,
+	"InvalidArgument":    3,
+	"DeadlineExceeded":   4,
+	"NotFound":           5,
+	"AlreadyExists":      6,
+	"PermissionDenied":   7,
+	"ResourceExhausted":  8,
+	"FailedPrecondition": 9,
+	"Aborted":            10,
+	"OutOfRange":         11,
+	"Unimplemented":      12,
+	"Internal":           13,
+	"Unavailable":        14,
+	"DataLoss":           15,
+	"Unauthenticated":    16,
+}
+
+// GRPCCodeValue returns the value of the given canonical gRPC status code name
+func GRPCCodeValue(name string) (uint32, bool) {
+	var value, isKnown = grpcCodes[name]
+	return value, isKnown
+}
+
+// Load reads the catalog file at the given path; files ending with ".json" are parsed as JSON, any other as YAML
+func Load(path string) (*Catalog, error) {
+	var content, err = os.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+	var catalog *Catalog
+	if strings.EqualFold(filepath.Ext(path), ".json") {
+		catalog, err = ParseJSON(content)
+	} else {
+		catalog, err = ParseYAML(content)
+	}
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", path, err)
+	}
+	return catalog, nil
+}
+
+// ParseYAML parses, normalizes and validates the given YAML catalog content
+func ParseYAML(content []byte) (*Catalog, error) {
+	var catalog Catalog
+	var decoder = yaml.NewDecoder(strings.NewReader(string(content)))
+	decoder.KnownFields(true)
+	if err := decoder.Decode(&catalog); err != nil {
+		return nil, err
+	}
+	return &catalog, catalog.normalize()
+}
+
+// ParseJSON parses, normalizes and validates the given JSON catalog content
+func ParseJSON(content []byte) (*Catalog, error) {
+	var catalog Catalog
+	var decoder = json.NewDecoder(strings.NewReader(string(content)))
+	decoder.DisallowUnknownFields()
+	if err := decoder.Decode(&catalog); err != nil {
+		return nil, err
+	}
+	return &catalog, catalog.normalize()
+}
+
+// normalize fills in default values and validates the catalog
+func (catalog *Catalog) normalize() error {
+	if catalog.Package != "" && !token.IsIdentifier(catalog.Package) {
+		return fmt.Errorf("package %q is not a valid identifier", catalog.Package)
+	}
+	if len(catalog.Codes) == 0 {
+		return fmt.Errorf("catalog has no codes")
+	}
+	var names = map[string]bool{}
+	var codes = map[int]string{}
+	var next = catalog.Base
+	if next == 0 {
+		next = int(apperror.CodeNotImplemented) + 1
+	}
+	for index := range catalog.Codes {
+		var entry = &catalog.Codes[index]
+		if !token.IsIdentifier(entry.Name) || !token.IsExported(entry.Name) {
+			return fmt.Errorf("codes[%d]: name %q is not an exported identifier", index, entry.Name)
+		}
+		if names[entry.Name] {
+			return fmt.Errorf("codes[%d]: name %q is duplicated", index, entry.Name)
+		}
+		names[entry.Name] = true
+		if entry.Code == nil {
+			var code = next
+			entry.Code = &code
+		}
+		var code = *entry.Code
+		next = code + 1
+		if existing, isDuplicated := codes[code]; isDuplicated {
+			return fmt.Errorf("codes[%d]: code %d is already used by %q", index, code, existing)
+		}
+		codes[code] = entry.Name
+		if definition, isBuiltIn := apperror.Lookup(apperror.Code(code)); isBuiltIn {
+			return fmt.Errorf("codes[%d]: code %d is reserved by %q", index, code, definition.Name)
+		}
+		if entry.HTTPStatus == 0 {
+			entry.HTTPStatus = http.StatusInternalServerError
+		}
+		if http.StatusText(entry.HTTPStatus) == "" {
+			return fmt.Errorf("codes[%d]: HTTP status %d is unknown", index, entry.HTTPStatus)
+		}
+		if entry.GRPCCode == "" {
+			entry.GRPCCode = "Unknown"
+		}
+		if _, isKnown := grpcCodes[entry.GRPCCode]; !isKnown {
+			return fmt.Errorf("codes[%d]: gRPC code %q is unknown", index, entry.GRPCCode)
+		}
+		if entry.Message == "" {
+			return fmt.Errorf("codes[%d]: message is missing", index)
+		}
+		if entry.Severity == "" {
+			entry.Severity = "error"
+		}
+	}
+	return nil
+}
+
+// Definitions converts the catalog entries into registry definitions
+func (catalog *Catalog) Definitions() []apperror.Definition {
+	var definitions = make([]apperror.Definition, 0, len(catalog.Codes))
+	for _, entry := range catalog.Codes {
+		var grpcCode, _ = GRPCCodeValue(entry.GRPCCode)
+		definitions = append(
+			definitions,
+			apperror.Definition{
+				Code:           apperror.Code(*entry.Code),
+				Name:           entry.Name,
+				HTTPStatusCode: entry.HTTPStatus,
+				Message:        entry.Message,
+				GRPCCode:       grpcCode,
+				Severity:       entry.Severity,
+				Retryable:      entry.Retryable,
+			},
+		)
+	}
+	return definitions
+}
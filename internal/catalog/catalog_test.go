@@ -0,0 +1,142 @@
+package catalog
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	apperror "github.com/zhongjie-cai/app-error/v2"
+)
+
+// codeOf returns a pointer to the given code value
+func codeOf(value int) *int {
+	return &value
+}
+
+func TestParseYAML_Defaults(t *testing.T) {
+	// arrange
+	var content = []byte(`
+package: billing
+base: 1000
+codes:
+  - name: QuotaExceeded
+    http_status: 429
+    grpc_code: ResourceExhausted
+    message: The quota is exceeded
+    severity: warning
+    retryable: true
+  - name: InvoiceMissing
+    message: The invoice is missing
+  - name: Explicit
+    code: 2000
+    message: Explicit code
+`)
+
+	// act
+	var catalog, err = ParseYAML(content)
+
+	// assert
+	assert.NoError(t, err)
+	assert.Equal(t, "billing", catalog.Package)
+	assert.Equal(t, []Entry{
+		{Name: "QuotaExceeded", Code: codeOf(1000), HTTPStatus: 429, GRPCCode: "ResourceExhausted", Message: "The quota is exceeded", Severity: "warning", Retryable: true},
+		{Name: "InvoiceMissing", Code: codeOf(1001), HTTPStatus: 500, GRPCCode: "Unknown", Message: "The invoice is missing", Severity: "error"},
+		{Name: "Explicit", Code: codeOf(2000), HTTPStatus: 500, GRPCCode: "Unknown", Message: "Explicit code", Severity: "error"},
+	}, catalog.Codes)
+}
+
+func TestParseJSON_Valid(t *testing.T) {
+	// arrange
+	var content = []byte(`{"base": 100, "codes": [{"name": "Gone", "http_status": 410, "grpc_code": "NotFound", "message": "Gone"}]}`)
+
+	// act
+	var catalog, err = ParseJSON(content)
+
+	// assert
+	assert.NoError(t, err)
+	assert.Equal(t, codeOf(100), catalog.Codes[0].Code)
+	assert.Equal(t, 410, catalog.Codes[0].HTTPStatus)
+}
+
+func TestParseJSON_NoBase(t *testing.T) {
+	// arrange
+	var content = []byte(`{"codes": [{"name": "A", "message": "m"}, {"name": "B", "message": "m"}]}`)
+
+	// act
+	var catalog, err = ParseJSON(content)
+
+	// assert
+	assert.NoError(t, err)
+	assert.Equal(t, codeOf(int(apperror.CodeNotImplemented)+1), catalog.Codes[0].Code)
+	assert.Equal(t, codeOf(int(apperror.CodeNotImplemented)+2), catalog.Codes[1].Code)
+}
+
+func TestParseJSON_Invalid(t *testing.T) {
+	// arrange
+	var testCases = map[string]string{
+		`{"codes": []}`:                   "catalog has no codes",
+		`{"package": "a-b", "codes": []}`: `package "a-b" is not a valid identifier`,
+		`{"base": 100, "codes": [{"name": "lower", "message": "m"}]}`:                                         `codes[0]: name "lower" is not an exported identifier`,
+		`{"base": 100, "codes": [{"name": "A", "message": "m"}, {"name": "A", "message": "m"}]}`:              `codes[1]: name "A" is duplicated`,
+		`{"base": 100, "codes": [{"name": "A", "message": "m"}, {"name": "B", "code": 100, "message": "m"}]}`: `codes[1]: code 100 is already used by "A"`,
+		`{"codes": [{"name": "A", "code": 4, "message": "m"}]}`:                                               `codes[0]: code 4 is reserved by "NotFound"`,
+		`{"codes": [{"name": "A", "code": 0, "message": "m"}]}`:                                               `codes[0]: code 0 is reserved by "GeneralFailure"`,
+		`{"base": 100, "codes": [{"name": "A", "http_status": 999, "message": "m"}]}`:                         "codes[0]: HTTP status 999 is unknown",
+		`{"base": 100, "codes": [{"name": "A", "grpc_code": "Nope", "message": "m"}]}`:                        `codes[0]: gRPC code "Nope" is unknown`,
+		`{"base": 100, "codes": [{"name": "A"}]}`:                                                             "codes[0]: message is missing",
+		`{"base": 100, "unknown": 1, "codes": [{"name": "A", "message": "m"}]}`:                               `json: unknown field "unknown"`,
+	}
+
+	for content, expectedError := range testCases {
+		// act
+		var _, err = ParseJSON([]byte(content))
+
+		// assert
+		assert.EqualError(t, err, expectedError, content)
+	}
+}
+
+func TestLoad_ByExtension(t *testing.T) {
+	// arrange
+	var directory = t.TempDir()
+	var jsonPath = filepath.Join(directory, "errors.json")
+	var yamlPath = filepath.Join(directory, "errors.yml")
+	os.WriteFile(jsonPath, []byte(`{"base": 100, "codes": [{"name": "A", "message": "m"}]}`), 0o600)
+	os.WriteFile(yamlPath, []byte("base: 100\ncodes:\n  - name: A\n    message: m\n  - name: A\n    message: m\n"), 0o600)
+
+	// act
+	var jsonCatalog, jsonErr = Load(jsonPath)
+	var _, yamlErr = Load(yamlPath)
+	var _, missingErr = Load(filepath.Join(directory, "missing.yaml"))
+
+	// assert
+	assert.NoError(t, jsonErr)
+	assert.Equal(t, "A", jsonCatalog.Codes[0].Name)
+	assert.EqualError(t, yamlErr, yamlPath+`: codes[1]: name "A" is duplicated`)
+	assert.Error(t, missingErr)
+}
+
+func TestGRPCCodeValue(t *testing.T) {
+	// act
+	var value, isKnown = GRPCCodeValue("ResourceExhausted")
+	var _, isUnknown = GRPCCodeValue("Nope")
+
+	// assert
+	assert.True(t, isKnown)
+	assert.Equal(t, uint32(8), value)
+	assert.False(t, isUnknown)
+}
+
+func TestCatalog_Definitions(t *testing.T) {
+	// arrange
+	var catalog, _ = ParseJSON([]byte(`{"base": 100, "codes": [{"name": "A", "http_status": 429, "grpc_code": "ResourceExhausted", "message": "m", "retryable": true}]}`))
+
+	// act
+	var definitions = catalog.Definitions()
+
+	// assert
+	assert.Equal(t, []apperror.Definition{
+		{Code: 100, Name: "A", HTTPStatusCode: 429, Message: "m", GRPCCode: 8, Severity: "error", Retryable: true},
+	}, definitions)
+}
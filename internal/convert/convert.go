@@ -0,0 +1,15 @@
+// Package convert lets the v2 package build v1 app errors from converted v2 errors, keeping the constructor out of the v1 API.
+package convert
+
+// AppError holds the data of an app error converted from another representation
+type AppError struct {
+	Code        int
+	Message     string
+	InnerErrors []error
+	ExtraData   map[string]interface{}
+	Component   string
+	InstanceID  string
+}
+
+// NewV1AppError creates a v1 app error from the given converted data, without reporting it as created; it is set by the v1 package when it is initialized
+var NewV1AppError func(appError AppError) error
@@ -0,0 +1,395 @@
+// Package errortext parses the one-line text produced by AppError.Error(), i.e. "(Code) Message [ name = value | ... ] [ inner error | ... ]", back into a tree.
+//
+// Two versions of the text are understood.
+// The escaped version prefixes every backslash, bracket, parenthesis, pipe and equal sign that is part of a code, message, name or value with a backslash, so it is unambiguous.
+// The legacy version has no escaping; it is parsed with heuristics and is used as a fallback whenever the text is not valid escaped text.
+package errortext
+
+import (
+	"errors"
+	"strings"
+)
+
+// These are the tokens of the one-line format, matching the code, extra data, joining and separator constants of the root package
+const (
+	tokenOpen      = " [ "
+	tokenClose     = " ]"
+	tokenSeparator = " | "
+	tokenAssign    = " = "
+	escapeChar     = '\\'
+	specialChars   = "\\[]|=()"
+)
+
+// SelfEscaper is implemented by the app errors of both package versions, which escape their own text in escaped print mode; errors wrapping them must not escape that text again
+type SelfEscaper interface {
+	EscapesOwnText() bool
+}
+
+// EscapesOwnText tells whether the error escapes its own text, i.e. is a SelfEscaper reporting so; wrappers of other errors, which implement SelfEscaper for the errors they wrap, report false for any other error
+func EscapesOwnText(err error) bool {
+	var escaper, isEscaper = err.(SelfEscaper)
+	return isEscaper && escaper.EscapesOwnText()
+}
+
+// ErrNotAppError is returned when the text does not start with a "(Code) " prefix
+var ErrNotAppError = errors.New("errortext: text is not an app error")
+
+// Field is a single extra data entry of an error
+type Field struct {
+	Key   string `json:"key"`
+	Value string `json:"value"`
+}
+
+// Node is a parsed error; plain (non app error) inner errors only have a Message
+type Node struct {
+	Code        string  `json:"code,omitempty"`
+	Message     string  `json:"message"`
+	Data        []Field `json:"data,omitempty"`
+	InnerErrors []*Node `json:"innerErrors,omitempty"`
+}
+
+// Walk calls f on the node and all of its descendants, depth first, stopping when f returns false
+func (node *Node) Walk(f func(*Node) bool) bool {
+	if !f(node) {
+		return false
+	}
+	for _, innerError := range node.InnerErrors {
+		if !innerError.Walk(f) {
+			return false
+		}
+	}
+	return true
+}
+
+// HasCode tells whether the node or any of its descendants has the given code
+func (node *Node) HasCode(code string) bool {
+	return !node.Walk(func(current *Node) bool {
+		return current.Code != code
+	})
+}
+
+// Escape escapes the special characters of the given code, message, name or value for the escaped version of the text
+func Escape(text string) string {
+	if !strings.ContainsAny(text, specialChars) {
+		return text
+	}
+	var builder strings.Builder
+	builder.Grow(len(text) + 8)
+	for index := 0; index < len(text); index++ {
+		if strings.IndexByte(specialChars, text[index]) >= 0 {
+			builder.WriteByte(escapeChar)
+		}
+		builder.WriteByte(text[index])
+	}
+	return builder.String()
+}
+
+// Parse parses the given text, which must start with a "(Code) " prefix where Code is a capitalized identifier; escaped text is parsed strictly, anything else with the legacy heuristics
+func Parse(text string) (*Node, error) {
+	if decoded, isDecoded := decode(text); isDecoded {
+		var parser = &parser{strict: true}
+		if node, err := parser.parse(decoded); err == nil && parser.valid {
+			return node, nil
+		}
+	}
+	return (&parser{}).parse(raw(text))
+}
+
+// Find locates the first app error in the given line, e.g. after a log timestamp, returning the text before it and the parsed error
+func Find(line string) (string, *Node, bool) {
+	for index := 0; index < len(line); index++ {
+		if line[index] != '(' || (index > 0 && line[index-1] == escapeChar) {
+			continue
+		}
+		var node, err = Parse(line[index:])
+		if err == nil {
+			return line[:index], node, true
+		}
+	}
+	return line, nil, false
+}
+
+// char is a byte of the text, remembering whether it was escaped
+type char struct {
+	value   byte
+	escaped bool
+}
+
+// chars is a text whose escape sequences have been resolved
+type chars []char
+
+// raw converts legacy text, where nothing is escaped
+func raw(text string) chars {
+	var result = make(chars, len(text))
+	for index := 0; index < len(text); index++ {
+		result[index] = char{value: text[index]}
+	}
+	return result
+}
+
+// decode resolves the escape sequences of escaped text, failing on a backslash not followed by a special character
+func decode(text string) (chars, bool) {
+	var result = make(chars, 0, len(text))
+	for index := 0; index < len(text); index++ {
+		if text[index] != escapeChar {
+			result = append(result, char{value: text[index]})
+			continue
+		}
+		index++
+		if index == len(text) || strings.IndexByte(specialChars, text[index]) < 0 {
+			return nil, false
+		}
+		result = append(result, char{value: text[index], escaped: true})
+	}
+	return result, true
+}
+
+// hasPrefix tells whether the text starts with the given unescaped token
+func (text chars) hasPrefix(token string) bool {
+	if len(text) < len(token) {
+		return false
+	}
+	for index := 0; index < len(token); index++ {
+		if text[index].escaped || text[index].value != token[index] {
+			return false
+		}
+	}
+	return true
+}
+
+// index returns the position of the first occurrence of the given unescaped token, or -1
+func (text chars) index(token string) int {
+	for index := range text {
+		if text[index:].hasPrefix(token) {
+			return index
+		}
+	}
+	return -1
+}
+
+func (text chars) String() string {
+	var bytes = make([]byte, len(text))
+	for index, char := range text {
+		bytes[index] = char.value
+	}
+	return string(bytes)
+}
+
+// parser parses either version of the text; in strict mode, an unescaped special character outside of the format tokens invalidates the text
+type parser struct {
+	strict bool
+	valid  bool
+}
+
+// parse parses a text starting with "(Code) "
+func (parser *parser) parse(text chars) (*Node, error) {
+	parser.valid = true
+	var code, rest, isCoded = splitCode(text)
+	if !isCoded {
+		return nil, ErrNotAppError
+	}
+	var node = parser.parseBody(rest)
+	node.Code = code
+	return node, nil
+}
+
+// plain converts a code, message, name or value, flagging unescaped special characters in strict mode
+func (parser *parser) plain(text chars) string {
+	if parser.strict {
+		for _, char := range text {
+			if !char.escaped && strings.IndexByte(specialChars, char.value) >= 0 {
+				parser.valid = false
+			}
+		}
+	}
+	return text.String()
+}
+
+func isCodeChar(char char) bool {
+	return char.value == '_' ||
+		(char.value >= '0' && char.value <= '9') ||
+		(char.value >= 'a' && char.value <= 'z') ||
+		(char.value >= 'A' && char.value <= 'Z')
+}
+
+// splitCode splits "(Code) rest" into "Code" and "rest"; codes are capitalized identifiers, as produced by Code.String()
+func splitCode(text chars) (string, chars, bool) {
+	if !text.hasPrefix("(") {
+		return "", text, false
+	}
+	var end = text.index(")")
+	if end <= 1 || text[1].value < 'A' || text[1].value > 'Z' {
+		return "", text, false
+	}
+	for index := 1; index < end; index++ {
+		if !isCodeChar(text[index]) {
+			return "", text, false
+		}
+	}
+	var rest = text[end+1:]
+	if len(rest) > 0 && !rest.hasPrefix(" ") {
+		return "", text, false
+	}
+	if len(rest) > 0 {
+		rest = rest[1:]
+	}
+	return text[1:end].String(), rest, true
+}
+
+// span is the content range of a top-level bracket group
+type span struct {
+	start int
+	end   int
+}
+
+// groups finds the top-level " [ ... ]" groups of the text, returning their content ranges
+func groups(text chars) []span {
+	var spans []span
+	var depth = 0
+	var start = 0
+	for index := 0; index < len(text); {
+		switch {
+		case text[index:].hasPrefix(tokenOpen):
+			if depth == 0 {
+				start = index + len(tokenOpen)
+			}
+			depth++
+			index += len(tokenOpen)
+		case depth > 0 && text[index:].hasPrefix(tokenClose):
+			depth--
+			if depth == 0 {
+				spans = append(spans, span{start: start, end: index})
+			}
+			index += len(tokenClose)
+		default:
+			index++
+		}
+	}
+	return spans
+}
+
+// trailingGroups returns the last (at most two) contiguous groups ending the text
+func trailingGroups(text chars) []span {
+	var spans = groups(text)
+	var trailing []span
+	var end = len(text)
+	for index := len(spans) - 1; index >= 0 && len(trailing) < 2; index-- {
+		if spans[index].end+len(tokenClose) != end {
+			break
+		}
+		trailing = append([]span{spans[index]}, trailing...)
+		end = spans[index].start - len(tokenOpen)
+	}
+	return trailing
+}
+
+// split splits the content of a group on its top-level separators
+func split(content chars) []chars {
+	if len(content) == 0 {
+		return nil
+	}
+	var items []chars
+	var depth = 0
+	var start = 0
+	for index := 0; index < len(content); {
+		switch {
+		case content[index:].hasPrefix(tokenOpen):
+			depth++
+			index += len(tokenOpen)
+		case depth > 0 && content[index:].hasPrefix(tokenClose):
+			depth--
+			index += len(tokenClose)
+		case depth == 0 && content[index:].hasPrefix(tokenSeparator):
+			items = append(items, content[start:index])
+			index += len(tokenSeparator)
+			start = index
+		default:
+			index++
+		}
+	}
+	return append(items, content[start:])
+}
+
+// isField tells whether the item looks like "name = value"; in strict mode names may hold spaces, which are never escaped, so only unescaped special characters disqualify them
+func (parser *parser) isField(item chars) bool {
+	var separator = item.index(tokenAssign)
+	if separator <= 0 || item.hasPrefix("(") {
+		return false
+	}
+	var disqualifying = " []()"
+	if parser.strict {
+		disqualifying = specialChars
+	}
+	for _, char := range item[:separator] {
+		if !char.escaped && strings.IndexByte(disqualifying, char.value) >= 0 {
+			return false
+		}
+	}
+	return true
+}
+
+// parseFields parses the items of a group as extra data, failing if any item is not "name = value"
+func (parser *parser) parseFields(items []chars) ([]Field, bool) {
+	for _, item := range items {
+		if !parser.isField(item) {
+			return nil, false
+		}
+	}
+	var fields = make([]Field, 0, len(items))
+	for _, item := range items {
+		var separator = item.index(tokenAssign)
+		fields = append(
+			fields,
+			Field{
+				Key:   parser.plain(item[:separator]),
+				Value: parser.plain(item[separator+len(tokenAssign):]),
+			},
+		)
+	}
+	return fields, true
+}
+
+func (parser *parser) parseInnerErrors(items []chars) []*Node {
+	var innerErrors = make([]*Node, 0, len(items))
+	for _, item := range items {
+		var code, rest, isCoded = splitCode(item)
+		if !isCoded {
+			innerErrors = append(innerErrors, &Node{Message: parser.plain(item)})
+			continue
+		}
+		var node = parser.parseBody(rest)
+		node.Code = code
+		innerErrors = append(innerErrors, node)
+	}
+	return innerErrors
+}
+
+// parseBody parses "Message [ data ] [ inner errors ]"; a single trailing group is taken as extra data only when all its items look like "name = value"
+func (parser *parser) parseBody(text chars) *Node {
+	var trailing = trailingGroups(text)
+	var node = &Node{}
+	switch len(trailing) {
+	case 2:
+		var fields, isData = parser.parseFields(split(text[trailing[0].start:trailing[0].end]))
+		if isData {
+			node.Data = fields
+			node.InnerErrors = parser.parseInnerErrors(split(text[trailing[1].start:trailing[1].end]))
+			node.Message = parser.plain(text[:trailing[0].start-len(tokenOpen)])
+			return node
+		}
+		trailing = trailing[1:]
+		fallthrough
+	case 1:
+		var items = split(text[trailing[0].start:trailing[0].end])
+		var fields, isData = parser.parseFields(items)
+		if isData && len(items) > 0 {
+			node.Data = fields
+		} else {
+			node.InnerErrors = parser.parseInnerErrors(items)
+		}
+		node.Message = parser.plain(text[:trailing[0].start-len(tokenOpen)])
+	default:
+		node.Message = parser.plain(text)
+	}
+	return node
+}
@@ -0,0 +1,192 @@
+package errortext_test
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	apperror "github.com/zhongjie-cai/app-error"
+	. "github.com/zhongjie-cai/app-error/internal/errortext"
+)
+
+func TestParse_NotAppError(t *testing.T) {
+	// arrange
+	var texts = []string{"", "plain", "() empty", "(Not Found) spaced", "(NotFound)glued", "(NotFound", "(notFound) lower"}
+
+	for _, text := range texts {
+		// act
+		var node, err = Parse(text)
+
+		// assert
+		assert.Nil(t, node, text)
+		assert.Equal(t, ErrNotAppError, err, text)
+	}
+}
+
+func TestParse_MessageOnly(t *testing.T) {
+	// act
+	var node, err = Parse("(NotFound) Requested resource is not found")
+
+	// assert
+	assert.NoError(t, err)
+	assert.Equal(t, &Node{Code: "NotFound", Message: "Requested resource is not found"}, node)
+}
+
+func TestParse_RoundTripsAppErrors(t *testing.T) {
+	// arrange
+	var inner = apperror.NewBaseAppError(apperror.CodeGeneralFailure, "boom")
+	inner.Wrap(errors.New("disk full"), errors.New("retry | later"))
+	var outer = apperror.NewBaseAppError(apperror.CodeNotFound, "user %v not found", 5)
+	outer.Attach("id", 5)
+	outer.Wrap(inner, errors.New("plain"))
+
+	// act
+	var node, err = Parse(outer.Error())
+
+	// assert
+	assert.NoError(t, err)
+	assert.Equal(t, &Node{
+		Code:    "NotFound",
+		Message: "user 5 not found",
+		Data:    []Field{{Key: "id", Value: "5"}},
+		InnerErrors: []*Node{
+			{
+				Code:    "GeneralFailure",
+				Message: "boom",
+				InnerErrors: []*Node{
+					{Message: "disk full"},
+					{Message: "retry"},
+					{Message: "later"},
+				},
+			},
+			{Message: "plain"},
+		},
+	}, node)
+}
+
+func TestParse_DataOnlyAndInnerOnly(t *testing.T) {
+	// act
+	var dataNode, _ = Parse("(BadRequest) bad [ a = 1 | b = [1 2] ]")
+	var innerNode, _ = Parse("(BadRequest) bad [ (NotFound) x [ a = 1 ] | other ]")
+	var emptyNode, _ = Parse("(BadRequest) bad [  ]")
+
+	// assert
+	assert.Equal(t, []Field{{Key: "a", Value: "1"}, {Key: "b", Value: "[1 2]"}}, dataNode.Data)
+	assert.Empty(t, dataNode.InnerErrors)
+	assert.Empty(t, innerNode.Data)
+	assert.Equal(t, []*Node{
+		{Code: "NotFound", Message: "x", Data: []Field{{Key: "a", Value: "1"}}},
+		{Message: "other"},
+	}, innerNode.InnerErrors)
+	assert.Equal(t, "bad", emptyNode.Message)
+	assert.Empty(t, emptyNode.InnerErrors)
+}
+
+func TestParse_TwoGroupsWithoutData(t *testing.T) {
+	// act
+	var node, _ = Parse("(BadRequest) see [ note ] [ inner ]")
+
+	// assert
+	assert.Equal(t, "see [ note ]", node.Message)
+	assert.Equal(t, []*Node{{Message: "inner"}}, node.InnerErrors)
+}
+
+func TestFind(t *testing.T) {
+	// act
+	var prefix, node, isFound = Find("2024-01-02 ERROR (handler) failed: (NotFound) missing [ id = 5 ]")
+	var line, missing, isMissing = Find("2024-01-02 INFO all good")
+
+	// assert
+	assert.True(t, isFound)
+	assert.Equal(t, "2024-01-02 ERROR (handler) failed: ", prefix)
+	assert.Equal(t, "NotFound", node.Code)
+	assert.False(t, isMissing)
+	assert.Nil(t, missing)
+	assert.Equal(t, "2024-01-02 INFO all good", line)
+}
+
+func TestNode_HasCode(t *testing.T) {
+	// arrange
+	var node, _ = Parse("(NotFound) x [ (GeneralFailure) y [ (BadRequest) z ] ]")
+
+	// assert
+	assert.True(t, node.HasCode("NotFound"))
+	assert.True(t, node.HasCode("BadRequest"))
+	assert.False(t, node.HasCode("Unauthorized"))
+}
+
+func TestEscape(t *testing.T) {
+	// assert
+	assert.Equal(t, "plain text", Escape("plain text"))
+	assert.Equal(t, `a \| b \= \[c\] \(d\) \\`, Escape(`a | b = [c] (d) \`))
+}
+
+func TestParse_Escaped(t *testing.T) {
+	// arrange
+	var text = "(NotFound) " + Escape("user [5] | gone = yes") +
+		" [ " + Escape("k|ey") + " = " + Escape("v ] [ x") + " ]" +
+		" [ (GeneralFailure) " + Escape("a | b") + " | " + Escape("(Plain) = text") + " ]"
+
+	// act
+	var node, err = Parse(text)
+
+	// assert
+	assert.NoError(t, err)
+	assert.Equal(t, &Node{
+		Code:    "NotFound",
+		Message: "user [5] | gone = yes",
+		Data:    []Field{{Key: "k|ey", Value: "v ] [ x"}},
+		InnerErrors: []*Node{
+			{Code: "GeneralFailure", Message: "a | b"},
+			{Message: "(Plain) = text"},
+		},
+	}, node)
+}
+
+func TestParse_EscapedNameWithSpaces(t *testing.T) {
+	// arrange
+	var text = "(NotFound) some message [ user id = 5 | " + Escape("full [name]") + " = " + Escape("a = b") + " ] [ some cause ]"
+
+	// act
+	var node, err = Parse(text)
+
+	// assert
+	assert.NoError(t, err)
+	assert.Equal(t, &Node{
+		Code:        "NotFound",
+		Message:     "some message",
+		Data:        []Field{{Key: "user id", Value: "5"}, {Key: "full [name]", Value: "a = b"}},
+		InnerErrors: []*Node{{Message: "some cause"}},
+	}, node)
+}
+
+func TestParse_LegacyFallback(t *testing.T) {
+	// act
+	var invalidEscape, _ = Parse(`(BadRequest) path C:\dir [ a = 1 ]`)
+	var unescapedSpecial, _ = Parse("(BadRequest) failed (retry 3) [ a = [1 2] ]")
+
+	// assert
+	assert.Equal(t, `path C:\dir`, invalidEscape.Message)
+	assert.Equal(t, []Field{{Key: "a", Value: "1"}}, invalidEscape.Data)
+	assert.Equal(t, "failed (retry 3)", unescapedSpecial.Message)
+	assert.Equal(t, []Field{{Key: "a", Value: "[1 2]"}}, unescapedSpecial.Data)
+}
+
+type dummySelfEscaper bool
+
+func (escaper dummySelfEscaper) Error() string {
+	return "some error"
+}
+
+func (escaper dummySelfEscaper) EscapesOwnText() bool {
+	return bool(escaper)
+}
+
+func TestEscapesOwnText(t *testing.T) {
+	// assert
+	assert.False(t, EscapesOwnText(nil))
+	assert.False(t, EscapesOwnText(errors.New("some error")))
+	assert.False(t, EscapesOwnText(dummySelfEscaper(false)))
+	assert.True(t, EscapesOwnText(dummySelfEscaper(true)))
+	assert.True(t, EscapesOwnText(apperror.NewBaseAppError(apperror.CodeNotFound, "some error")))
+}
@@ -0,0 +1,71 @@
+package apperror
+
+import (
+	"log/slog"
+)
+
+// LogValue implements slog.LogValuer: the app error is logged as a group of its code, message, instance ID, responsible component (see ResponsibleComponent), sequence, creation time when captured, extra data and inner error texts; it leaves out Error(), which a type embedding *BaseAppError may override
+func (baseAppError *BaseAppError) LogValue() slog.Value {
+	if baseAppError == nil {
+		return slog.StringValue(nilErrorText)
+	}
+	var attrs = []slog.Attr{
+		slog.String("code", baseAppError.Code()),
+		slog.String("message", baseAppError.Message()),
+	}
+	if instanceID := baseAppError.InstanceID(); instanceID != "" {
+		attrs = append(
+			attrs,
+			slog.String("instanceId", instanceID),
+		)
+	}
+	if component := ResponsibleComponent(baseAppError); component != "" {
+		attrs = append(
+			attrs,
+			slog.String("component", component),
+		)
+	}
+	if baseAppError.getDetails().sequence != 0 {
+		attrs = append(
+			attrs,
+			slog.Uint64("sequence", baseAppError.getDetails().sequence),
+		)
+	}
+	if !baseAppError.getDetails().createdAt.IsZero() {
+		attrs = append(
+			attrs,
+			slog.Time("createdAt", baseAppError.getDetails().createdAt),
+		)
+	}
+	var dataAttrs []slog.Attr
+	baseAppError.Attrs(func(attr Attr) bool {
+		dataAttrs = append(
+			dataAttrs,
+			slog.Any(attr.Key, attr.Value.Any()),
+		)
+		return true
+	})
+	if len(dataAttrs) > 0 {
+		attrs = append(
+			attrs,
+			slog.Attr{Key: "data", Value: slog.GroupValue(dataAttrs...)},
+		)
+	}
+	var innerErrors []string
+	for _, innerError := range baseAppError.innerErrors {
+		if innerError == nil {
+			continue
+		}
+		innerErrors = append(
+			innerErrors,
+			getErrorMessageFunc(innerError),
+		)
+	}
+	if len(innerErrors) > 0 {
+		attrs = append(
+			attrs,
+			slog.Any("innerErrors", innerErrors),
+		)
+	}
+	return slog.GroupValue(attrs...)
+}
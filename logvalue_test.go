@@ -0,0 +1,88 @@
+package apperror
+
+import (
+	"bytes"
+	"errors"
+	"log/slog"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func newTestLogger(buffer *bytes.Buffer) *slog.Logger {
+	return slog.New(slog.NewTextHandler(buffer, &slog.HandlerOptions{
+		ReplaceAttr: func(groups []string, attr slog.Attr) slog.Attr {
+			if attr.Key == slog.TimeKey && len(groups) == 0 {
+				return slog.Attr{}
+			}
+			return attr
+		},
+	}))
+}
+
+func TestLogValue_Nil(t *testing.T) {
+	// arrange
+	var sut *BaseAppError
+
+	// act
+	var result = sut.LogValue()
+
+	// assert
+	assert.Equal(t, slog.StringValue("<nil>"), result)
+}
+
+func TestLogValue_Plain(t *testing.T) {
+	// arrange
+	var buffer bytes.Buffer
+	var sut = &BaseAppError{
+		error:       errors.New("some message"),
+		code:        CodeNotFound,
+		innerErrors: []error{errors.New("some inner error")},
+	}
+
+	// act
+	newTestLogger(&buffer).Error("failed", "error", sut)
+
+	// assert
+	assert.Equal(t, `level=ERROR msg=failed error.code=NotFound error.message="some message" error.innerErrors="[some inner error]"`+"\n", buffer.String())
+}
+
+func TestLogValue_Stamped(t *testing.T) {
+	// arrange
+	var buffer bytes.Buffer
+	var sut = &BaseAppError{
+		error:     errors.New("some message"),
+		code:      CodeBadRequest,
+		extraData: map[string]interface{}{},
+		attrs:     []Attr{String("name", "foo")},
+		details: &errorDetails{
+			createdAt: time.Date(2026, 10, 18, 1, 2, 3, 0, time.UTC),
+			sequence:  42,
+		},
+	}
+	var dummyInstanceID = "some instance id"
+	sut.getCache().instanceID.Store(&dummyInstanceID)
+
+	// act
+	newTestLogger(&buffer).Error("failed", "error", sut)
+
+	// assert
+	assert.Equal(t, `level=ERROR msg=failed error.code=BadRequest error.message="some message" error.instanceId="some instance id" error.sequence=42 error.createdAt=2026-10-18T01:02:03.000Z error.data.name=foo`+"\n", buffer.String())
+}
+
+func TestLogValue_EmbedderOverridingError(t *testing.T) {
+	// arrange
+	var buffer bytes.Buffer
+	var sut = detailedAppError{&BaseAppError{
+		error: errors.New("some message"),
+		code:  CodeNotFound,
+	}}
+
+	// act
+	newTestLogger(&buffer).Error("failed", "error", sut)
+
+	// assert
+	assert.Equal(t, `level=ERROR msg=failed error.code=NotFound error.message="some message"`+"\n", buffer.String())
+	assert.NotContains(t, buffer.String(), "(NotFound) some message")
+}
@@ -0,0 +1,41 @@
+package apperror
+
+// Matcher decides whether err, an app error or one of its (nested) inner errors, matches the target given to ContainsMatch
+type Matcher func(err, target error) bool
+
+// MatchIdentity matches only the very same error value
+func MatchIdentity(err, target error) bool {
+	return err == target
+}
+
+// MatchErrorsIs matches through errors.Is, including Code sentinels and errors wrapped with %w; this is the strict default used by Contains
+func MatchErrorsIs(err, target error) bool {
+	return errorsIs(err, target)
+}
+
+// MatchCode matches any app error having the same Code as the target app error
+func MatchCode(err, target error) bool {
+	var typedError, isTypedError = err.(AppError)
+	var typedTarget, isTypedTarget = target.(AppError)
+	return isTypedError &&
+		isTypedTarget &&
+		typedError.Code() == typedTarget.Code()
+}
+
+// MatchMessageTemplate matches any app error created with the same Code and message format as the target app error, regardless of its parameters and extra data
+func MatchMessageTemplate(err, target error) bool {
+	var typedError, isTypedError = err.(*BaseAppError)
+	var typedTarget, isTypedTarget = target.(*BaseAppError)
+	return isTypedError &&
+		isTypedTarget &&
+		typedError.code == typedTarget.code &&
+		typedError.messageFormat == typedTarget.messageFormat
+}
+
+// MatchLegacy matches errors with identical Error() messages, as Contains used to do; prefer a stricter matcher, since unrelated errors may share a message
+func MatchLegacy(err, target error) bool {
+	return equalsErrorFunc(
+		err,
+		target,
+	)
+}
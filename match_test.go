@@ -0,0 +1,176 @@
+package apperror
+
+import (
+	"errors"
+	"fmt"
+	"math/rand"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestMatchIdentity(t *testing.T) {
+	// arrange
+	var dummyError = errors.New("some error")
+
+	// mock
+	createMock(t)
+
+	// SUT + act + assert
+	assert.True(t, MatchIdentity(dummyError, dummyError))
+	assert.False(t, MatchIdentity(dummyError, errors.New("some error")))
+
+	// verify
+	verifyAll(t)
+}
+
+func TestMatchErrorsIs(t *testing.T) {
+	// arrange
+	var dummyError = errors.New("some error")
+	var dummyTarget = errors.New("some target")
+	var dummyResult = rand.Intn(100) > 50
+
+	// mock
+	createMock(t)
+
+	// expect
+	errorsIsExpected = 1
+	errorsIs = func(err, target error) bool {
+		errorsIsCalled++
+		assert.Equal(t, dummyError, err)
+		assert.Equal(t, dummyTarget, target)
+		return dummyResult
+	}
+
+	// SUT + act
+	var result = MatchErrorsIs(
+		dummyError,
+		dummyTarget,
+	)
+
+	// assert
+	assert.Equal(t, dummyResult, result)
+
+	// verify
+	verifyAll(t)
+}
+
+func TestMatchCode(t *testing.T) {
+	// mock
+	createMock(t)
+
+	// SUT + act + assert
+	assert.True(t, MatchCode(&BaseAppError{code: CodeNotFound}, &BaseAppError{code: CodeNotFound}))
+	assert.False(t, MatchCode(&BaseAppError{code: CodeNotFound}, &BaseAppError{code: CodeBadRequest}))
+	assert.False(t, MatchCode(errors.New("NotFound"), &BaseAppError{code: CodeNotFound}))
+	assert.False(t, MatchCode(&BaseAppError{code: CodeNotFound}, errors.New("NotFound")))
+
+	// verify
+	verifyAll(t)
+}
+
+func TestMatchMessageTemplate(t *testing.T) {
+	// arrange
+	var dummyFormat = "some format %v"
+
+	// mock
+	createMock(t)
+
+	// SUT + act + assert
+	assert.True(t, MatchMessageTemplate(
+		&BaseAppError{code: CodeNotFound, messageFormat: dummyFormat, error: errors.New("some format 1")},
+		&BaseAppError{code: CodeNotFound, messageFormat: dummyFormat, error: errors.New("some format 2")},
+	))
+	assert.False(t, MatchMessageTemplate(
+		&BaseAppError{code: CodeNotFound, messageFormat: dummyFormat},
+		&BaseAppError{code: CodeBadRequest, messageFormat: dummyFormat},
+	))
+	assert.False(t, MatchMessageTemplate(
+		&BaseAppError{code: CodeNotFound, messageFormat: dummyFormat},
+		&BaseAppError{code: CodeNotFound, messageFormat: "some other format"},
+	))
+	assert.False(t, MatchMessageTemplate(errors.New("some error"), &BaseAppError{}))
+	assert.False(t, MatchMessageTemplate(&BaseAppError{}, errors.New("some error")))
+
+	// verify
+	verifyAll(t)
+}
+
+func TestMatchLegacy(t *testing.T) {
+	// arrange
+	var dummyError = errors.New("some error")
+	var dummyTarget = errors.New("some target")
+	var dummyResult = rand.Intn(100) > 50
+
+	// mock
+	createMock(t)
+
+	// expect
+	equalsErrorFuncExpected = 1
+	equalsErrorFunc = func(err, target error) bool {
+		equalsErrorFuncCalled++
+		assert.Equal(t, dummyError, err)
+		assert.Equal(t, dummyTarget, target)
+		return dummyResult
+	}
+
+	// SUT + act
+	var result = MatchLegacy(
+		dummyError,
+		dummyTarget,
+	)
+
+	// assert
+	assert.Equal(t, dummyResult, result)
+
+	// verify
+	verifyAll(t)
+}
+
+func TestContainsMatch_Strategies(t *testing.T) {
+	// arrange
+	var timeoutError = errors.New("timeout")
+	var notFoundError = NewBaseAppError(CodeNotFound, "Item %v is not found", 1)
+	var sut = GetGeneralFailureError(
+		timeoutError,
+		GetBadRequestError(notFoundError),
+		NewBaseAppError(CodeDataCorruption, "Lookup failed: %w", ErrNotImplemented),
+	).(*BaseAppError)
+	sut.Attach("some name", "some value")
+
+	// act + assert
+	assert.True(t, sut.Contains(timeoutError))
+	assert.False(t, sut.Contains(errors.New("timeout")))
+	assert.True(t, sut.ContainsMatch(errors.New("timeout"), MatchLegacy))
+	assert.True(t, sut.Contains(ErrNotFound))
+	assert.True(t, sut.Contains(ErrNotImplemented))
+	assert.True(t, sut.ContainsMatch(notFoundError, MatchIdentity))
+	assert.False(t, sut.ContainsMatch(ErrNotFound, MatchIdentity))
+	assert.True(t, sut.ContainsMatch(GetBadRequestError(), MatchCode))
+	assert.False(t, sut.ContainsMatch(GetUnauthorized(), MatchCode))
+	assert.True(t, sut.ContainsMatch(NewBaseAppError(CodeNotFound, "Item %v is not found", 2), MatchMessageTemplate))
+	assert.False(t, sut.ContainsMatch(NewBaseAppError(CodeNotFound, "Item is gone"), MatchMessageTemplate))
+	assert.True(t, sut.ContainsMatch(fmt.Errorf("some wrapper"), func(err, target error) bool {
+		var appError, isAppError = err.(AppError)
+		return isAppError && appError.HTTPStatusCode() == 409
+	}))
+}
+
+func TestMatchMessageTemplate_DerivedErrors(t *testing.T) {
+	// arrange
+	var template = NewBaseAppError(CodeNotFound, "user %v not found", 0)
+	var sut = NewBaseAppError(CodeNotFound, "user %v not found", 42)
+
+	// act
+	var derivedErrors = []error{
+		sut.With("id", 42),
+		sut.WithCause(errors.New("some cause")),
+		sut.WithComponent("some component"),
+	}
+
+	// assert
+	assert.True(t, MatchMessageTemplate(sut, template))
+	for _, derivedError := range derivedErrors {
+		assert.True(t, MatchMessageTemplate(derivedError, template))
+	}
+}
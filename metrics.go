@@ -0,0 +1,90 @@
+package apperror
+
+import (
+	"sync/atomic"
+)
+
+// MetricsEventKind tells at which point of its lifecycle an app error is reported to the metrics hook
+type MetricsEventKind int
+
+// These are the metrics event kinds
+const (
+	// MetricsEventCreated is reported when NewBaseAppError (or any helper built on it) or the v2 Builder creates an app error; Parse does not report the errors it reconstructs
+	MetricsEventCreated MetricsEventKind = iota
+	// MetricsEventRendered is reported when WriteProblem renders an app error to a client
+	MetricsEventRendered
+)
+
+// String returns the lower case name of the event kind, suitable as a metrics label
+func (kind MetricsEventKind) String() string {
+	switch kind {
+	case MetricsEventCreated:
+		return "created"
+	case MetricsEventRendered:
+		return "rendered"
+	}
+	return "unknown"
+}
+
+// MetricsEvent is what the metrics hook receives
+type MetricsEvent struct {
+	Kind           MetricsEventKind
+	Code           Code
+	HTTPStatusCode int
+	// Component is the component ultimately responsible for a rendered app error (see ResponsibleComponent); it is empty for created ones, which are not attributed yet
+	Component string
+}
+
+// MetricsHook receives the metrics events of all app errors; it must be safe for concurrent use and fast, as it runs inline
+type MetricsHook func(event MetricsEvent)
+
+var metricsHook atomic.Pointer[MetricsHook]
+
+// SetMetricsHook installs the metrics hook, replacing any previous one; pass nil to remove it
+func SetMetricsHook(hook MetricsHook) {
+	if hook == nil {
+		metricsHook.Store(nil)
+		return
+	}
+	metricsHook.Store(&hook)
+}
+
+func emitMetricsEvent(kind MetricsEventKind, code Code, httpStatusCode int, component string) {
+	var hook = metricsHook.Load()
+	if hook == nil {
+		return
+	}
+	(*hook)(
+		MetricsEvent{
+			Kind:           kind,
+			Code:           code,
+			HTTPStatusCode: httpStatusCode,
+			Component:      component,
+		},
+	)
+}
+
+// EmitMetricsEvent reports the given event to the metrics hook, so that app errors of other implementations, such as those built by the v2 Builder, are counted alongside the v1 ones
+func EmitMetricsEvent(event MetricsEvent) {
+	emitMetricsEventFunc(
+		event.Kind,
+		event.Code,
+		event.HTTPStatusCode,
+		event.Component,
+	)
+}
+
+// codeEnumer is implemented by app errors exposing their code enum, such as *BaseAppError
+type codeEnumer interface {
+	CodeEnum() Code
+}
+
+func getCodeEnum(appError AppError) Code {
+	if typedError, isTyped := appError.(codeEnumer); isTyped {
+		return typedError.CodeEnum()
+	}
+	var code, _ = parseCodeFunc(
+		appError.Code(),
+	)
+	return code
+}
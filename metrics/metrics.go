@@ -0,0 +1,208 @@
+// Package metrics counts app errors by event, code, HTTP status, severity and responsible component, and exposes the counts in the Prometheus text exposition format and through expvar.
+//
+// Install a registry as the app error metrics hook once at startup:
+//
+//	var registry = metrics.NewRegistry()
+//	registry.Install()
+//	http.Handle("/metrics", registry.Handler())
+//	registry.Publish("apperror")
+//
+// Label cardinality is bounded: codes not registered in the v2 registry are counted under the "Unknown" code, and components not allowed through AllowComponents under the "other" component.
+package metrics
+
+import (
+	"expvar"
+	"fmt"
+	"io"
+	"net/http"
+	"sort"
+	"strconv"
+	"strings"
+	"sync"
+	"sync/atomic"
+
+	v1 "github.com/zhongjie-cai/app-error"
+	apperror "github.com/zhongjie-cai/app-error/v2"
+)
+
+// These are the exposition related constants
+const (
+	metricName        = "apperror_errors_total"
+	metricHelp        = "Number of app errors by event, code, HTTP status, severity and responsible component."
+	contentType       = "text/plain; version=0.0.4; charset=utf-8"
+	unknownCode       = "Unknown"
+	unknownSeverity   = "unknown"
+	otherComponent    = "other"
+	labelEvent        = "event"
+	labelCode         = "code"
+	labelStatus       = "status"
+	labelSeverity     = "severity"
+	labelComponent    = "component"
+	exportedSeparator = "|"
+)
+
+// Labels identify a single counter
+type Labels struct {
+	Event     string
+	Code      string
+	Status    int
+	Severity  string
+	Component string
+}
+
+// Registry holds the counters; it is safe for concurrent use
+type Registry struct {
+	counters   sync.Map // Labels -> *atomic.Uint64
+	components sync.Map // string -> struct{}
+}
+
+// NewRegistry creates an empty registry
+func NewRegistry() *Registry {
+	return &Registry{}
+}
+
+// Install sets the registry as the app error metrics hook, replacing any previous hook
+func (registry *Registry) Install() {
+	v1.SetMetricsHook(registry.Observe)
+}
+
+// AllowComponents adds the given components to the ones counted under their own name; the responsible components of rendered errors are free-form, so any component not allowed is counted as "other"
+func (registry *Registry) AllowComponents(components ...string) {
+	for _, component := range components {
+		registry.components.Store(component, struct{}{})
+	}
+}
+
+func (registry *Registry) boundComponent(component string) string {
+	if component == "" {
+		return ""
+	}
+	if _, isAllowed := registry.components.Load(component); isAllowed {
+		return component
+	}
+	return otherComponent
+}
+
+// Observe counts the given app error metrics event
+func (registry *Registry) Observe(event v1.MetricsEvent) {
+	var labels = Labels{
+		Event:     event.Kind.String(),
+		Code:      unknownCode,
+		Status:    event.HTTPStatusCode,
+		Severity:  unknownSeverity,
+		Component: registry.boundComponent(event.Component),
+	}
+	if definition, isRegistered := apperror.Lookup(event.Code); isRegistered {
+		labels.Code = definition.Name
+		labels.Severity = definition.Severity
+	}
+	var counter, isLoaded = registry.counters.Load(labels)
+	if !isLoaded {
+		counter, _ = registry.counters.LoadOrStore(labels, new(atomic.Uint64))
+	}
+	counter.(*atomic.Uint64).Add(1)
+}
+
+// Count returns the current value of the counter of the given labels
+func (registry *Registry) Count(labels Labels) uint64 {
+	var counter, isLoaded = registry.counters.Load(labels)
+	if !isLoaded {
+		return 0
+	}
+	return counter.(*atomic.Uint64).Load()
+}
+
+// sample is a counter value with its labels
+type sample struct {
+	labels Labels
+	value  uint64
+}
+
+// snapshot returns the counters ordered by event, code, status, severity and component
+func (registry *Registry) snapshot() []sample {
+	var samples []sample
+	registry.counters.Range(func(key, value interface{}) bool {
+		samples = append(samples, sample{labels: key.(Labels), value: value.(*atomic.Uint64).Load()})
+		return true
+	})
+	sort.Slice(samples, func(i, j int) bool {
+		var left, right = samples[i].labels, samples[j].labels
+		if left.Event != right.Event {
+			return left.Event < right.Event
+		}
+		if left.Code != right.Code {
+			return left.Code < right.Code
+		}
+		if left.Status != right.Status {
+			return left.Status < right.Status
+		}
+		if left.Severity != right.Severity {
+			return left.Severity < right.Severity
+		}
+		return left.Component < right.Component
+	})
+	return samples
+}
+
+func escapeLabel(value string) string {
+	return strings.NewReplacer("\\", "\\\\", "\"", "\\\"", "\n", "\\n").Replace(value)
+}
+
+// WriteText writes the counters to the given writer in the Prometheus text exposition format, stopping at the first write error
+func (registry *Registry) WriteText(writer io.Writer) error {
+	var _, err = fmt.Fprintf(writer, "# HELP %s %s\n# TYPE %s counter\n", metricName, metricHelp, metricName)
+	if err != nil {
+		return err
+	}
+	for _, sample := range registry.snapshot() {
+		_, err = fmt.Fprintf(
+			writer,
+			"%s{%s=\"%s\",%s=\"%s\",%s=\"%d\",%s=\"%s\",%s=\"%s\"} %d\n",
+			metricName,
+			labelEvent, escapeLabel(sample.labels.Event),
+			labelCode, escapeLabel(sample.labels.Code),
+			labelStatus, sample.labels.Status,
+			labelSeverity, escapeLabel(sample.labels.Severity),
+			labelComponent, escapeLabel(sample.labels.Component),
+			sample.value,
+		)
+		if err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// Handler serves the counters in the Prometheus text exposition format
+func (registry *Registry) Handler() http.Handler {
+	return http.HandlerFunc(func(responseWriter http.ResponseWriter, request *http.Request) {
+		responseWriter.Header().Set("Content-Type", contentType)
+		registry.WriteText(responseWriter)
+	})
+}
+
+// Vars returns the counters keyed by "event|code|status|severity|component", as published through expvar
+func (registry *Registry) Vars() map[string]uint64 {
+	var vars = map[string]uint64{}
+	for _, sample := range registry.snapshot() {
+		var key = strings.Join(
+			[]string{
+				sample.labels.Event,
+				sample.labels.Code,
+				strconv.Itoa(sample.labels.Status),
+				sample.labels.Severity,
+				sample.labels.Component,
+			},
+			exportedSeparator,
+		)
+		vars[key] = sample.value
+	}
+	return vars
+}
+
+// Publish exposes the counters through expvar under the given name; like expvar.Publish, it panics if the name is already in use
+func (registry *Registry) Publish(name string) {
+	expvar.Publish(name, expvar.Func(func() interface{} {
+		return registry.Vars()
+	}))
+}
@@ -0,0 +1,156 @@
+package metrics
+
+import (
+	"encoding/json"
+	"errors"
+	"expvar"
+	"net/http"
+	"net/http/httptest"
+	"strconv"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	v1 "github.com/zhongjie-cai/app-error"
+	apperror "github.com/zhongjie-cai/app-error/v2"
+)
+
+// testRuns counts the runs of the tests using the process-global code registry and expvar names, which cannot be undone, so that each run uses its own codes and names
+var testRuns int
+
+func TestRegistry_Observe(t *testing.T) {
+	// arrange
+	var registry = NewRegistry()
+
+	// act
+	registry.Observe(v1.MetricsEvent{Kind: v1.MetricsEventCreated, Code: v1.CodeNotFound, HTTPStatusCode: 404})
+	registry.Observe(v1.MetricsEvent{Kind: v1.MetricsEventCreated, Code: v1.CodeNotFound, HTTPStatusCode: 404})
+	registry.Observe(v1.MetricsEvent{Kind: v1.MetricsEventCreated, Code: v1.Code(123456), HTTPStatusCode: 500})
+
+	// assert
+	assert.Equal(t, uint64(2), registry.Count(Labels{Event: "created", Code: "NotFound", Status: 404, Severity: "error"}))
+	assert.Equal(t, uint64(1), registry.Count(Labels{Event: "created", Code: "Unknown", Status: 500, Severity: "unknown"}))
+	assert.Zero(t, registry.Count(Labels{Event: "rendered"}))
+}
+
+func TestRegistry_Observe_BoundedComponents(t *testing.T) {
+	// arrange
+	var registry = NewRegistry()
+	registry.AllowComponents("postgres", "partner-api")
+
+	// act
+	registry.Observe(v1.MetricsEvent{Kind: v1.MetricsEventRendered, Code: v1.CodeNotFound, HTTPStatusCode: 404, Component: "postgres"})
+	registry.Observe(v1.MetricsEvent{Kind: v1.MetricsEventRendered, Code: v1.CodeNotFound, HTTPStatusCode: 404, Component: "user-supplied-1"})
+	registry.Observe(v1.MetricsEvent{Kind: v1.MetricsEventRendered, Code: v1.CodeNotFound, HTTPStatusCode: 404, Component: "user-supplied-2"})
+	registry.Observe(v1.MetricsEvent{Kind: v1.MetricsEventRendered, Code: v1.CodeNotFound, HTTPStatusCode: 404})
+
+	// assert
+	assert.Equal(t, uint64(1), registry.Count(Labels{Event: "rendered", Code: "NotFound", Status: 404, Severity: "error", Component: "postgres"}))
+	assert.Equal(t, uint64(2), registry.Count(Labels{Event: "rendered", Code: "NotFound", Status: 404, Severity: "error", Component: "other"}))
+	assert.Equal(t, uint64(1), registry.Count(Labels{Event: "rendered", Code: "NotFound", Status: 404, Severity: "error"}))
+	assert.Zero(t, registry.Count(Labels{Event: "rendered", Code: "NotFound", Status: 404, Severity: "error", Component: "user-supplied-1"}))
+}
+
+func TestRegistry_Install(t *testing.T) {
+	// arrange
+	var registry = NewRegistry()
+	registry.AllowComponents("partner-api")
+	registry.Install()
+	defer v1.SetMetricsHook(nil)
+	testRuns++
+	var dummyCode = apperror.Code(4100 + testRuns)
+	var dummyName = "MetricsQuota" + strconv.Itoa(testRuns)
+	apperror.MustRegister(apperror.Definition{Code: dummyCode, Name: dummyName, HTTPStatusCode: 429, Severity: "warning"})
+
+	// act
+	var appError = v1.NewBaseAppError(dummyCode, "quota")
+	v1.WriteProblem(httptest.NewRecorder(), v1.GetBadRequestError(v1.FromComponent("partner-api", errors.New("some error"))))
+
+	// assert
+	assert.NotNil(t, appError)
+	assert.Equal(t, uint64(1), registry.Count(Labels{Event: "created", Code: dummyName, Status: 429, Severity: "warning"}))
+	assert.Equal(t, uint64(1), registry.Count(Labels{Event: "created", Code: "BadRequest", Status: 400, Severity: "error"}))
+	assert.Equal(t, uint64(1), registry.Count(Labels{Event: "rendered", Code: "BadRequest", Status: 400, Severity: "error", Component: "partner-api"}))
+}
+
+func TestRegistry_Handler(t *testing.T) {
+	// arrange
+	var registry = NewRegistry()
+	registry.AllowComponents("postgres")
+	registry.Observe(v1.MetricsEvent{Kind: v1.MetricsEventRendered, Code: v1.CodeNotFound, HTTPStatusCode: 404, Component: "postgres"})
+	registry.Observe(v1.MetricsEvent{Kind: v1.MetricsEventCreated, Code: v1.CodeBadRequest, HTTPStatusCode: 400})
+	var recorder = httptest.NewRecorder()
+
+	// act
+	registry.Handler().ServeHTTP(recorder, httptest.NewRequest(http.MethodGet, "/metrics", nil))
+
+	// assert
+	assert.Equal(t, "text/plain; version=0.0.4; charset=utf-8", recorder.Header().Get("Content-Type"))
+	assert.Equal(t, `# HELP apperror_errors_total Number of app errors by event, code, HTTP status, severity and responsible component.
+# TYPE apperror_errors_total counter
+apperror_errors_total{event="created",code="BadRequest",status="400",severity="error",component=""} 1
+apperror_errors_total{event="rendered",code="NotFound",status="404",severity="error",component="postgres"} 1
+`, recorder.Body.String())
+}
+
+type failingWriter struct {
+	writes int
+}
+
+func (writer *failingWriter) Write(data []byte) (int, error) {
+	writer.writes++
+	return 0, errors.New("some write error")
+}
+
+func TestRegistry_WriteText(t *testing.T) {
+	// arrange
+	var registry = NewRegistry()
+	registry.Observe(v1.MetricsEvent{Kind: v1.MetricsEventCreated, Code: v1.CodeNotFound, HTTPStatusCode: 404})
+	var builder strings.Builder
+
+	// act
+	var err = registry.WriteText(&builder)
+
+	// assert
+	assert.NoError(t, err)
+	assert.Equal(t, `# HELP apperror_errors_total Number of app errors by event, code, HTTP status, severity and responsible component.
+# TYPE apperror_errors_total counter
+apperror_errors_total{event="created",code="NotFound",status="404",severity="error",component=""} 1
+`, builder.String())
+}
+
+func TestRegistry_WriteText_WriteError(t *testing.T) {
+	// arrange
+	var registry = NewRegistry()
+	registry.Observe(v1.MetricsEvent{Kind: v1.MetricsEventCreated, Code: v1.CodeNotFound, HTTPStatusCode: 404})
+	var writer = &failingWriter{}
+
+	// act
+	var err = registry.WriteText(writer)
+
+	// assert
+	assert.EqualError(t, err, "some write error")
+	assert.Equal(t, 1, writer.writes)
+}
+
+func TestEscapeLabel(t *testing.T) {
+	// assert
+	assert.Equal(t, `a\\b\"c\nd`, escapeLabel("a\\b\"c\nd"))
+}
+
+func TestRegistry_Publish(t *testing.T) {
+	// arrange
+	var registry = NewRegistry()
+	registry.Observe(v1.MetricsEvent{Kind: v1.MetricsEventCreated, Code: v1.CodeNotFound, HTTPStatusCode: 404})
+	var vars map[string]uint64
+	testRuns++
+	var dummyName = "apperror_test" + strconv.Itoa(testRuns)
+
+	// act
+	registry.Publish(dummyName)
+
+	// assert
+	assert.NoError(t, json.NewDecoder(strings.NewReader(expvar.Get(dummyName).String())).Decode(&vars))
+	assert.Equal(t, map[string]uint64{"created|NotFound|404|error|": 1}, vars)
+	assert.Panics(t, func() { registry.Publish(dummyName) })
+}
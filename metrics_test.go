@@ -0,0 +1,129 @@
+package apperror
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestMetricsEventKind_String(t *testing.T) {
+	// assert
+	assert.Equal(t, "created", MetricsEventCreated.String())
+	assert.Equal(t, "rendered", MetricsEventRendered.String())
+	assert.Equal(t, "unknown", MetricsEventKind(99).String())
+}
+
+func TestEmitMetricsEvent_NoHook(t *testing.T) {
+	// mock
+	createMock(t)
+
+	// SUT + act
+	SetMetricsHook(nil)
+	emitMetricsEvent(MetricsEventCreated, CodeNotFound, http.StatusNotFound, "")
+
+	// verify
+	verifyAll(t)
+}
+
+func TestEmitMetricsEvent_WithHook(t *testing.T) {
+	// arrange
+	var events []MetricsEvent
+
+	// mock
+	createMock(t)
+
+	// SUT + act
+	SetMetricsHook(func(event MetricsEvent) {
+		events = append(events, event)
+	})
+	emitMetricsEvent(MetricsEventRendered, CodeNotFound, http.StatusNotFound, "some component")
+	SetMetricsHook(nil)
+	emitMetricsEvent(MetricsEventCreated, CodeNotFound, http.StatusNotFound, "")
+
+	// assert
+	assert.Equal(t, []MetricsEvent{{Kind: MetricsEventRendered, Code: CodeNotFound, HTTPStatusCode: http.StatusNotFound, Component: "some component"}}, events)
+
+	// verify
+	verifyAll(t)
+}
+
+func TestEmitMetricsEvent_Exported(t *testing.T) {
+	// arrange
+	var dummyEvent = MetricsEvent{Kind: MetricsEventCreated, Code: CodeNotFound, HTTPStatusCode: http.StatusNotFound, Component: "some component"}
+
+	// mock
+	createMock(t)
+
+	// expect
+	emitMetricsEventFuncExpected = 1
+	emitMetricsEventFunc = func(kind MetricsEventKind, code Code, httpStatusCode int, component string) {
+		emitMetricsEventFuncCalled++
+		assert.Equal(t, dummyEvent, MetricsEvent{Kind: kind, Code: code, HTTPStatusCode: httpStatusCode, Component: component})
+	}
+
+	// SUT + act
+	EmitMetricsEvent(dummyEvent)
+
+	// verify
+	verifyAll(t)
+}
+
+func TestGetCodeEnum_CodeEnumer(t *testing.T) {
+	// mock
+	createMock(t)
+
+	// SUT + act
+	var result = getCodeEnum(&BaseAppError{code: CodeOperationLock})
+
+	// assert
+	assert.Equal(t, CodeOperationLock, result)
+
+	// verify
+	verifyAll(t)
+}
+
+func TestGetCodeEnum_ByName(t *testing.T) {
+	// arrange
+	var dummyAppError = dummyCodedError{code: CodeNotFound}
+
+	// mock
+	createMock(t)
+
+	// expect
+	parseCodeFuncExpected = 1
+	parseCodeFunc = func(name string) (Code, bool) {
+		parseCodeFuncCalled++
+		assert.Equal(t, "NotFound", name)
+		return CodeNotFound, true
+	}
+
+	// SUT + act
+	var result = getCodeEnum(dummyAppError)
+
+	// assert
+	assert.Equal(t, CodeNotFound, result)
+
+	// verify
+	verifyAll(t)
+}
+
+func TestMetricsHook_Lifecycle(t *testing.T) {
+	// arrange
+	var events []MetricsEvent
+	SetMetricsHook(func(event MetricsEvent) {
+		events = append(events, event)
+	})
+	defer SetMetricsHook(nil)
+
+	// act
+	var appError = GetNotFoundError()
+	WriteProblem(httptest.NewRecorder(), appError)
+
+	// assert
+	assert.Equal(t, []MetricsEvent{
+		{Kind: MetricsEventCreated, Code: CodeNotFound, HTTPStatusCode: http.StatusNotFound},
+		{Kind: MetricsEventRendered, Code: CodeNotFound, HTTPStatusCode: http.StatusNotFound},
+	}, events)
+}
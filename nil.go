@@ -0,0 +1,23 @@
+package apperror
+
+import (
+	"reflect"
+)
+
+// nilErrorText is what a nil *BaseAppError prints, the same as fmt prints a nil error
+const nilErrorText = "<nil>"
+
+// IsNil reports whether err is nil, including a nil pointer such as a nil *BaseAppError held by a non-nil error interface
+func IsNil(err error) bool {
+	if err == nil {
+		return true
+	}
+	var value = reflectValueOf(
+		err,
+	)
+	switch value.Kind() {
+	case reflect.Ptr, reflect.Map, reflect.Slice, reflect.Func, reflect.Chan, reflect.Interface:
+		return value.IsNil()
+	}
+	return false
+}
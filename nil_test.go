@@ -0,0 +1,238 @@
+package apperror
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"net/http/httptest"
+	"reflect"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestIsNil_Nil(t *testing.T) {
+	// mock
+	createMock(t)
+
+	// SUT + act
+	var result = IsNil(nil)
+
+	// assert
+	assert.True(t, result)
+
+	// verify
+	verifyAll(t)
+}
+
+func TestIsNil_TypedNil(t *testing.T) {
+	// arrange
+	var dummyAppError *BaseAppError
+
+	// mock
+	createMock(t)
+
+	// expect
+	reflectValueOfExpected = 1
+	reflectValueOf = func(i interface{}) reflect.Value {
+		reflectValueOfCalled++
+		assert.Equal(t, error(dummyAppError), i)
+		return reflect.ValueOf(i)
+	}
+
+	// SUT + act
+	var result = IsNil(dummyAppError)
+
+	// assert
+	assert.True(t, result)
+
+	// verify
+	verifyAll(t)
+}
+
+func TestIsNil_NonNil(t *testing.T) {
+	// arrange
+	var dummyErrors = []error{
+		errors.New("some error"),
+		&BaseAppError{},
+		FieldViolation{},
+	}
+
+	// mock
+	createMock(t)
+
+	// expect
+	reflectValueOfExpected = len(dummyErrors)
+	reflectValueOf = func(i interface{}) reflect.Value {
+		reflectValueOfCalled++
+		return reflect.ValueOf(i)
+	}
+
+	for _, dummyError := range dummyErrors {
+		// SUT + act
+		var result = IsNil(dummyError)
+
+		// assert
+		assert.False(t, result)
+	}
+
+	// verify
+	verifyAll(t)
+}
+
+func TestBaseAppError_NilReceiver(t *testing.T) {
+	// arrange
+	var dummyError = errors.New("some error")
+	var matcherCalled = 0
+
+	// mock
+	createMock(t)
+
+	// SUT
+	var sut *BaseAppError
+
+	// act
+	sut.Wrap(dummyError)
+	sut.Attach("some name", "some value")
+	sut.AttachAttrs(String("some key", "some value"))
+	sut.Attrs(func(Attr) bool {
+		matcherCalled++
+		return true
+	})
+
+	// assert
+	assert.Equal(t, nilErrorText, sut.Error())
+	assert.Equal(t, "GeneralFailure", sut.Code())
+	assert.Equal(t, CodeGeneralFailure, sut.CodeEnum())
+	assert.Equal(t, "", sut.Message())
+	assert.Nil(t, sut.InnerErrors())
+	assert.Equal(t, 500, sut.HTTPStatusCode())
+	assert.False(t, sut.Contains(dummyError))
+	assert.False(t, sut.ContainsMatch(dummyError, func(err, target error) bool {
+		matcherCalled++
+		return true
+	}))
+	assert.Nil(t, sut.Freeze())
+	assert.False(t, sut.IsFrozen())
+	assert.Nil(t, sut.With("some name", "some value"))
+	assert.Nil(t, sut.WithCause(dummyError))
+	assert.False(t, sut.Is(ErrGeneralFailure))
+	var marshalled, marshalError = sut.MarshalJSON()
+	assert.Equal(t, "null", string(marshalled))
+	assert.NoError(t, marshalError)
+	assert.Zero(t, matcherCalled)
+
+	// verify
+	verifyAll(t)
+}
+
+func TestBaseAppError_ZeroValue(t *testing.T) {
+	// arrange
+	var buffer bytes.Buffer
+	var recorder = httptest.NewRecorder()
+
+	// SUT
+	var sut = &BaseAppError{}
+
+	// act
+	var marshalled, marshalError = sut.MarshalJSON()
+	newTestLogger(&buffer).Error("failed", "error", sut)
+	WriteProblem(recorder, sut)
+
+	// assert
+	assert.Equal(t, "(GeneralFailure) <nil>", sut.Error())
+	assert.Equal(t, nilErrorText, sut.Message())
+	assert.False(t, sut.Contains(errors.New("some error")))
+	assert.JSONEq(t, `{"code": "GeneralFailure", "message": "<nil>"}`, string(marshalled))
+	assert.NoError(t, marshalError)
+	assert.Contains(t, buffer.String(), `error.message=<nil>`)
+	assert.Contains(t, fmt.Sprintf("%#v", sut), `message:"<nil>"`)
+	assert.Equal(t, "(GeneralFailure) <nil>", sut.Detail())
+	assert.Equal(t, 500, recorder.Code)
+}
+
+func TestBaseAppError_NilReceiverFormat(t *testing.T) {
+	// SUT
+	var sut *BaseAppError
+
+	// act
+	var result = fmt.Sprintf("%v|%#v|%s", sut, sut, sut) + "|" + sut.Detail()
+
+	// assert
+	assert.Equal(t, "<nil>|<nil>|<nil>|<nil>", result)
+}
+
+func TestBaseAppError_IsTypedNilTarget(t *testing.T) {
+	// arrange
+	var dummyTarget *BaseAppError
+
+	// mock
+	createMock(t)
+
+	// SUT
+	var sut = &BaseAppError{}
+
+	// act
+	var result = sut.Is(dummyTarget)
+
+	// assert
+	assert.False(t, result)
+
+	// verify
+	verifyAll(t)
+}
+
+func TestCleanupInnerErrors_TypedNil(t *testing.T) {
+	// arrange
+	var dummyAppError *BaseAppError
+	var dummyError = errors.New("some error")
+
+	// SUT + act
+	var result = cleanupInnerErrors(
+		[]error{dummyAppError, dummyError},
+	)
+
+	// assert
+	assert.Equal(t, []error{dummyError}, result)
+}
+
+func TestNewProblem_Nil(t *testing.T) {
+	// arrange
+	var dummyAppError *BaseAppError
+
+	// SUT + act
+	var untyped = NewProblem(nil)
+	var typed = NewProblem(dummyAppError)
+
+	// assert
+	assert.Equal(t, Problem{Type: "about:blank", Title: "Internal Server Error", Status: 500, Code: "GeneralFailure"}, untyped)
+	assert.Equal(t, untyped, typed)
+}
+
+func TestWriteProblem_Nil(t *testing.T) {
+	// arrange
+	var dummyAppError *BaseAppError
+	var recorder = httptest.NewRecorder()
+
+	// mock
+	createMock(t)
+
+	// expect
+	isNilFuncExpected = 1
+	isNilFunc = func(err error) bool {
+		isNilFuncCalled++
+		assert.Equal(t, error(dummyAppError), err)
+		return true
+	}
+
+	// SUT + act
+	var err = WriteProblem(recorder, dummyAppError)
+
+	// assert
+	assert.NoError(t, err)
+	assert.False(t, recorder.Flushed)
+	assert.Empty(t, recorder.Body.String())
+
+	// verify
+	verifyAll(t)
+}
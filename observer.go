@@ -0,0 +1,129 @@
+package apperror
+
+import (
+	"sync"
+	"sync/atomic"
+)
+
+// LifecycleEventKind tells at which point of its lifecycle an app error is reported to the observers
+type LifecycleEventKind int
+
+// These are the lifecycle event kinds
+const (
+	// LifecycleEventCreated is reported when NewBaseAppError (or any helper built on it) creates an app error
+	LifecycleEventCreated LifecycleEventKind = iota
+	// LifecycleEventWrapped is reported when Wrap adds inner errors to an app error
+	LifecycleEventWrapped
+	// LifecycleEventAttached is reported when Attach or AttachAttrs adds extra data to an app error, once per name
+	LifecycleEventAttached
+	// LifecycleEventRendered is reported when WriteProblem renders an app error to a client
+	LifecycleEventRendered
+)
+
+// String returns the lower case name of the event kind
+func (kind LifecycleEventKind) String() string {
+	switch kind {
+	case LifecycleEventCreated:
+		return "created"
+	case LifecycleEventWrapped:
+		return "wrapped"
+	case LifecycleEventAttached:
+		return "attached"
+	case LifecycleEventRendered:
+		return "rendered"
+	}
+	return "unknown"
+}
+
+// LifecycleEvent is what the observers receive; only the fields relevant to the kind are set
+type LifecycleEvent struct {
+	Kind LifecycleEventKind
+	// Error is the app error the event is about
+	Error AppError
+	// InnerErrors are the inner errors just added, for LifecycleEventWrapped
+	InnerErrors []error
+	// Attr is the extra data just added, for LifecycleEventAttached
+	Attr Attr
+	// HTTPStatusCode is the status code written to the client, for LifecycleEventRendered
+	HTTPStatusCode int
+}
+
+// Observer receives the lifecycle events of all app errors; it runs inline on the goroutine causing the event, so it must be safe for concurrent use and fast, and must not retain InnerErrors beyond the call
+type Observer func(event LifecycleEvent)
+
+type observerEntry struct {
+	observer Observer
+}
+
+// ObserverHandle unregisters an observer registered with RegisterObserver
+type ObserverHandle struct {
+	entry *observerEntry
+}
+
+var (
+	observersLock sync.Mutex
+	observers     atomic.Pointer[[]*observerEntry]
+)
+
+// RegisterObserver adds the observer after all the already registered ones; observers are called in registration order, and a panicking observer is recovered without affecting the other observers or the caller
+func RegisterObserver(observer Observer) *ObserverHandle {
+	var entry = &observerEntry{
+		observer: observer,
+	}
+	observersLock.Lock()
+	defer observersLock.Unlock()
+	var entries = []*observerEntry{}
+	if current := observers.Load(); current != nil {
+		entries = append(entries, *current...)
+	}
+	entries = append(entries, entry)
+	observers.Store(&entries)
+	return &ObserverHandle{
+		entry: entry,
+	}
+}
+
+// Unregister removes the observer; it may be called more than once, and events already being delivered may still reach the observer
+func (handle *ObserverHandle) Unregister() {
+	if handle == nil {
+		return
+	}
+	observersLock.Lock()
+	defer observersLock.Unlock()
+	var current = observers.Load()
+	if current == nil {
+		return
+	}
+	var entries = []*observerEntry{}
+	for _, entry := range *current {
+		if entry != handle.entry {
+			entries = append(entries, entry)
+		}
+	}
+	if len(entries) == 0 {
+		observers.Store(nil)
+		return
+	}
+	observers.Store(&entries)
+}
+
+func callObserver(observer Observer, event LifecycleEvent) {
+	defer func() {
+		// a failing observer must not affect the others or the caller
+		_ = recover()
+	}()
+	observer(event)
+}
+
+func notifyObservers(event LifecycleEvent) {
+	var current = observers.Load()
+	if current == nil {
+		return
+	}
+	for _, entry := range *current {
+		callObserverFunc(
+			entry.observer,
+			event,
+		)
+	}
+}
@@ -0,0 +1,155 @@
+package apperror
+
+import (
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestLifecycleEventKind_String(t *testing.T) {
+	// assert
+	assert.Equal(t, "created", LifecycleEventCreated.String())
+	assert.Equal(t, "wrapped", LifecycleEventWrapped.String())
+	assert.Equal(t, "attached", LifecycleEventAttached.String())
+	assert.Equal(t, "rendered", LifecycleEventRendered.String())
+	assert.Equal(t, "unknown", LifecycleEventKind(99).String())
+}
+
+func TestCallObserver_Panic(t *testing.T) {
+	// arrange
+	var observerCalled int
+
+	// mock
+	createMock(t)
+
+	// SUT + act
+	assert.NotPanics(t, func() {
+		callObserver(
+			func(event LifecycleEvent) {
+				observerCalled++
+				panic("some panic")
+			},
+			LifecycleEvent{},
+		)
+	})
+
+	// assert
+	assert.Equal(t, 1, observerCalled)
+
+	// verify
+	verifyAll(t)
+}
+
+func TestNotifyObservers_NoObservers(t *testing.T) {
+	// mock
+	createMock(t)
+
+	// SUT + act
+	notifyObservers(LifecycleEvent{Kind: LifecycleEventCreated})
+
+	// verify
+	verifyAll(t)
+}
+
+func TestNotifyObservers_Ordering(t *testing.T) {
+	// arrange
+	var dummyEvent = LifecycleEvent{Kind: LifecycleEventAttached, Attr: String("some key", "some value")}
+	var calls []string
+
+	// mock
+	createMock(t)
+
+	// expect
+	callObserverFuncExpected = 3
+	callObserverFunc = func(observer Observer, event LifecycleEvent) {
+		callObserverFuncCalled++
+		assert.Equal(t, dummyEvent, event)
+		observer(event)
+	}
+
+	// SUT
+	var handle1 = RegisterObserver(func(event LifecycleEvent) { calls = append(calls, "1") })
+	var handle2 = RegisterObserver(func(event LifecycleEvent) { calls = append(calls, "2") })
+	var handle3 = RegisterObserver(func(event LifecycleEvent) { calls = append(calls, "3") })
+
+	// act
+	notifyObservers(dummyEvent)
+	handle2.Unregister()
+	handle2.Unregister()
+	handle1.Unregister()
+	handle3.Unregister()
+	handle3.Unregister()
+	notifyObservers(dummyEvent)
+
+	// assert
+	assert.Equal(t, []string{"1", "2", "3"}, calls)
+	assert.Nil(t, observers.Load())
+
+	// verify
+	verifyAll(t)
+}
+
+func TestObserverHandle_Unregister_Nil(t *testing.T) {
+	// arrange
+	var handle *ObserverHandle
+
+	// assert
+	assert.NotPanics(t, handle.Unregister)
+}
+
+func TestNotifyObservers_NoAllocationWithoutObservers(t *testing.T) {
+	// arrange
+	var sut = NewBaseAppError(CodeNotFound, "some message")
+	var dummyInnerErrors = []error{errors.New("some inner error")}
+
+	// act
+	var allocations = testing.AllocsPerRun(100, func() {
+		notifyObservers(
+			LifecycleEvent{
+				Kind:        LifecycleEventWrapped,
+				Error:       sut,
+				InnerErrors: dummyInnerErrors,
+			},
+		)
+	})
+
+	// assert
+	assert.Zero(t, allocations)
+}
+
+func TestObservers_Lifecycle(t *testing.T) {
+	// arrange
+	var dummyInnerError = errors.New("some inner error")
+	var kinds []LifecycleEventKind
+	var panicking = RegisterObserver(func(event LifecycleEvent) {
+		panic("some panic")
+	})
+	defer panicking.Unregister()
+	var recording = RegisterObserver(func(event LifecycleEvent) {
+		kinds = append(kinds, event.Kind)
+	})
+	defer recording.Unregister()
+
+	// act
+	var appError = NewBaseAppError(CodeNotFound, "some message")
+	appError.Wrap(dummyInnerError)
+	appError.Wrap(nil)
+	appError.Attach("some name", "some value")
+	appError.AttachAttrs(Int("some int", 1), Bool("some bool", true))
+	var recorder = httptest.NewRecorder()
+	WriteProblem(recorder, appError)
+
+	// assert
+	assert.Equal(t, http.StatusNotFound, recorder.Code)
+	assert.Equal(t, []LifecycleEventKind{
+		LifecycleEventCreated,
+		LifecycleEventWrapped,
+		LifecycleEventAttached,
+		LifecycleEventAttached,
+		LifecycleEventAttached,
+		LifecycleEventRendered,
+	}, kinds)
+}
@@ -0,0 +1,90 @@
+package apperror
+
+import (
+	"errors"
+
+	"github.com/zhongjie-cai/app-error/internal/errortext"
+)
+
+// ErrParse is wrapped by the errors returned from Parse
+var ErrParse = errors.New("apperror: text is not a parsable app error")
+
+func parseCode(name string) (Code, bool) {
+	for code := CodeGeneralFailure; code < codeMaxCount; code++ {
+		if code.String() == name {
+			return code, true
+		}
+	}
+	if code, isRegistered := customCodeNames.Load(name); isRegistered {
+		return code.(Code), true
+	}
+	return 0, false
+}
+
+// convertNode builds the app error directly rather than through NewBaseAppError, Attach and Wrap, so that parsed errors are not reported as created errors to metrics or observers; they keep the instance ID printed in the text, if any
+func convertNode(node *errortext.Node) *BaseAppError {
+	var code, isKnown = parseCodeFunc(
+		node.Code,
+	)
+	if !isKnown {
+		// codes neither built-in nor registered, e.g. printed as "Unknown", fall back rather than failing the whole tree
+		code = CodeGeneralFailure
+	}
+	var baseAppError = &BaseAppError{
+		code:          code,
+		messageFormat: node.Message,
+	}
+	setMessageFunc(
+		baseAppError,
+		"%s",
+		[]interface{}{
+			node.Message,
+		},
+	)
+	for _, field := range node.Data {
+		if field.Key == InstanceIDDataName {
+			var instanceID = field.Value
+			baseAppError.getCache().instanceID.Store(&instanceID)
+			continue
+		}
+		if baseAppError.extraData == nil {
+			baseAppError.extraData = make(map[string]interface{}, len(node.Data))
+		}
+		baseAppError.extraData[field.Key] = field.Value
+	}
+	for _, innerNode := range node.InnerErrors {
+		if innerNode.Code == "" {
+			baseAppError.innerErrors = append(
+				baseAppError.innerErrors,
+				errorsNew(
+					innerNode.Message,
+				),
+			)
+			continue
+		}
+		baseAppError.innerErrors = append(
+			baseAppError.innerErrors,
+			convertNode(
+				innerNode,
+			),
+		)
+	}
+	return baseAppError
+}
+
+// Parse reconstructs an app error from the text of its Error(), unambiguously in the escaped print mode and on a best-effort basis otherwise
+func Parse(text string) (AppError, error) {
+	var node, err = errortextParse(
+		text,
+	)
+	if err != nil {
+		return nil, fmtErrorf(
+			"%w: %v",
+			ErrParse,
+			err,
+		)
+	}
+	return convertNodeFunc(
+		node,
+	), nil
+}
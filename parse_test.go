@@ -0,0 +1,291 @@
+package apperror
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/zhongjie-cai/app-error/internal/errortext"
+)
+
+func TestParseCode_Known(t *testing.T) {
+	// mock
+	createMock(t)
+
+	for code := CodeGeneralFailure; code < codeMaxCount; code++ {
+		// SUT + act
+		var result, isKnown = parseCode(code.String())
+
+		// assert
+		assert.True(t, isKnown)
+		assert.Equal(t, code, result)
+	}
+
+	// verify
+	verifyAll(t)
+}
+
+func TestParseCode_Unknown(t *testing.T) {
+	// mock
+	createMock(t)
+
+	// SUT + act
+	var result, isKnown = parseCode("Unknown")
+
+	// assert
+	assert.False(t, isKnown)
+	assert.Zero(t, result)
+
+	// verify
+	verifyAll(t)
+}
+
+func TestConvertNode_UnknownCode(t *testing.T) {
+	// arrange
+	var dummyNode = &errortext.Node{Code: "SomeCode", Message: "some message"}
+	var dummyError = errors.New("some message")
+
+	// mock
+	createMock(t)
+
+	// expect
+	parseCodeFuncExpected = 1
+	parseCodeFunc = func(name string) (Code, bool) {
+		parseCodeFuncCalled++
+		assert.Equal(t, "SomeCode", name)
+		return 0, false
+	}
+	setMessageFuncExpected = 1
+	setMessageFunc = func(baseAppError *BaseAppError, messageFormat string, parameters []interface{}) {
+		setMessageFuncCalled++
+		assert.Equal(t, "%s", messageFormat)
+		assert.Equal(t, []interface{}{"some message"}, parameters)
+		baseAppError.error = dummyError
+	}
+
+	// SUT + act
+	var result = convertNode(dummyNode)
+
+	// assert
+	assert.Equal(t, CodeGeneralFailure, result.code)
+	assert.Equal(t, dummyError, result.error)
+	assert.Nil(t, result.extraData)
+	assert.Nil(t, result.innerErrors)
+
+	// verify
+	verifyAll(t)
+}
+
+func TestConvertNode_Tree(t *testing.T) {
+	// arrange
+	var dummyNode = &errortext.Node{
+		Code:    "NotFound",
+		Message: "some message",
+		Data:    []errortext.Field{{Key: "id", Value: "5"}},
+		InnerErrors: []*errortext.Node{
+			{Code: "BadRequest", Message: "some inner message"},
+			{Message: "some plain message"},
+		},
+	}
+	var dummyPlainError = errors.New("some plain message")
+	var dummyOuterError = errors.New("some message")
+	var dummyInnerError = errors.New("some inner message")
+
+	// mock
+	createMock(t)
+
+	// expect
+	parseCodeFuncExpected = 2
+	parseCodeFunc = func(name string) (Code, bool) {
+		parseCodeFuncCalled++
+		return parseCode(name)
+	}
+	setMessageFuncExpected = 2
+	setMessageFunc = func(baseAppError *BaseAppError, messageFormat string, parameters []interface{}) {
+		setMessageFuncCalled++
+		assert.Equal(t, "%s", messageFormat)
+		if setMessageFuncCalled == 1 {
+			assert.Equal(t, []interface{}{"some message"}, parameters)
+			baseAppError.error = dummyOuterError
+			return
+		}
+		assert.Equal(t, []interface{}{"some inner message"}, parameters)
+		baseAppError.error = dummyInnerError
+	}
+	errorsNewExpected = 1
+	errorsNew = func(text string) error {
+		errorsNewCalled++
+		assert.Equal(t, "some plain message", text)
+		return dummyPlainError
+	}
+
+	// SUT + act
+	var result = convertNode(dummyNode)
+
+	// assert
+	assert.Equal(t, CodeNotFound, result.code)
+	assert.Equal(t, dummyOuterError, result.error)
+	assert.Equal(t, "some message", result.messageFormat)
+	assert.Equal(t, map[string]interface{}{"id": "5"}, result.extraData)
+	assert.Empty(t, result.InstanceID())
+	assert.Len(t, result.innerErrors, 2)
+	var innerAppError = result.innerErrors[0].(*BaseAppError)
+	assert.Equal(t, CodeBadRequest, innerAppError.code)
+	assert.Equal(t, dummyInnerError, innerAppError.error)
+	assert.Nil(t, innerAppError.innerErrors)
+	assert.Equal(t, dummyPlainError, result.innerErrors[1])
+
+	// verify
+	verifyAll(t)
+}
+
+func TestParse_NoLifecycleEvents(t *testing.T) {
+	// arrange
+	var events []LifecycleEvent
+	var handle = RegisterObserver(func(event LifecycleEvent) {
+		events = append(events, event)
+	})
+	defer handle.Unregister()
+	var metricsEvents []MetricsEvent
+	SetMetricsHook(func(event MetricsEvent) {
+		metricsEvents = append(metricsEvents, event)
+	})
+	defer SetMetricsHook(nil)
+
+	// SUT + act
+	var result, err = Parse("(NotFound) some message [ id = 5 ] [ (BadRequest) some inner message | some plain message ]")
+
+	// assert
+	assert.NoError(t, err)
+	assert.Equal(t, "(NotFound) some message [ id = 5 ] [ (BadRequest) some inner message | some plain message ]", result.Error())
+	assert.Empty(t, result.(*BaseAppError).InstanceID())
+	assert.Empty(t, events)
+	assert.Empty(t, metricsEvents)
+}
+
+func TestParse_TextError(t *testing.T) {
+	// arrange
+	var dummyText = "some text"
+	var dummyParseError = errors.New("some parse error")
+	var dummyError = errors.New("some error")
+
+	// mock
+	createMock(t)
+
+	// expect
+	errortextParseExpected = 1
+	errortextParse = func(text string) (*errortext.Node, error) {
+		errortextParseCalled++
+		assert.Equal(t, dummyText, text)
+		return nil, dummyParseError
+	}
+	fmtErrorfExpected = 1
+	fmtErrorf = func(format string, a ...interface{}) error {
+		fmtErrorfCalled++
+		assert.Equal(t, "%w: %v", format)
+		assert.Equal(t, []interface{}{ErrParse, dummyParseError}, a)
+		return dummyError
+	}
+
+	// SUT + act
+	var result, err = Parse(dummyText)
+
+	// assert
+	assert.Nil(t, result)
+	assert.Equal(t, dummyError, err)
+
+	// verify
+	verifyAll(t)
+}
+
+func TestParse_Success(t *testing.T) {
+	// arrange
+	var dummyNode = &errortext.Node{}
+	var dummyAppError = &BaseAppError{}
+
+	// mock
+	createMock(t)
+
+	// expect
+	errortextParseExpected = 1
+	errortextParse = func(text string) (*errortext.Node, error) {
+		errortextParseCalled++
+		return dummyNode, nil
+	}
+	convertNodeFuncExpected = 1
+	convertNodeFunc = func(node *errortext.Node) *BaseAppError {
+		convertNodeFuncCalled++
+		assert.Equal(t, dummyNode, node)
+		return dummyAppError
+	}
+
+	// SUT + act
+	var result, err = Parse("some text")
+
+	// assert
+	assert.NoError(t, err)
+	assert.Same(t, dummyAppError, result)
+
+	// verify
+	verifyAll(t)
+}
+
+func TestParse_RoundTrip(t *testing.T) {
+	// arrange
+	var inner = NewBaseAppError(CodeGeneralFailure, "disk failed")
+	inner.Wrap(errors.New("no space left"))
+	var outer = NewBaseAppError(CodeNotFound, "user %v not found", 5)
+	outer.Attach("id", 5)
+	outer.Wrap(inner, errors.New("plain"))
+
+	// SUT + act
+	var result, err = Parse(outer.Error())
+
+	// assert
+	assert.NoError(t, err)
+	assert.Equal(t, outer.Error(), result.Error())
+	assert.True(t, errors.Is(result, ErrNotFound))
+	assert.True(t, errors.Is(result, ErrGeneralFailure))
+	assert.Equal(t, "5", result.(*BaseAppError).extraData["id"])
+}
+
+func TestParse_RoundTripEscapedNameWithSpaces(t *testing.T) {
+	// arrange
+	SetPrintMode(PrintModeEscaped)
+	defer SetPrintMode(PrintModeLegacy)
+	var sut = NewBaseAppError(CodeNotFound, "some message")
+	sut.Attach("full [name]", "a = b")
+	sut.Wrap(errors.New("some cause"))
+
+	// act
+	var result, err = Parse(sut.Error())
+
+	// assert
+	assert.NoError(t, err)
+	assert.Equal(t, map[string]interface{}{"full [name]": "a = b"}, result.(*BaseAppError).extraData)
+	assert.Len(t, result.(*BaseAppError).innerErrors, 1)
+	assert.Equal(t, sut.Error(), result.Error())
+}
+
+func TestParse_Invalid(t *testing.T) {
+	// SUT + act
+	var notAppError, notAppErrorErr = Parse("plain text")
+
+	// assert
+	assert.Nil(t, notAppError)
+	assert.ErrorIs(t, notAppErrorErr, ErrParse)
+}
+
+func TestParse_UnknownCodes(t *testing.T) {
+	// SUT + act
+	var root, rootErr = Parse("(Unknown) some message")
+	var nested, nestedErr = Parse("(NotFound) some message [ (Unknown) some custom message [ id = 5 ] | some plain message ]")
+
+	// assert
+	assert.NoError(t, rootErr)
+	assert.Equal(t, "(GeneralFailure) some message", root.Error())
+	assert.NoError(t, nestedErr)
+	assert.Equal(t, "(NotFound) some message [ (GeneralFailure) some custom message [ id = 5 ] | some plain message ]", nested.Error())
+	assert.True(t, errors.Is(nested, ErrNotFound))
+	assert.True(t, errors.Is(nested, ErrGeneralFailure))
+}
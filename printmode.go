@@ -0,0 +1,44 @@
+package apperror
+
+import (
+	"sync/atomic"
+)
+
+// PrintMode selects how Error() prints the messages, extra data and inner errors of app errors
+type PrintMode int32
+
+// These are the supported print modes
+const (
+	// PrintModeLegacy prints messages, extra data and inner errors as they are; this is the default
+	PrintModeLegacy PrintMode = iota
+	// PrintModeEscaped prefixes backslashes, brackets, parentheses, pipes and equal signs inside messages, extra data names and values and non app inner errors with a backslash, so the output can be split reliably and parsed back by Parse
+	PrintModeEscaped
+)
+
+var printMode atomic.Int32
+
+// SetPrintMode selects the print mode used by Error() of all app errors
+func SetPrintMode(mode PrintMode) {
+	printMode.Store(int32(mode))
+}
+
+// CurrentPrintMode returns the print mode used by Error() of all app errors
+func CurrentPrintMode() PrintMode {
+	return PrintMode(printMode.Load())
+}
+
+func escapedPrintMode() bool {
+	return CurrentPrintMode() == PrintModeEscaped
+}
+
+var printInstanceID atomic.Bool
+
+// SetPrintInstanceID selects whether Error() of all app errors prints their instance IDs as the last extra data entry, under InstanceIDDataName; this is off by default, so the text of otherwise identical errors stays the same
+func SetPrintInstanceID(enabled bool) {
+	printInstanceID.Store(enabled)
+}
+
+// CurrentPrintInstanceID returns whether Error() of all app errors prints their instance IDs
+func CurrentPrintInstanceID() bool {
+	return printInstanceID.Load()
+}
@@ -0,0 +1,200 @@
+package apperror
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestSetPrintMode(t *testing.T) {
+	// mock
+	createMock(t)
+
+	// SUT + act
+	SetPrintMode(PrintModeEscaped)
+	var escapedMode = CurrentPrintMode()
+	var isEscaped = escapedPrintMode()
+	SetPrintMode(PrintModeLegacy)
+	var legacyMode = CurrentPrintMode()
+	var isLegacyEscaped = escapedPrintMode()
+
+	// assert
+	assert.Equal(t, PrintModeEscaped, escapedMode)
+	assert.True(t, isEscaped)
+	assert.Equal(t, PrintModeLegacy, legacyMode)
+	assert.False(t, isLegacyEscaped)
+
+	// verify
+	verifyAll(t)
+}
+
+func TestSetPrintInstanceID(t *testing.T) {
+	// mock
+	createMock(t)
+
+	// SUT + act
+	SetPrintInstanceID(true)
+	var enabled = CurrentPrintInstanceID()
+	SetPrintInstanceID(false)
+	var disabled = CurrentPrintInstanceID()
+
+	// assert
+	assert.True(t, enabled)
+	assert.False(t, disabled)
+
+	// verify
+	verifyAll(t)
+}
+
+func TestFormatExtraData_Escaped(t *testing.T) {
+	// arrange
+	var dummyExtraData = map[string]interface{}{
+		"some name": 123,
+	}
+	SetPrintMode(PrintModeEscaped)
+	defer SetPrintMode(PrintModeLegacy)
+
+	// mock
+	createMock(t)
+
+	// expect
+	errortextEscapeExpected = 2
+	errortextEscape = func(text string) string {
+		errortextEscapeCalled++
+		return "<" + text + ">"
+	}
+	formatExtraDataValueFuncExpected = 1
+	formatExtraDataValueFunc = func(value interface{}) string {
+		formatExtraDataValueFuncCalled++
+		assert.Equal(t, 123, value)
+		return "123"
+	}
+
+	// SUT + act
+	var result = formatExtraData(
+		dummyExtraData,
+	)
+
+	// assert
+	assert.Equal(t, " [ <some name> = <123> ]", result)
+
+	// verify
+	verifyAll(t)
+}
+
+func TestBaseAppError_PrintError_Escaped(t *testing.T) {
+	// arrange
+	var dummyError = errors.New("some error")
+	SetPrintMode(PrintModeEscaped)
+	defer SetPrintMode(PrintModeLegacy)
+
+	// mock
+	createMock(t)
+
+	// expect
+	getErrorMessageFuncExpected = 1
+	getErrorMessageFunc = func(err error) string {
+		getErrorMessageFuncCalled++
+		assert.Equal(t, dummyError, err)
+		return "some message"
+	}
+	errortextEscapeExpected = 1
+	errortextEscape = func(text string) string {
+		errortextEscapeCalled++
+		assert.Equal(t, "some message", text)
+		return "some escaped message"
+	}
+
+	// SUT
+	var sut = &BaseAppError{}
+
+	// act
+	var result = sut.PrintError(
+		CodeNotFound,
+		dummyError,
+		nil,
+	)
+
+	// assert
+	assert.Equal(t, "(NotFound) some escaped message", result)
+
+	// verify
+	verifyAll(t)
+}
+
+type selfEscapingError struct{}
+
+func (selfEscapingError) Error() string {
+	return "some self escaping message"
+}
+
+func (selfEscapingError) EscapesOwnText() bool {
+	return true
+}
+
+func TestPrintInnerErrors_Escaped(t *testing.T) {
+	// arrange
+	var dummyPlainError = errors.New("some plain error")
+	var dummyAppError = &BaseAppError{}
+	SetPrintMode(PrintModeEscaped)
+	defer SetPrintMode(PrintModeLegacy)
+
+	// mock
+	createMock(t)
+
+	// expect
+	getErrorMessageFuncExpected = 3
+	getErrorMessageFunc = func(err error) string {
+		getErrorMessageFuncCalled++
+		if err == dummyPlainError {
+			return "some plain message"
+		}
+		if err == dummyAppError {
+			return "some app message"
+		}
+		return err.Error()
+	}
+	errortextEscapeExpected = 1
+	errortextEscape = func(text string) string {
+		errortextEscapeCalled++
+		assert.Equal(t, "some plain message", text)
+		return "some escaped message"
+	}
+
+	// SUT + act
+	var result = printInnerErrors(
+		[]error{dummyPlainError, nil, dummyAppError, selfEscapingError{}},
+	)
+
+	// assert
+	assert.Equal(t, " [ some escaped message | some app message | some self escaping message ]", result)
+
+	// verify
+	verifyAll(t)
+}
+
+func TestPrintModeEscaped_RoundTrip(t *testing.T) {
+	// arrange
+	SetPrintMode(PrintModeEscaped)
+	defer SetPrintMode(PrintModeLegacy)
+	var inner = NewBaseAppError(CodeGeneralFailure, "a | b ] [ c")
+	inner.Wrap(errors.New("x = y (z)"))
+	var outer = NewBaseAppError(CodeBadRequest, "bad [input]")
+	outer.Attach("path|name", "C:\\dir | file")
+	outer.Wrap(inner)
+
+	// act
+	var text = outer.Error()
+	var result, err = Parse(text)
+
+	// assert
+	assert.Equal(t, `(BadRequest) bad \[input\] [ path\|name = C:\\dir \| file ] [ (GeneralFailure) a \| b \] \[ c [ x \= y \(z\) ] ]`, text)
+	assert.NoError(t, err)
+	assert.Equal(t, "bad [input]", result.(*BaseAppError).Message())
+	assert.Equal(t, "C:\\dir | file", result.(*BaseAppError).extraData["path|name"])
+	var parsedInner = result.(*BaseAppError).innerErrors[0].(*BaseAppError)
+	assert.Equal(t, "a | b ] [ c", parsedInner.Message())
+	assert.Equal(t, "x = y (z)", parsedInner.innerErrors[0].Error())
+	assert.Equal(t, text, result.Error())
+}
@@ -0,0 +1,201 @@
+package apperror
+
+import (
+	"encoding/json"
+	"net/http"
+	"time"
+)
+
+// contentTypeProblemJSON is the media type for RFC 7807 problem details
+const contentTypeProblemJSON string = "application/problem+json"
+
+type appErrorJSON struct {
+	Code        string                 `json:"code"`
+	Message     string                 `json:"message"`
+	Data        map[string]interface{} `json:"data,omitempty"`
+	InnerErrors []interface{}          `json:"innerErrors,omitempty"`
+	Violations  []FieldViolation       `json:"violations,omitempty"`
+	Sequence    uint64                 `json:"sequence,omitempty"`
+	CreatedAt   *time.Time             `json:"createdAt,omitempty"`
+	InstanceID  string                 `json:"instanceId,omitempty"`
+	Component   string                 `json:"component,omitempty"`
+}
+
+type innerErrorJSON struct {
+	Message string `json:"message"`
+}
+
+// Problem is the RFC 7807 problem details representation of an app error, to be sent to clients as application/problem+json
+type Problem struct {
+	Type       string           `json:"type"`
+	Title      string           `json:"title"`
+	Status     int              `json:"status"`
+	Detail     string           `json:"detail,omitempty"`
+	Instance   string           `json:"instance,omitempty"`
+	Code       string           `json:"code"`
+	Violations []FieldViolation `json:"violations,omitempty"`
+}
+
+func getViolation(err error) (FieldViolation, bool) {
+	var typedError FieldViolation
+	if errorsAs(err, &typedError) {
+		return typedError, true
+	}
+	var pointerError *FieldViolation
+	if errorsAs(err, &pointerError) && pointerError != nil {
+		return *pointerError, true
+	}
+	return FieldViolation{}, false
+}
+
+func collectViolations(innerErrors []error, recursive bool) []FieldViolation {
+	var violations []FieldViolation
+	for _, innerError := range innerErrors {
+		var violation, isViolation = getViolationFunc(
+			innerError,
+		)
+		if isViolation {
+			violations = append(
+				violations,
+				violation,
+			)
+			continue
+		}
+		var typedError, isTyped = innerError.(*BaseAppError)
+		if recursive && isTyped {
+			violations = append(
+				violations,
+				collectViolations(
+					typedError.innerErrors,
+					recursive,
+				)...,
+			)
+		}
+	}
+	return violations
+}
+
+func marshalInnerErrors(innerErrors []error) []interface{} {
+	var marshalledErrors []interface{}
+	for _, innerError := range innerErrors {
+		var _, isViolation = getViolationFunc(
+			innerError,
+		)
+		if isViolation {
+			continue
+		}
+		var marshaler, isMarshaler = innerError.(json.Marshaler)
+		if isMarshaler {
+			marshalledErrors = append(
+				marshalledErrors,
+				marshaler,
+			)
+		} else {
+			marshalledErrors = append(
+				marshalledErrors,
+				innerErrorJSON{
+					getErrorMessageFunc(innerError),
+				},
+			)
+		}
+	}
+	return marshalledErrors
+}
+
+// MarshalJSON renders the app error as a JSON object with its code, message, extra data, inner errors, field violations, sequence, creation time, instance ID and component; it never uses Error(), which a type embedding *BaseAppError may override
+func (baseAppError *BaseAppError) MarshalJSON() ([]byte, error) {
+	if baseAppError == nil {
+		return []byte("null"), nil
+	}
+	var details = baseAppError.getDetails()
+	var createdAt *time.Time
+	if !details.createdAt.IsZero() {
+		createdAt = &details.createdAt
+	}
+	return jsonMarshal(
+		appErrorJSON{
+			Code:    baseAppError.Code(),
+			Message: getErrorMessageFunc(baseAppError.error),
+			Data: getExtraData(
+				baseAppError,
+			),
+			InnerErrors: marshalInnerErrorsFunc(
+				baseAppError.innerErrors,
+			),
+			Violations: collectViolationsFunc(
+				baseAppError.innerErrors,
+				false,
+			),
+			Sequence:   details.sequence,
+			CreatedAt:  createdAt,
+			InstanceID: baseAppError.InstanceID(),
+			Component:  details.component,
+		},
+	)
+}
+
+// NewProblem creates the RFC 7807 problem details for the given app error, including its instance ID and the field violations from its whole error tree; a nil app error, including a nil *BaseAppError, is rendered as a general failure
+func NewProblem(appError AppError) Problem {
+	if isNilFunc(appError) {
+		appError = (*BaseAppError)(nil)
+	}
+	var statusCode = appError.HTTPStatusCode()
+	var problem = Problem{
+		Type:   "about:blank",
+		Title:  httpStatusText(statusCode),
+		Status: statusCode,
+		Code:   appError.Code(),
+	}
+	if typedError, isTyped := appError.(instanceIDer); isTyped {
+		problem.Instance = typedError.InstanceID()
+	}
+	var baseAppError, isBase = appError.(*BaseAppError)
+	if isBase && baseAppError != nil {
+		problem.Detail = getErrorMessageFunc(baseAppError.error)
+		problem.Violations = collectViolationsFunc(
+			baseAppError.innerErrors,
+			true,
+		)
+	}
+	return problem
+}
+
+// WriteProblem writes the given app error to the HTTP response as application/problem+json, with its instance ID in the HeaderInstanceID header; nothing is written and nil is returned for a nil app error, including a nil *BaseAppError
+func WriteProblem(responseWriter http.ResponseWriter, appError AppError) error {
+	if isNilFunc(appError) {
+		return nil
+	}
+	var problem = newProblemFunc(
+		appError,
+	)
+	var body, marshalError = jsonMarshal(
+		problem,
+	)
+	if marshalError != nil {
+		return marshalError
+	}
+	emitMetricsEventFunc(
+		MetricsEventRendered,
+		getCodeEnumFunc(
+			appError,
+		),
+		problem.Status,
+		responsibleComponentFunc(
+			appError,
+		),
+	)
+	notifyObserversFunc(
+		LifecycleEvent{
+			Kind:           LifecycleEventRendered,
+			Error:          appError,
+			HTTPStatusCode: problem.Status,
+		},
+	)
+	responseWriter.Header().Set("Content-Type", contentTypeProblemJSON)
+	if problem.Instance != "" {
+		responseWriter.Header().Set(HeaderInstanceID, problem.Instance)
+	}
+	responseWriter.WriteHeader(problem.Status)
+	var _, writeError = responseWriter.Write(body)
+	return writeError
+}
@@ -0,0 +1,659 @@
+package apperror
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"math/rand"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestGetViolation_NotFieldError(t *testing.T) {
+	// arrange
+	var dummyError = errors.New("some error")
+
+	// mock
+	createMock(t)
+
+	// expect
+	errorsAsExpected = 2
+	errorsAs = func(err error, target interface{}) bool {
+		errorsAsCalled++
+		assert.Equal(t, dummyError, err)
+		return errors.As(err, target)
+	}
+
+	// SUT + act
+	var result, ok = getViolation(
+		dummyError,
+	)
+
+	// assert
+	assert.False(t, ok)
+	assert.Zero(t, result)
+
+	// verify
+	verifyAll(t)
+}
+
+func TestGetViolation_FieldViolation(t *testing.T) {
+	// arrange
+	var dummyFieldViolation = FieldViolation{
+		Field:   "some field",
+		Rule:    "some rule",
+		Message: "some message",
+		Value:   rand.Int(),
+	}
+
+	// mock
+	createMock(t)
+
+	// expect
+	errorsAsExpected = 1
+	errorsAs = func(err error, target interface{}) bool {
+		errorsAsCalled++
+		return errors.As(err, target)
+	}
+
+	// SUT + act
+	var result, ok = getViolation(
+		dummyFieldViolation,
+	)
+
+	// assert
+	assert.True(t, ok)
+	assert.Equal(t, dummyFieldViolation, result)
+
+	// verify
+	verifyAll(t)
+}
+
+func TestGetViolation_WrappedFieldViolation(t *testing.T) {
+	// arrange
+	var dummyFieldViolation = FieldViolation{
+		Field:   "some field",
+		Rule:    "some rule",
+		Message: "some message",
+	}
+	var dummyError = fmt.Errorf("some context: %w", dummyFieldViolation)
+
+	// mock
+	createMock(t)
+
+	// expect
+	errorsAsExpected = 1
+	errorsAs = func(err error, target interface{}) bool {
+		errorsAsCalled++
+		return errors.As(err, target)
+	}
+
+	// SUT + act
+	var result, ok = getViolation(
+		dummyError,
+	)
+
+	// assert
+	assert.True(t, ok)
+	assert.Equal(t, dummyFieldViolation, result)
+
+	// verify
+	verifyAll(t)
+}
+
+func TestGetViolation_FieldViolationPointer(t *testing.T) {
+	// arrange
+	var dummyFieldViolation = FieldViolation{
+		Field:   "some field",
+		Rule:    "some rule",
+		Message: "some message",
+	}
+	var dummyErrors = []error{
+		&dummyFieldViolation,
+		fmt.Errorf("some context: %w", &dummyFieldViolation),
+	}
+
+	// mock
+	createMock(t)
+
+	// expect
+	errorsAsExpected = 4
+	errorsAs = func(err error, target interface{}) bool {
+		errorsAsCalled++
+		return errors.As(err, target)
+	}
+
+	for _, dummyError := range dummyErrors {
+		// SUT + act
+		var result, ok = getViolation(
+			dummyError,
+		)
+
+		// assert
+		assert.True(t, ok)
+		assert.Equal(t, dummyFieldViolation, result)
+	}
+
+	// verify
+	verifyAll(t)
+}
+
+func TestCollectViolations_NotRecursive(t *testing.T) {
+	// arrange
+	var dummyViolation = FieldViolation{Field: "some field", Message: "some message"}
+	var dummyInnerError1 = errors.New("some inner error 1")
+	var dummyInnerError2 = &BaseAppError{
+		innerErrors: []error{errors.New("some nested error")},
+	}
+	var dummyInnerErrors = []error{
+		dummyInnerError1,
+		dummyInnerError2,
+	}
+
+	// mock
+	createMock(t)
+
+	// expect
+	getViolationFuncExpected = 2
+	getViolationFunc = func(err error) (FieldViolation, bool) {
+		getViolationFuncCalled++
+		return dummyViolation, err == dummyInnerError1
+	}
+
+	// SUT + act
+	var result = collectViolations(
+		dummyInnerErrors,
+		false,
+	)
+
+	// assert
+	assert.Equal(t, []FieldViolation{dummyViolation}, result)
+
+	// verify
+	verifyAll(t)
+}
+
+func TestCollectViolations_Recursive(t *testing.T) {
+	// arrange
+	var dummyViolation1 = FieldViolation{Field: "some field 1", Message: "some message 1"}
+	var dummyViolation2 = FieldViolation{Field: "some field 2", Message: "some message 2"}
+	var dummyNestedError = errors.New("some nested error")
+	var dummyInnerError1 = errors.New("some inner error 1")
+	var dummyInnerError2 = &BaseAppError{
+		innerErrors: []error{dummyNestedError},
+	}
+	var dummyInnerErrors = []error{
+		dummyInnerError1,
+		dummyInnerError2,
+	}
+
+	// mock
+	createMock(t)
+
+	// expect
+	getViolationFuncExpected = 3
+	getViolationFunc = func(err error) (FieldViolation, bool) {
+		getViolationFuncCalled++
+		if err == dummyInnerError1 {
+			return dummyViolation1, true
+		} else if err == dummyNestedError {
+			return dummyViolation2, true
+		}
+		return FieldViolation{}, false
+	}
+
+	// SUT + act
+	var result = collectViolations(
+		dummyInnerErrors,
+		true,
+	)
+
+	// assert
+	assert.Equal(t, []FieldViolation{dummyViolation1, dummyViolation2}, result)
+
+	// verify
+	verifyAll(t)
+}
+
+func TestMarshalInnerErrors_NoInnerErrors(t *testing.T) {
+	// mock
+	createMock(t)
+
+	// SUT + act
+	var result = marshalInnerErrors(
+		nil,
+	)
+
+	// assert
+	assert.Nil(t, result)
+
+	// verify
+	verifyAll(t)
+}
+
+func TestMarshalInnerErrors_MixedInnerErrors(t *testing.T) {
+	// arrange
+	var dummyInnerError1 = errors.New("some inner error 1")
+	var dummyInnerError2 = FieldViolation{Field: "some path", Message: "some inner error 2"}
+	var dummyInnerError3 = &BaseAppError{}
+	var dummyMessage = "some message"
+
+	// mock
+	createMock(t)
+
+	// expect
+	getViolationFuncExpected = 3
+	getViolationFunc = func(err error) (FieldViolation, bool) {
+		getViolationFuncCalled++
+		return dummyInnerError2, err == dummyInnerError2
+	}
+	getErrorMessageFuncExpected = 1
+	getErrorMessageFunc = func(err error) string {
+		getErrorMessageFuncCalled++
+		assert.Equal(t, dummyInnerError1, err)
+		return dummyMessage
+	}
+
+	// SUT + act
+	var result = marshalInnerErrors(
+		[]error{
+			dummyInnerError1,
+			dummyInnerError2,
+			dummyInnerError3,
+		},
+	)
+
+	// assert
+	assert.Equal(t, []interface{}{innerErrorJSON{dummyMessage}, dummyInnerError3}, result)
+
+	// verify
+	verifyAll(t)
+}
+
+func TestBaseAppError_MarshalJSON(t *testing.T) {
+	// arrange
+	var dummyError = errors.New("some error")
+	var dummyInnerErrors = []error{errors.New("some inner error")}
+	var dummyExtraData = map[string]interface{}{"foo": "bar"}
+	var dummyMessage = "some message"
+	var dummyMarshalledErrors = []interface{}{"some marshalled error"}
+	var dummyViolations = []FieldViolation{{Field: "some field", Message: "some violation"}}
+	var dummyResult = []byte("some result")
+	var dummyResultError = errors.New("some result error")
+
+	// mock
+	createMock(t)
+
+	// SUT
+	var sut = &BaseAppError{
+		error:       dummyError,
+		code:        CodeBadRequest,
+		innerErrors: dummyInnerErrors,
+		extraData:   dummyExtraData,
+	}
+
+	// expect
+	getErrorMessageFuncExpected = 1
+	getErrorMessageFunc = func(err error) string {
+		getErrorMessageFuncCalled++
+		assert.Equal(t, dummyError, err)
+		return dummyMessage
+	}
+	marshalInnerErrorsFuncExpected = 1
+	marshalInnerErrorsFunc = func(innerErrors []error) []interface{} {
+		marshalInnerErrorsFuncCalled++
+		assert.Equal(t, dummyInnerErrors, innerErrors)
+		return dummyMarshalledErrors
+	}
+	collectViolationsFuncExpected = 1
+	collectViolationsFunc = func(innerErrors []error, recursive bool) []FieldViolation {
+		collectViolationsFuncCalled++
+		assert.Equal(t, dummyInnerErrors, innerErrors)
+		assert.False(t, recursive)
+		return dummyViolations
+	}
+	jsonMarshalExpected = 1
+	jsonMarshal = func(v interface{}) ([]byte, error) {
+		jsonMarshalCalled++
+		assert.Equal(t, appErrorJSON{
+			Code:        CodeBadRequest.String(),
+			Message:     dummyMessage,
+			Data:        dummyExtraData,
+			InnerErrors: dummyMarshalledErrors,
+			Violations:  dummyViolations,
+		}, v)
+		return dummyResult, dummyResultError
+	}
+
+	// act
+	var result, err = sut.MarshalJSON()
+
+	// assert
+	assert.Equal(t, dummyResult, result)
+	assert.Equal(t, dummyResultError, err)
+
+	// verify
+	verifyAll(t)
+}
+
+func TestNewProblem_NonBaseAppError(t *testing.T) {
+	// arrange
+	var dummyAppError = dummyCodedError{code: CodeNotFound}
+	var dummyTitle = "some title"
+
+	// mock
+	createMock(t)
+
+	// expect
+	isNilFuncExpected = 1
+	isNilFunc = func(err error) bool {
+		isNilFuncCalled++
+		return err == nil
+	}
+	httpStatusTextExpected = 1
+	httpStatusText = func(code int) string {
+		httpStatusTextCalled++
+		assert.Equal(t, http.StatusNotFound, code)
+		return dummyTitle
+	}
+
+	// SUT + act
+	var result = NewProblem(
+		dummyAppError,
+	)
+
+	// assert
+	assert.Equal(t, Problem{
+		Type:   "about:blank",
+		Title:  dummyTitle,
+		Status: http.StatusNotFound,
+		Code:   CodeNotFound.String(),
+	}, result)
+
+	// verify
+	verifyAll(t)
+}
+
+func TestNewProblem_BaseAppError(t *testing.T) {
+	// arrange
+	var dummyError = errors.New("some error")
+	var dummyInnerErrors = []error{errors.New("some inner error")}
+	var dummyBaseAppError = &BaseAppError{
+		error:       dummyError,
+		code:        CodeBadRequest,
+		innerErrors: dummyInnerErrors,
+	}
+	var dummyTitle = "some title"
+	var dummyMessage = "some message"
+	var dummyViolations = []FieldViolation{{Field: "some field", Message: "some violation"}}
+
+	// mock
+	createMock(t)
+
+	// expect
+	isNilFuncExpected = 1
+	isNilFunc = func(err error) bool {
+		isNilFuncCalled++
+		return err == nil
+	}
+	httpStatusTextExpected = 1
+	httpStatusText = func(code int) string {
+		httpStatusTextCalled++
+		assert.Equal(t, http.StatusBadRequest, code)
+		return dummyTitle
+	}
+	getErrorMessageFuncExpected = 1
+	getErrorMessageFunc = func(err error) string {
+		getErrorMessageFuncCalled++
+		assert.Equal(t, dummyError, err)
+		return dummyMessage
+	}
+	collectViolationsFuncExpected = 1
+	collectViolationsFunc = func(innerErrors []error, recursive bool) []FieldViolation {
+		collectViolationsFuncCalled++
+		assert.Equal(t, dummyInnerErrors, innerErrors)
+		assert.True(t, recursive)
+		return dummyViolations
+	}
+
+	// SUT + act
+	var result = NewProblem(
+		dummyBaseAppError,
+	)
+
+	// assert
+	assert.Equal(t, Problem{
+		Type:       "about:blank",
+		Title:      dummyTitle,
+		Status:     http.StatusBadRequest,
+		Detail:     dummyMessage,
+		Code:       CodeBadRequest.String(),
+		Violations: dummyViolations,
+	}, result)
+
+	// verify
+	verifyAll(t)
+}
+
+func TestNewProblem_WrappedViolations(t *testing.T) {
+	// arrange
+	var dummyViolation1 = FieldViolation{Field: "email", Rule: "required", Message: "email is required"}
+	var dummyViolation2 = &FieldViolation{Field: "age", Rule: "min", Message: "age is too low"}
+	var appError = GetBadRequestError(
+		fmt.Errorf("some context: %w", dummyViolation1),
+		dummyViolation2,
+		errors.New("some inner error"),
+	)
+
+	// act
+	var result = NewProblem(appError)
+	var data, err = json.Marshal(appError)
+
+	// assert
+	assert.Equal(t, []FieldViolation{dummyViolation1, *dummyViolation2}, result.Violations)
+	assert.NoError(t, err)
+	assert.Contains(t, string(data), `"innerErrors":[{"message":"some inner error"}]`)
+}
+
+func TestWriteProblem_MarshalError(t *testing.T) {
+	// arrange
+	var dummyAppError = &BaseAppError{}
+	var dummyProblem = Problem{Status: rand.Intn(600)}
+	var dummyMarshalError = errors.New("some marshal error")
+	var dummyResponseWriter = httptest.NewRecorder()
+
+	// mock
+	createMock(t)
+
+	// expect
+	isNilFuncExpected = 1
+	isNilFunc = func(err error) bool {
+		isNilFuncCalled++
+		return err == nil
+	}
+	newProblemFuncExpected = 1
+	newProblemFunc = func(appError AppError) Problem {
+		newProblemFuncCalled++
+		assert.Equal(t, dummyAppError, appError)
+		return dummyProblem
+	}
+	jsonMarshalExpected = 1
+	jsonMarshal = func(v interface{}) ([]byte, error) {
+		jsonMarshalCalled++
+		assert.Equal(t, dummyProblem, v)
+		return nil, dummyMarshalError
+	}
+
+	// SUT + act
+	var err = WriteProblem(
+		dummyResponseWriter,
+		dummyAppError,
+	)
+
+	// assert
+	assert.Equal(t, dummyMarshalError, err)
+	assert.Empty(t, dummyResponseWriter.Header().Get("Content-Type"))
+
+	// verify
+	verifyAll(t)
+}
+
+func TestWriteProblem_HappyPath(t *testing.T) {
+	// arrange
+	var dummyAppError = &BaseAppError{}
+	var dummyProblem = Problem{Status: http.StatusConflict}
+	var dummyBody = []byte("some body")
+	var dummyResponseWriter = httptest.NewRecorder()
+
+	// mock
+	createMock(t)
+
+	// expect
+	isNilFuncExpected = 1
+	isNilFunc = func(err error) bool {
+		isNilFuncCalled++
+		return err == nil
+	}
+	newProblemFuncExpected = 1
+	newProblemFunc = func(appError AppError) Problem {
+		newProblemFuncCalled++
+		assert.Equal(t, dummyAppError, appError)
+		return dummyProblem
+	}
+	jsonMarshalExpected = 1
+	jsonMarshal = func(v interface{}) ([]byte, error) {
+		jsonMarshalCalled++
+		assert.Equal(t, dummyProblem, v)
+		return dummyBody, nil
+	}
+
+	getCodeEnumFuncExpected = 1
+	getCodeEnumFunc = func(appError AppError) Code {
+		getCodeEnumFuncCalled++
+		assert.Equal(t, dummyAppError, appError)
+		return CodeDataCorruption
+	}
+	responsibleComponentFuncExpected = 1
+	responsibleComponentFunc = func(err error) string {
+		responsibleComponentFuncCalled++
+		assert.Equal(t, dummyAppError, err)
+		return "some component"
+	}
+	emitMetricsEventFuncExpected = 1
+	emitMetricsEventFunc = func(kind MetricsEventKind, code Code, httpStatusCode int, component string) {
+		emitMetricsEventFuncCalled++
+		assert.Equal(t, MetricsEventRendered, kind)
+		assert.Equal(t, CodeDataCorruption, code)
+		assert.Equal(t, http.StatusConflict, httpStatusCode)
+		assert.Equal(t, "some component", component)
+	}
+	notifyObserversFuncExpected = 1
+	notifyObserversFunc = func(event LifecycleEvent) {
+		notifyObserversFuncCalled++
+		assert.Equal(t, LifecycleEventRendered, event.Kind)
+		assert.Equal(t, dummyAppError, event.Error)
+		assert.Equal(t, http.StatusConflict, event.HTTPStatusCode)
+	}
+
+	// SUT + act
+	var err = WriteProblem(
+		dummyResponseWriter,
+		dummyAppError,
+	)
+
+	// assert
+	assert.NoError(t, err)
+	assert.Equal(t, contentTypeProblemJSON, dummyResponseWriter.Header().Get("Content-Type"))
+	assert.Equal(t, http.StatusConflict, dummyResponseWriter.Code)
+	assert.Equal(t, dummyBody, dummyResponseWriter.Body.Bytes())
+
+	// verify
+	verifyAll(t)
+}
+
+func TestCollector_ProblemJSON(t *testing.T) {
+	// arrange
+	SetInstanceIDGenerator(func() string { return "some instance id" })
+	defer SetInstanceIDGenerator(nil)
+	var collector = NewCollector()
+	collector.AddField(FieldPath("items", 3, "price"), errors.New("must be positive"))
+	var appError = collector.Build(CodeBadRequest, "Request body is invalid")
+	var dummyResponseWriter = httptest.NewRecorder()
+
+	// act
+	var err = WriteProblem(
+		dummyResponseWriter,
+		GetGeneralFailureError(appError),
+	)
+
+	// assert
+	assert.NoError(t, err)
+	assert.JSONEq(t, `{
+		"type": "about:blank",
+		"title": "Internal Server Error",
+		"status": 500,
+		"detail": "An error occurred during execution",
+		"instance": "some instance id",
+		"code": "GeneralFailure",
+		"violations": [{"field": "items[3].price", "pointer": "/items/3/price", "message": "must be positive"}]
+	}`, dummyResponseWriter.Body.String())
+	assert.Equal(t, "some instance id", dummyResponseWriter.Header().Get(HeaderInstanceID))
+}
+
+func TestCollector_JSON(t *testing.T) {
+	// arrange
+	SetInstanceIDGenerator(func() string { return "some instance id" })
+	defer SetInstanceIDGenerator(nil)
+	var collector = NewCollector()
+	collector.Add(errors.New("some error"))
+	collector.AddField("name", errors.New("is required"))
+	var appError = collector.Build(CodeBadRequest, "Request body is invalid")
+	appError.Attach("id", 5)
+
+	// act
+	var result, err = jsonMarshal(appError)
+
+	// assert
+	assert.NoError(t, err)
+	assert.JSONEq(t, `{
+		"code": "BadRequest",
+		"message": "Request body is invalid",
+		"data": {"id": 5},
+		"innerErrors": [{"message": "some error"}],
+		"violations": [{"field": "name", "pointer": "/name", "message": "is required"}],
+		"instanceId": "some instance id"
+	}`, string(result))
+}
+
+type dummyCodedError struct {
+	AppError
+	code Code
+}
+
+func (dummyCodedError dummyCodedError) Code() string {
+	return dummyCodedError.code.String()
+}
+
+func (dummyCodedError dummyCodedError) HTTPStatusCode() int {
+	return dummyCodedError.code.HTTPStatusCode()
+}
+
+func TestBaseAppError_MarshalJSON_EmbedderOverridingError(t *testing.T) {
+	// arrange
+	SetInstanceIDGenerator(func() string { return "" })
+	defer SetInstanceIDGenerator(nil)
+	var dummyBaseAppError = NewBaseAppError(CodeNotFound, "some message")
+	var sut = NewBaseAppError(CodeGeneralFailure, "some root")
+	sut.Wrap(detailedAppError{dummyBaseAppError})
+
+	// act
+	var result, err = sut.MarshalJSON()
+
+	// assert
+	assert.NoError(t, err)
+	assert.JSONEq(t, `{"code": "GeneralFailure", "message": "some root", "innerErrors": [{"code": "NotFound", "message": "some message"}]}`, string(result))
+	assert.NotContains(t, string(result), "some overridden text")
+}
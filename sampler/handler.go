@@ -0,0 +1,68 @@
+package sampler
+
+import (
+	"context"
+	"log/slog"
+)
+
+// SuppressedKey is the key of the attribute holding the number of suppressed occurrences, added to the sampled records that follow suppressed ones
+const SuppressedKey = "suppressed"
+
+// Handler is an slog.Handler sampling the records holding an error among their top level attributes, and passing the others through
+type Handler struct {
+	next    slog.Handler
+	sampler *Sampler
+}
+
+// NewHandler wraps the given handler with the given sampler
+func NewHandler(next slog.Handler, sampler *Sampler) *Handler {
+	return &Handler{
+		next:    next,
+		sampler: sampler,
+	}
+}
+
+// Enabled reports whether the wrapped handler handles records at the given level
+func (handler *Handler) Enabled(ctx context.Context, level slog.Level) bool {
+	return handler.next.Enabled(ctx, level)
+}
+
+func recordError(record slog.Record) error {
+	var err error
+	record.Attrs(func(attr slog.Attr) bool {
+		// errors implementing slog.LogValuer, such as app errors, are kept unresolved in records
+		if kind := attr.Value.Kind(); kind != slog.KindAny && kind != slog.KindLogValuer {
+			return true
+		}
+		err, _ = attr.Value.Any().(error)
+		return err == nil
+	})
+	return err
+}
+
+// Handle passes the record to the wrapped handler unless its error is suppressed by the sampler
+func (handler *Handler) Handle(ctx context.Context, record slog.Record) error {
+	var err = recordError(record)
+	if err == nil {
+		return handler.next.Handle(ctx, record)
+	}
+	var decision = handler.sampler.Sample(err)
+	if !decision.Allowed {
+		return nil
+	}
+	if decision.Suppressed > 0 {
+		record = record.Clone()
+		record.AddAttrs(slog.Uint64(SuppressedKey, decision.Suppressed))
+	}
+	return handler.next.Handle(ctx, record)
+}
+
+// WithAttrs returns a handler sharing the same sampler, wrapping the wrapped handler with the given attributes
+func (handler *Handler) WithAttrs(attrs []slog.Attr) slog.Handler {
+	return NewHandler(handler.next.WithAttrs(attrs), handler.sampler)
+}
+
+// WithGroup returns a handler sharing the same sampler, wrapping the wrapped handler with the given group
+func (handler *Handler) WithGroup(name string) slog.Handler {
+	return NewHandler(handler.next.WithGroup(name), handler.sampler)
+}
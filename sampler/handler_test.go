@@ -0,0 +1,93 @@
+package sampler
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"log/slog"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	apperror "github.com/zhongjie-cai/app-error"
+)
+
+func newTestLogger(buffer *bytes.Buffer) *slog.Logger {
+	var clock = &fakeClock{now: time.Unix(1000, 0)}
+	var textHandler = slog.NewTextHandler(buffer, &slog.HandlerOptions{
+		ReplaceAttr: func(groups []string, attr slog.Attr) slog.Attr {
+			if attr.Key == slog.TimeKey && len(groups) == 0 {
+				return slog.Attr{}
+			}
+			return attr
+		},
+	})
+	return slog.New(NewHandler(textHandler, newTestSampler(clock)))
+}
+
+func TestHandler_Handle(t *testing.T) {
+	// arrange
+	apperror.SetInstanceIDGenerator(func() string { return "some-id" })
+	defer apperror.SetInstanceIDGenerator(nil)
+	var dataCorruptionAttrs = `error.code=DataCorruption error.message="Operation failed due to internal storage data corruption" error.instanceId=some-id`
+	var buffer bytes.Buffer
+	var sut = newTestLogger(&buffer).With("service", "orders")
+
+	// act
+	for i := 0; i < 5; i++ {
+		sut.Error("storage failed", "error", apperror.GetDataCorruptionError())
+		sut.Info("no error", "attempt", i)
+	}
+
+	// assert
+	var lines = strings.Split(strings.TrimSpace(buffer.String()), "\n")
+	assert.Equal(t, []string{
+		`level=ERROR msg="storage failed" service=orders ` + dataCorruptionAttrs,
+		`level=INFO msg="no error" service=orders attempt=0`,
+		`level=ERROR msg="storage failed" service=orders ` + dataCorruptionAttrs,
+		`level=INFO msg="no error" service=orders attempt=1`,
+		`level=INFO msg="no error" service=orders attempt=2`,
+		`level=INFO msg="no error" service=orders attempt=3`,
+		`level=ERROR msg="storage failed" service=orders ` + dataCorruptionAttrs + ` suppressed=2`,
+		`level=INFO msg="no error" service=orders attempt=4`,
+	}, lines)
+}
+
+func TestHandler_WithGroup(t *testing.T) {
+	// arrange
+	var buffer bytes.Buffer
+	var sut = newTestLogger(&buffer).WithGroup("request")
+
+	// act
+	for i := 0; i < 3; i++ {
+		sut.Error("not found", "error", apperror.GetNotFoundError())
+	}
+
+	// assert
+	assert.Equal(t, 2, strings.Count(buffer.String(), "\n"))
+	assert.Contains(t, buffer.String(), `request.error.code=NotFound`)
+}
+
+func TestHandler_Enabled(t *testing.T) {
+	// arrange
+	var sut = NewHandler(slog.NewTextHandler(&bytes.Buffer{}, &slog.HandlerOptions{Level: slog.LevelWarn}), New(Config{}))
+
+	// assert
+	assert.False(t, sut.Enabled(context.Background(), slog.LevelInfo))
+	assert.True(t, sut.Enabled(context.Background(), slog.LevelError))
+}
+
+func TestHandler_PlainError(t *testing.T) {
+	// arrange
+	var buffer bytes.Buffer
+	var sut = newTestLogger(&buffer)
+
+	// act
+	for i := 0; i < 3; i++ {
+		sut.Error("failed", "error", errors.New("some error"))
+	}
+
+	// assert
+	assert.Equal(t, 2, strings.Count(buffer.String(), "\n"))
+}
@@ -0,0 +1,190 @@
+// Package sampler limits how often identical app errors are logged: per error code and fingerprint it allows the first N occurrences of every time window, then one in M, and reports how many occurrences were suppressed since the previous allowed one.
+//
+// Use it standalone:
+//
+//	var errorSampler = sampler.New(sampler.Config{First: 10, Thereafter: 100, Window: time.Minute})
+//
+//	if decision := errorSampler.Sample(err); decision.Allowed {
+//		log.Printf("%v (%d suppressed)", err, decision.Suppressed)
+//	}
+//
+// or as an slog.Handler wrapper, which samples the records holding an error attribute:
+//
+//	var logger = slog.New(sampler.NewHandler(slog.NewJSONHandler(os.Stdout, nil), errorSampler))
+package sampler
+
+import (
+	"container/list"
+	"errors"
+	"sync"
+	"time"
+
+	apperror "github.com/zhongjie-cai/app-error"
+)
+
+// defaultMaxKeys is the default number of tracked keys
+const defaultMaxKeys = 10000
+
+// Config configures a Sampler
+type Config struct {
+	// First is the number of occurrences allowed per key and window
+	First int
+	// Thereafter allows one in every Thereafter occurrences past the First ones in the same window; zero suppresses them all
+	Thereafter int
+	// Window is the length of the time window; the counts of a key restart when its window elapses
+	Window time.Duration
+	// Fingerprint groups errors of the same code; defaults to Fingerprint
+	Fingerprint func(err error) string
+	// Now is the clock; defaults to time.Now
+	Now func() time.Time
+	// MaxKeys bounds the memory used: past it, the keys of the oldest windows are dropped along with their pending suppressed counts; defaults to 10000
+	MaxKeys int
+}
+
+// Decision is the result of sampling one error occurrence
+type Decision struct {
+	// Allowed tells whether the occurrence should be logged
+	Allowed bool
+	// Suppressed is the number of occurrences of the same key suppressed since the previous allowed one; only set when Allowed
+	Suppressed uint64
+}
+
+type key struct {
+	code        string
+	fingerprint string
+}
+
+type entry struct {
+	key         key
+	windowStart time.Time
+	count       int
+	suppressed  uint64
+}
+
+// Sampler samples error occurrences; it is safe for concurrent use
+type Sampler struct {
+	config  Config
+	lock    sync.Mutex
+	entries map[key]*list.Element
+	// order holds the entries by the start of their window, oldest first
+	order *list.List
+}
+
+// messageTemplater is implemented by app errors exposing the message format they were created with, e.g. *apperror.BaseAppError
+type messageTemplater interface {
+	Code() string
+	MessageTemplate() string
+}
+
+// messager is implemented by app errors exposing their message, e.g. the v2 *apperror.Error
+type messager interface {
+	Message() string
+}
+
+// Fingerprint returns the code and message template of the app error the given error is or wraps, leaving out its parameters, extra data and inner errors; other errors exposing a message give their message, and the rest their Error() text
+func Fingerprint(err error) string {
+	var templater messageTemplater
+	if errors.As(err, &templater) {
+		return templater.Code() + " " + templater.MessageTemplate()
+	}
+	var typedError messager
+	if errors.As(err, &typedError) {
+		return typedError.Message()
+	}
+	return err.Error()
+}
+
+func errorCode(err error) string {
+	var appError apperror.AppError
+	if errors.As(err, &appError) {
+		return appError.Code()
+	}
+	return ""
+}
+
+// New creates a sampler with the given configuration
+func New(config Config) *Sampler {
+	if config.Fingerprint == nil {
+		config.Fingerprint = Fingerprint
+	}
+	if config.Now == nil {
+		config.Now = time.Now
+	}
+	if config.MaxKeys <= 0 {
+		config.MaxKeys = defaultMaxKeys
+	}
+	return &Sampler{
+		config:  config,
+		entries: map[key]*list.Element{},
+		order:   list.New(),
+	}
+}
+
+// Allow tells whether the given error occurrence should be logged; nil errors are always allowed
+func (sampler *Sampler) Allow(err error) bool {
+	return sampler.Sample(err).Allowed
+}
+
+// Sample decides whether the given error occurrence should be logged; nil errors are always allowed
+func (sampler *Sampler) Sample(err error) Decision {
+	if apperror.IsNil(err) {
+		return Decision{Allowed: true}
+	}
+	var sampleKey = key{
+		code:        errorCode(err),
+		fingerprint: sampler.config.Fingerprint(err),
+	}
+	var now = sampler.config.Now()
+	sampler.lock.Lock()
+	defer sampler.lock.Unlock()
+	var element, isFound = sampler.entries[sampleKey]
+	if !isFound {
+		sampler.prune(now)
+		element = sampler.order.PushBack(&entry{key: sampleKey, windowStart: now})
+		sampler.entries[sampleKey] = element
+	} else if sampler.isElapsed(element.Value.(*entry), now) {
+		element.Value.(*entry).windowStart = now
+		element.Value.(*entry).count = 0
+		sampler.order.MoveToBack(element)
+	}
+	var sampleEntry = element.Value.(*entry)
+	sampleEntry.count++
+	if !sampler.isAllowed(sampleEntry.count) {
+		sampleEntry.suppressed++
+		return Decision{}
+	}
+	var suppressed = sampleEntry.suppressed
+	sampleEntry.suppressed = 0
+	return Decision{
+		Allowed:    true,
+		Suppressed: suppressed,
+	}
+}
+
+func (sampler *Sampler) isElapsed(sampleEntry *entry, now time.Time) bool {
+	return !now.Before(sampleEntry.windowStart.Add(sampler.config.Window))
+}
+
+func (sampler *Sampler) isAllowed(count int) bool {
+	if count <= sampler.config.First {
+		return true
+	}
+	return sampler.config.Thereafter > 0 &&
+		(count-sampler.config.First)%sampler.config.Thereafter == 0
+}
+
+// prune drops the key of the oldest window, and those of any other elapsed window, once there are MaxKeys keys
+func (sampler *Sampler) prune(now time.Time) {
+	if sampler.order.Len() < sampler.config.MaxKeys {
+		return
+	}
+	sampler.drop(sampler.order.Front())
+	for oldest := sampler.order.Front(); oldest != nil && sampler.isElapsed(oldest.Value.(*entry), now); oldest = sampler.order.Front() {
+		sampler.drop(oldest)
+	}
+}
+
+func (sampler *Sampler) drop(element *list.Element) {
+	sampler.order.Remove(element)
+	delete(sampler.entries, element.Value.(*entry).key)
+}
@@ -0,0 +1,204 @@
+package sampler
+
+import (
+	"errors"
+	"fmt"
+	"strconv"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	apperror "github.com/zhongjie-cai/app-error"
+)
+
+type fakeClock struct {
+	now time.Time
+}
+
+func (clock *fakeClock) Now() time.Time {
+	return clock.now
+}
+
+func (clock *fakeClock) Advance(duration time.Duration) {
+	clock.now = clock.now.Add(duration)
+}
+
+func newTestSampler(clock *fakeClock) *Sampler {
+	return New(Config{
+		First:      2,
+		Thereafter: 3,
+		Window:     time.Minute,
+		Now:        clock.Now,
+	})
+}
+
+func TestFingerprint(t *testing.T) {
+	// arrange
+	var appError = apperror.NewBaseAppError(apperror.CodeDataCorruption, "some message")
+	appError.Attach("id", 1)
+
+	// assert
+	assert.Equal(t, "DataCorruption some message", Fingerprint(appError))
+	assert.Equal(t, "some error", Fingerprint(errors.New("some error")))
+}
+
+func TestFingerprint_MessageTemplate(t *testing.T) {
+	// arrange
+	var first = apperror.NewBaseAppError(apperror.CodeNotFound, "user %v not found", 1)
+	var second = apperror.NewBaseAppError(apperror.CodeNotFound, "user %v not found", 2)
+
+	// assert
+	assert.Equal(t, "NotFound user %v not found", Fingerprint(first))
+	assert.Equal(t, Fingerprint(first), Fingerprint(second))
+}
+
+func TestFingerprint_Wrapped(t *testing.T) {
+	// arrange
+	var appError = apperror.NewBaseAppError(apperror.CodeNotFound, "user %v not found", 1)
+	var wrapped = fmt.Errorf("while loading: %w", appError)
+
+	// assert
+	assert.Equal(t, "NotFound user %v not found", Fingerprint(wrapped))
+	assert.Equal(t, "NotFound", errorCode(wrapped))
+}
+
+func TestSampler_Sample_WrappedSameTemplate(t *testing.T) {
+	// arrange
+	var clock = &fakeClock{now: time.Unix(1000, 0)}
+	var sut = New(Config{First: 1, Window: time.Minute, Now: clock.Now})
+
+	// act
+	var first = sut.Allow(apperror.NewBaseAppError(apperror.CodeNotFound, "user %v not found", 1))
+	var second = sut.Allow(fmt.Errorf("while loading: %w", apperror.NewBaseAppError(apperror.CodeNotFound, "user %v not found", 2)))
+
+	// assert
+	assert.True(t, first)
+	assert.False(t, second)
+}
+
+func TestSampler_Sample_FirstThenThereafter(t *testing.T) {
+	// arrange
+	var clock = &fakeClock{now: time.Unix(1000, 0)}
+	var sut = newTestSampler(clock)
+	var decisions []Decision
+
+	// act
+	for i := 0; i < 9; i++ {
+		decisions = append(decisions, sut.Sample(apperror.GetDataCorruptionError()))
+	}
+
+	// assert
+	assert.Equal(t, []Decision{
+		{Allowed: true},
+		{Allowed: true},
+		{},
+		{},
+		{Allowed: true, Suppressed: 2},
+		{},
+		{},
+		{Allowed: true, Suppressed: 2},
+		{},
+	}, decisions)
+}
+
+func TestSampler_Sample_Window(t *testing.T) {
+	// arrange
+	var clock = &fakeClock{now: time.Unix(1000, 0)}
+	var sut = newTestSampler(clock)
+	for i := 0; i < 3; i++ {
+		sut.Sample(apperror.GetDataCorruptionError())
+	}
+
+	// act
+	clock.Advance(59 * time.Second)
+	var inWindow = sut.Sample(apperror.GetDataCorruptionError())
+	clock.Advance(time.Second)
+	var nextWindow = sut.Sample(apperror.GetDataCorruptionError())
+
+	// assert
+	assert.Equal(t, Decision{}, inWindow)
+	assert.Equal(t, Decision{Allowed: true, Suppressed: 2}, nextWindow)
+}
+
+func TestSampler_Sample_Keys(t *testing.T) {
+	// arrange
+	var clock = &fakeClock{now: time.Unix(1000, 0)}
+	var sut = New(Config{First: 1, Window: time.Minute, Now: clock.Now})
+
+	// act
+	var first = sut.Allow(apperror.NewBaseAppError(apperror.CodeNotFound, "some message"))
+	var sameKey = sut.Allow(apperror.NewBaseAppError(apperror.CodeNotFound, "some message"))
+	var otherCode = sut.Allow(apperror.NewBaseAppError(apperror.CodeBadRequest, "some message"))
+	var otherMessage = sut.Allow(apperror.NewBaseAppError(apperror.CodeNotFound, "some other message"))
+	var plain = sut.Allow(errors.New("some message"))
+	var nilError = sut.Allow(nil)
+
+	// assert
+	assert.True(t, first)
+	assert.False(t, sameKey)
+	assert.True(t, otherCode)
+	assert.True(t, otherMessage)
+	assert.True(t, plain)
+	assert.True(t, nilError)
+}
+
+func TestSampler_Sample_CustomFingerprint(t *testing.T) {
+	// arrange
+	var clock = &fakeClock{now: time.Unix(1000, 0)}
+	var sut = New(Config{
+		First:       1,
+		Window:      time.Minute,
+		Now:         clock.Now,
+		Fingerprint: func(err error) string { return "" },
+	})
+
+	// act
+	var first = sut.Allow(apperror.NewBaseAppError(apperror.CodeNotFound, "some message"))
+	var second = sut.Allow(apperror.NewBaseAppError(apperror.CodeNotFound, "some other message"))
+
+	// assert
+	assert.True(t, first)
+	assert.False(t, second)
+}
+
+func TestSampler_Prune(t *testing.T) {
+	// arrange
+	var clock = &fakeClock{now: time.Unix(1000, 0)}
+	var sut = New(Config{First: 1, Window: time.Minute, Now: clock.Now, MaxKeys: 2})
+	sut.Sample(errors.New("some error 1"))
+	sut.Sample(errors.New("some error 2"))
+
+	// act
+	clock.Advance(30 * time.Second)
+	sut.Sample(errors.New("some error 3"))
+	clock.Advance(30 * time.Second)
+	sut.Sample(errors.New("some error 4"))
+
+	// assert
+	assert.Len(t, sut.entries, 2)
+	assert.Contains(t, sut.entries, key{fingerprint: "some error 3"})
+	assert.Contains(t, sut.entries, key{fingerprint: "some error 4"})
+}
+
+func TestSampler_Prune_LiveKeysPastMaxKeys(t *testing.T) {
+	// arrange
+	var clock = &fakeClock{now: time.Unix(1000, 0)}
+	var sut = New(Config{First: 1, Window: time.Minute, Now: clock.Now, MaxKeys: 3})
+
+	// act
+	for index := 1; index <= 5; index++ {
+		clock.Advance(time.Second)
+		sut.Sample(errors.New("some error " + strconv.Itoa(index)))
+	}
+	clock.Advance(time.Second)
+	var repeated = sut.Allow(errors.New("some error 3"))
+	sut.Sample(errors.New("some error 6"))
+
+	// assert
+	assert.False(t, repeated)
+	assert.Len(t, sut.entries, 3)
+	assert.Equal(t, 3, sut.order.Len())
+	assert.Contains(t, sut.entries, key{fingerprint: "some error 4"})
+	assert.Contains(t, sut.entries, key{fingerprint: "some error 5"})
+	assert.Contains(t, sut.entries, key{fingerprint: "some error 6"})
+}
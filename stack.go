@@ -0,0 +1,92 @@
+package apperror
+
+import (
+	"runtime"
+	"sync/atomic"
+)
+
+// These are stack capturing related constants
+const (
+	stackMaxDepth       int    = 32
+	stackSkippedFrames  int    = 3
+	errorStackHeader    string = "stack:"
+	errorStackFrameFile string = "%s:%d"
+)
+
+var stackCaptureEnabled atomic.Bool
+
+// SetStackCapture makes NewBaseAppError capture the stack it is called from, up to 32 frames, which Detail then prints below the app error; capturing is off by default as it costs a runtime.Callers call and an allocation per app error
+func SetStackCapture(enabled bool) {
+	stackCaptureEnabled.Store(enabled)
+}
+
+func captureStack() []uintptr {
+	if !stackCaptureEnabled.Load() {
+		return nil
+	}
+	var programCounters [stackMaxDepth]uintptr
+	// skips runtime.Callers, captureStack and NewBaseAppError, so the stack starts at the caller of NewBaseAppError
+	var count = runtimeCallers(
+		stackSkippedFrames,
+		programCounters[:],
+	)
+	return append(
+		[]uintptr(nil),
+		programCounters[:count]...,
+	)
+}
+
+func formatStack(stack []uintptr) string {
+	var lines = []string{
+		errorStackHeader,
+	}
+	var frames = runtimeCallersFrames(
+		stack,
+	)
+	for {
+		var frame, more = frames.Next()
+		lines = append(
+			lines,
+			indentLinesFunc(
+				frame.Function,
+			),
+			indentLinesFunc(
+				indentLinesFunc(
+					fmtSprintf(
+						errorStackFrameFile,
+						frame.File,
+						frame.Line,
+					),
+				),
+			),
+		)
+		if !more {
+			break
+		}
+	}
+	return stringsJoin(
+		lines,
+		errorTreeLineBreak,
+	)
+}
+
+// StackTrace returns the frames of the stack NewBaseAppError was called from, or nil if it was not captured (see SetStackCapture); errors derived through With or WithCause keep the stack of the error they derive from
+func (baseAppError *BaseAppError) StackTrace() []runtime.Frame {
+	if baseAppError == nil || len(baseAppError.getDetails().stack) == 0 {
+		return nil
+	}
+	var stackTrace = []runtime.Frame{}
+	var frames = runtimeCallersFrames(
+		baseAppError.getDetails().stack,
+	)
+	for {
+		var frame, more = frames.Next()
+		stackTrace = append(
+			stackTrace,
+			frame,
+		)
+		if !more {
+			return stackTrace
+		}
+	}
+}
@@ -0,0 +1,140 @@
+package apperror
+
+import (
+	"fmt"
+	"runtime"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestCaptureStack_Disabled(t *testing.T) {
+	// mock
+	createMock(t)
+
+	// SUT + act
+	SetStackCapture(false)
+	var result = captureStack()
+
+	// assert
+	assert.Nil(t, result)
+
+	// verify
+	verifyAll(t)
+}
+
+func TestCaptureStack_Enabled(t *testing.T) {
+	// mock
+	createMock(t)
+
+	// expect
+	runtimeCallersExpected = 1
+	runtimeCallers = func(skip int, pc []uintptr) int {
+		runtimeCallersCalled++
+		assert.Equal(t, stackSkippedFrames, skip)
+		assert.Equal(t, stackMaxDepth, len(pc))
+		pc[0], pc[1] = 1, 2
+		return 2
+	}
+
+	// SUT + act
+	SetStackCapture(true)
+	defer SetStackCapture(false)
+	var result = captureStack()
+
+	// assert
+	assert.Equal(t, []uintptr{1, 2}, result)
+
+	// verify
+	verifyAll(t)
+}
+
+func TestFormatStack(t *testing.T) {
+	// arrange
+	var dummyStack = make([]uintptr, 1)
+	runtime.Callers(1, dummyStack)
+	var dummyFrame, _ = runtime.CallersFrames(dummyStack).Next()
+	var dummyResult = "some result"
+
+	// mock
+	createMock(t)
+
+	// expect
+	runtimeCallersFramesExpected = 1
+	runtimeCallersFrames = func(callers []uintptr) *runtime.Frames {
+		runtimeCallersFramesCalled++
+		assert.Equal(t, dummyStack, callers)
+		return runtime.CallersFrames(callers)
+	}
+	fmtSprintfExpected = 1
+	fmtSprintf = func(format string, a ...interface{}) string {
+		fmtSprintfCalled++
+		assert.Equal(t, errorStackFrameFile, format)
+		assert.Equal(t, []interface{}{dummyFrame.File, dummyFrame.Line}, a)
+		return "some file line"
+	}
+	indentLinesFuncExpected = 3
+	indentLinesFunc = func(text string) string {
+		indentLinesFuncCalled++
+		return "indented " + text
+	}
+	stringsJoinExpected = 1
+	stringsJoin = func(a []string, sep string) string {
+		stringsJoinCalled++
+		assert.Equal(t, []string{
+			errorStackHeader,
+			"indented " + dummyFrame.Function,
+			"indented indented some file line",
+		}, a)
+		assert.Equal(t, errorTreeLineBreak, sep)
+		return dummyResult
+	}
+
+	// SUT + act
+	var result = formatStack(
+		dummyStack,
+	)
+
+	// assert
+	assert.Equal(t, dummyResult, result)
+
+	// verify
+	verifyAll(t)
+}
+
+func TestBaseAppError_StackTrace_NotCaptured(t *testing.T) {
+	// arrange
+	var sut1 *BaseAppError
+	var sut2 = &BaseAppError{}
+
+	// assert
+	assert.Nil(t, sut1.StackTrace())
+	assert.Nil(t, sut2.StackTrace())
+}
+
+func TestBaseAppError_Detail_StackTrace(t *testing.T) {
+	// arrange
+	SetInstanceIDGenerator(func() string { return "" })
+	defer SetInstanceIDGenerator(nil)
+	SetStackCapture(true)
+	defer SetStackCapture(false)
+
+	// act
+	var sut = NewBaseAppError(CodeNotFound, "some message")
+	var derived = sut.WithCause(nil)
+	var tree = sut.Detail()
+
+	// assert
+	var stackTrace = sut.StackTrace()
+	assert.NotEmpty(t, stackTrace)
+	assert.Equal(t, "github.com/zhongjie-cai/app-error.TestBaseAppError_Detail_StackTrace", stackTrace[0].Function)
+	assert.Equal(t, stackTrace, derived.StackTrace())
+	assert.True(t, strings.HasPrefix(tree, strings.Join([]string{
+		"(NotFound) some message",
+		"    stack:",
+		"        github.com/zhongjie-cai/app-error.TestBaseAppError_Detail_StackTrace",
+		fmt.Sprintf("            %v:%v", stackTrace[0].File, stackTrace[0].Line),
+	}, "\n")))
+	assert.Equal(t, "(NotFound) some message", sut.Error())
+}
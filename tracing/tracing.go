@@ -0,0 +1,302 @@
+// Package tracing records app errors on trace spans following the OpenTelemetry exception semantic conventions.
+//
+// The package does not depend on OpenTelemetry; it works against the minimal Span interface, which an OpenTelemetry span satisfies through a small adapter:
+//
+//	type otelSpan struct{ trace.Span }
+//
+//	func (span otelSpan) AddEvent(name string, attributes []tracing.Attribute) {
+//		var keyValues []attribute.KeyValue
+//		for _, attr := range attributes {
+//			keyValues = append(keyValues, attribute.String(attr.Key, fmt.Sprint(attr.Value)))
+//		}
+//		span.Span.AddEvent(name, trace.WithAttributes(keyValues...))
+//	}
+//
+//	func (span otelSpan) SetStatus(code tracing.StatusCode, description string) {
+//		span.Span.SetStatus(codes.Code(code), description)
+//	}
+//
+// RecordError adds one exception event for the error, followed by one event per inner error, linked to its parent through the apperror.event.index and apperror.event.parent attributes.
+// The stack trace of each event is the creation stack of its error when captured (see apperror.SetStackCapture); the root event falls back to the stack of the caller.
+// Nothing is recorded for a nil span or error.
+package tracing
+
+import (
+	"fmt"
+	"net/http"
+	"runtime"
+	"sort"
+	"strings"
+
+	apperror "github.com/zhongjie-cai/app-error"
+)
+
+// These are the names of the recorded event and attributes
+const (
+	EventName              = "exception"
+	AttributeType          = "exception.type"
+	AttributeMessage       = "exception.message"
+	AttributeStacktrace    = "exception.stacktrace"
+	AttributeHTTPStatus    = "http.response.status_code"
+	AttributeComponent     = "apperror.component"
+	AttributeEventIndex    = "apperror.event.index"
+	AttributeEventParent   = "apperror.event.parent"
+	AttributeDataKeyPrefix = "apperror.data."
+)
+
+// callerStackDepth bounds the number of frames of the caller stack recorded for errors without a captured creation stack
+const callerStackDepth = 64
+
+// StatusCode is the status of a span; its values match the OpenTelemetry codes.Code values
+type StatusCode uint32
+
+// These are the span status codes
+const (
+	StatusUnset StatusCode = iota
+	StatusError
+	StatusOK
+)
+
+// Attribute is a key-value pair recorded on a span event
+type Attribute struct {
+	Key   string
+	Value interface{}
+}
+
+// Span is the part of a trace span needed to record app errors
+type Span interface {
+	// AddEvent adds an event with the given name and attributes to the span
+	AddEvent(name string, attributes []Attribute)
+	// SetStatus sets the status of the span
+	SetStatus(code StatusCode, description string)
+}
+
+// StatusFromHTTPStatusCode returns StatusError for server errors (5xx) and StatusUnset otherwise, as client errors do not make a server span fail
+func StatusFromHTTPStatusCode(httpStatusCode int) StatusCode {
+	if httpStatusCode >= http.StatusInternalServerError {
+		return StatusError
+	}
+	return StatusUnset
+}
+
+// These interfaces cover both the v1 and the v2 app errors
+type (
+	coder interface {
+		Code() string
+	}
+	namer interface {
+		Name() string
+	}
+	messager interface {
+		Message() string
+	}
+	statusCoder interface {
+		HTTPStatusCode() int
+	}
+	componenter interface {
+		Component() string
+	}
+	innerErrorer interface {
+		InnerErrors() []error
+	}
+	attrser interface {
+		Attrs(f func(apperror.Attr) bool)
+	}
+	dataer interface {
+		Data() map[string]interface{}
+	}
+	stackTracer interface {
+		StackTrace() []runtime.Frame
+	}
+)
+
+func errorType(err error) string {
+	switch typedError := err.(type) {
+	case coder:
+		return typedError.Code()
+	case namer:
+		return typedError.Name()
+	}
+	return fmt.Sprintf("%T", err)
+}
+
+func errorMessage(err error) string {
+	if typedError, isTyped := err.(messager); isTyped {
+		return typedError.Message()
+	}
+	return err.Error()
+}
+
+func innerErrors(err error) []error {
+	switch typedError := err.(type) {
+	case innerErrorer:
+		return typedError.InnerErrors()
+	case interface{ Unwrap() []error }:
+		return typedError.Unwrap()
+	case interface{ Unwrap() error }:
+		if innerError := typedError.Unwrap(); innerError != nil {
+			return []error{innerError}
+		}
+	}
+	return nil
+}
+
+func dataAttributes(err error) []Attribute {
+	var attributes []Attribute
+	switch typedError := err.(type) {
+	case attrser:
+		typedError.Attrs(func(attr apperror.Attr) bool {
+			attributes = append(
+				attributes,
+				Attribute{
+					Key:   AttributeDataKeyPrefix + attr.Key,
+					Value: attr.Value.Any(),
+				},
+			)
+			return true
+		})
+	case dataer:
+		var data = typedError.Data()
+		var keys = make([]string, 0, len(data))
+		for key := range data {
+			keys = append(keys, key)
+		}
+		sort.Strings(keys)
+		for _, key := range keys {
+			attributes = append(
+				attributes,
+				Attribute{
+					Key:   AttributeDataKeyPrefix + key,
+					Value: data[key],
+				},
+			)
+		}
+	}
+	return attributes
+}
+
+// formatStack prints the frames in the layout of a Go stack trace, a function line followed by an indented file:line line per frame
+func formatStack(frames []runtime.Frame) string {
+	var builder strings.Builder
+	for _, frame := range frames {
+		fmt.Fprintf(&builder, "%s()\n\t%s:%d\n", frame.Function, frame.File, frame.Line)
+	}
+	return builder.String()
+}
+
+// creationStack returns the stack the error was created from, if it was captured (see apperror.SetStackCapture)
+func creationStack(err error) string {
+	if typedError, isTyped := err.(stackTracer); isTyped {
+		return formatStack(typedError.StackTrace())
+	}
+	return ""
+}
+
+// callerStack returns the stack of the caller of RecordError, without the frames of this package
+func callerStack() string {
+	var programCounters [callerStackDepth]uintptr
+	// skips runtime.Callers, callerStack and RecordError
+	var count = runtime.Callers(3, programCounters[:])
+	var frames = runtime.CallersFrames(programCounters[:count])
+	var stack []runtime.Frame
+	for {
+		var frame, more = frames.Next()
+		stack = append(stack, frame)
+		if !more {
+			return formatStack(stack)
+		}
+	}
+}
+
+// recorder numbers the events of one error tree so that inner error events can link to their parent event
+type recorder struct {
+	span      Span
+	nextIndex int
+}
+
+// attributedError returns the component and the attributed error of the wrappers created by apperror.FromComponent, which are recorded as the error they attribute; other errors are returned as they are
+func attributedError(err error) (string, error) {
+	var component string
+	if typedError, isTyped := err.(componenter); isTyped {
+		component = typedError.Component()
+	}
+	if _, isStatusCoder := err.(statusCoder); isStatusCoder {
+		return component, err
+	}
+	var wrapper, isWrapper = err.(interface{ Unwrap() error })
+	if component == "" || !isWrapper || wrapper.Unwrap() == nil {
+		return component, err
+	}
+	return component, wrapper.Unwrap()
+}
+
+func (recorder *recorder) record(err error, parentIndex int, fallbackStacktrace string) {
+	var component string
+	component, err = attributedError(err)
+	var stacktrace = creationStack(err)
+	if stacktrace == "" {
+		stacktrace = fallbackStacktrace
+	}
+	var index = recorder.nextIndex
+	recorder.nextIndex++
+	var attributes = []Attribute{
+		{Key: AttributeType, Value: errorType(err)},
+		{Key: AttributeMessage, Value: errorMessage(err)},
+		{Key: AttributeEventIndex, Value: index},
+	}
+	if parentIndex >= 0 {
+		attributes = append(
+			attributes,
+			Attribute{Key: AttributeEventParent, Value: parentIndex},
+		)
+	}
+	if stacktrace != "" {
+		attributes = append(
+			attributes,
+			Attribute{Key: AttributeStacktrace, Value: stacktrace},
+		)
+	}
+	if typedError, isTyped := err.(statusCoder); isTyped {
+		attributes = append(
+			attributes,
+			Attribute{Key: AttributeHTTPStatus, Value: typedError.HTTPStatusCode()},
+		)
+	}
+	if component != "" {
+		attributes = append(
+			attributes,
+			Attribute{Key: AttributeComponent, Value: component},
+		)
+	}
+	attributes = append(
+		attributes,
+		dataAttributes(err)...,
+	)
+	recorder.span.AddEvent(EventName, attributes)
+	for _, innerError := range innerErrors(err) {
+		if apperror.IsNil(innerError) {
+			continue
+		}
+		recorder.record(innerError, index, "")
+	}
+}
+
+// RecordError records the given error and its inner errors as exception events on the span and sets the span status from the error's HTTP status code
+func RecordError(span Span, err error) {
+	if span == nil || apperror.IsNil(err) {
+		return
+	}
+	var recorder = &recorder{
+		span: span,
+	}
+	recorder.record(err, -1, callerStack())
+	var httpStatusCode = http.StatusInternalServerError
+	if typedError, isTyped := err.(statusCoder); isTyped {
+		httpStatusCode = typedError.HTTPStatusCode()
+	}
+	var status = StatusFromHTTPStatusCode(httpStatusCode)
+	if status == StatusUnset {
+		return
+	}
+	span.SetStatus(status, errorMessage(err))
+}
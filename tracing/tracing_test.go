@@ -0,0 +1,188 @@
+package tracing
+
+import (
+	"errors"
+	"fmt"
+	"net/http"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	v1 "github.com/zhongjie-cai/app-error"
+	apperror "github.com/zhongjie-cai/app-error/v2"
+)
+
+type fakeEvent struct {
+	name       string
+	attributes map[string]interface{}
+}
+
+type fakeSpan struct {
+	events      []fakeEvent
+	status      StatusCode
+	description string
+}
+
+func (span *fakeSpan) AddEvent(name string, attributes []Attribute) {
+	var event = fakeEvent{name: name, attributes: map[string]interface{}{}}
+	for _, attribute := range attributes {
+		event.attributes[attribute.Key] = attribute.Value
+	}
+	span.events = append(span.events, event)
+}
+
+func (span *fakeSpan) SetStatus(code StatusCode, description string) {
+	span.status = code
+	span.description = description
+}
+
+func TestStatusFromHTTPStatusCode(t *testing.T) {
+	// assert
+	assert.Equal(t, StatusUnset, StatusFromHTTPStatusCode(http.StatusNotFound))
+	assert.Equal(t, StatusError, StatusFromHTTPStatusCode(http.StatusInternalServerError))
+	assert.Equal(t, StatusError, StatusFromHTTPStatusCode(http.StatusServiceUnavailable))
+}
+
+func TestRecordError_Nil(t *testing.T) {
+	// arrange
+	var span = &fakeSpan{}
+	var nilAppError *v1.BaseAppError
+
+	// act
+	RecordError(span, nil)
+	RecordError(span, nilAppError)
+	RecordError(nil, errors.New("some error"))
+
+	// assert
+	assert.Empty(t, span.events)
+	assert.Equal(t, StatusUnset, span.status)
+}
+
+func TestRecordError_Tree(t *testing.T) {
+	// arrange
+	var span = &fakeSpan{}
+	var inner = v1.NewBaseAppError(v1.CodeNotFound, "some inner message")
+	inner.Wrap(fmt.Errorf("some wrapper: %w", errors.New("some root cause")))
+	var sut = v1.NewBaseAppError(v1.CodeGeneralFailure, "some message")
+	sut.Attach("id", 42)
+	sut.AttachAttrs(v1.String("table", "orders"))
+	sut.Wrap(inner)
+	sut.WrapFrom("postgres", errors.New("some plain error"))
+
+	// act
+	RecordError(span, sut)
+
+	// assert
+	assert.Equal(t, StatusError, span.status)
+	assert.Equal(t, "some message", span.description)
+	assert.Len(t, span.events, 5)
+	for _, event := range span.events {
+		assert.Equal(t, "exception", event.name)
+	}
+	var root = span.events[0].attributes
+	assert.Equal(t, "GeneralFailure", root[AttributeType])
+	assert.Equal(t, "some message", root[AttributeMessage])
+	assert.Equal(t, http.StatusInternalServerError, root[AttributeHTTPStatus])
+	assert.Equal(t, 0, root[AttributeEventIndex])
+	assert.NotContains(t, root, AttributeEventParent)
+	assert.Contains(t, root[AttributeStacktrace], "TestRecordError_Tree")
+	assert.Equal(t, "orders", root["apperror.data.table"])
+	assert.Equal(t, int64(42), root["apperror.data.id"])
+	assert.Equal(t, map[string]interface{}{
+		AttributeType:        "NotFound",
+		AttributeMessage:     "some inner message",
+		AttributeHTTPStatus:  http.StatusNotFound,
+		AttributeEventIndex:  1,
+		AttributeEventParent: 0,
+	}, span.events[1].attributes)
+	assert.Equal(t, map[string]interface{}{
+		AttributeType:        "*fmt.wrapError",
+		AttributeMessage:     "some wrapper: some root cause",
+		AttributeEventIndex:  2,
+		AttributeEventParent: 1,
+	}, span.events[2].attributes)
+	assert.Equal(t, map[string]interface{}{
+		AttributeType:        "*errors.errorString",
+		AttributeMessage:     "some root cause",
+		AttributeEventIndex:  3,
+		AttributeEventParent: 2,
+	}, span.events[3].attributes)
+	assert.Equal(t, "some plain error", span.events[4].attributes[AttributeMessage])
+	assert.Equal(t, 0, span.events[4].attributes[AttributeEventParent])
+	assert.Equal(t, "postgres", span.events[4].attributes[AttributeComponent])
+	assert.Equal(t, "*errors.errorString", span.events[4].attributes[AttributeType])
+	assert.NotContains(t, root, AttributeComponent)
+}
+
+func TestRecordError_ClientError(t *testing.T) {
+	// arrange
+	var span = &fakeSpan{}
+
+	// act
+	RecordError(span, v1.GetBadRequestError())
+
+	// assert
+	assert.Len(t, span.events, 1)
+	assert.Equal(t, "BadRequest", span.events[0].attributes[AttributeType])
+	assert.Equal(t, StatusUnset, span.status)
+}
+
+func TestRecordError_PlainError(t *testing.T) {
+	// arrange
+	var span = &fakeSpan{}
+
+	// act
+	RecordError(span, errors.New("some error"))
+
+	// assert
+	assert.Len(t, span.events, 1)
+	assert.Equal(t, "*errors.errorString", span.events[0].attributes[AttributeType])
+	assert.Equal(t, StatusError, span.status)
+	assert.Equal(t, "some error", span.description)
+}
+
+func TestRecordError_V2(t *testing.T) {
+	// arrange
+	var span = &fakeSpan{}
+	var sut = apperror.New(apperror.CodeOperationLock).
+		Message("some locked message").
+		With("trip", "some trip").
+		Cause(errors.New("some cause")).
+		Build()
+
+	// act
+	RecordError(span, sut)
+
+	// assert
+	assert.Len(t, span.events, 2)
+	assert.Equal(t, "OperationLock", span.events[0].attributes[AttributeType])
+	assert.Equal(t, "some locked message", span.events[0].attributes[AttributeMessage])
+	assert.Equal(t, "some trip", span.events[0].attributes["apperror.data.trip"])
+	assert.Equal(t, "some cause", span.events[1].attributes[AttributeMessage])
+}
+
+func newCapturedError() *v1.BaseAppError {
+	return v1.NewBaseAppError(v1.CodeNotFound, "some captured message")
+}
+
+func TestRecordError_CreationStack(t *testing.T) {
+	// arrange
+	var span = &fakeSpan{}
+	v1.SetStackCapture(true)
+	var inner = newCapturedError()
+	v1.SetStackCapture(false)
+	var sut = v1.NewBaseAppError(v1.CodeGeneralFailure, "some message")
+	sut.Wrap(inner)
+
+	// act
+	RecordError(span, sut)
+
+	// assert
+	var root = span.events[0].attributes[AttributeStacktrace].(string)
+	var innerStack = span.events[1].attributes[AttributeStacktrace].(string)
+	assert.True(t, strings.HasPrefix(root, "github.com/zhongjie-cai/app-error/tracing.TestRecordError_CreationStack()\n"), root)
+	assert.NotContains(t, root, "tracing.RecordError")
+	assert.NotContains(t, root, "tracing.callerStack")
+	assert.True(t, strings.HasPrefix(innerStack, "github.com/zhongjie-cai/app-error/tracing.newCapturedError()\n"), innerStack)
+	assert.Contains(t, innerStack, "tracing_test.go:")
+}
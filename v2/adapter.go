@@ -0,0 +1,97 @@
+package apperror
+
+import (
+	v1 "github.com/zhongjie-cai/app-error"
+	"github.com/zhongjie-cai/app-error/internal/convert"
+)
+
+// v1CodeEnumer is implemented by v1 app errors exposing their code enum, e.g. *v1.BaseAppError
+type v1CodeEnumer interface {
+	CodeEnum() Code
+}
+
+// v1Messager is implemented by v1 app errors exposing their message, e.g. *v1.BaseAppError
+type v1Messager interface {
+	Message() string
+}
+
+// v1Componenter is implemented by v1 app errors attributed to a component, e.g. *v1.BaseAppError
+type v1Componenter interface {
+	Component() string
+}
+
+// v1Attrser is implemented by v1 app errors exposing their extra data as typed attributes, e.g. *v1.BaseAppError
+type v1Attrser interface {
+	Attrs(f func(Attr) bool)
+}
+
+// v1InstanceIDer is implemented by v1 app errors carrying an instance ID, e.g. *v1.BaseAppError
+type v1InstanceIDer interface {
+	InstanceID() string
+}
+
+// v1InnerErrorer is implemented by v1 app errors exposing their inner errors, e.g. *v1.BaseAppError
+type v1InnerErrorer interface {
+	InnerErrors() []error
+}
+
+// FromV1 converts the given v1 app error into a v2 *Error with the same code, message, extra data, component and inner errors (as causes); a plain nil is returned for a nil app error, including a nil *v1.BaseAppError, so the result can be returned as an error as is
+func FromV1(appError v1.AppError) AppError {
+	if v1.IsNil(appError) {
+		return nil
+	}
+	var builder *Builder
+	if codeEnumer, isCodeEnumer := appError.(v1CodeEnumer); isCodeEnumer {
+		builder = New(codeEnumer.CodeEnum())
+	} else if definition, isRegistered := LookupName(appError.Code()); isRegistered {
+		builder = New(definition.Code)
+	} else {
+		builder = New(CodeGeneralFailure)
+	}
+	if messager, isMessager := appError.(v1Messager); isMessager {
+		builder.Message("%s", messager.Message())
+	} else {
+		builder.Message("%s", appError.Error())
+	}
+	if innerErrorer, isInnerErrorer := appError.(v1InnerErrorer); isInnerErrorer {
+		builder.Cause(innerErrorer.InnerErrors()...)
+	}
+	if componenter, isComponenter := appError.(v1Componenter); isComponenter {
+		builder.Component(componenter.Component())
+	}
+	if attrser, isAttrser := appError.(v1Attrser); isAttrser {
+		attrser.Attrs(func(attr Attr) bool {
+			builder.WithAttrs(attr)
+			return true
+		})
+	}
+	var err = builder.build()
+	if instanceIDer, isInstanceIDer := appError.(v1InstanceIDer); isInstanceIDer {
+		err.instanceID = instanceIDer.InstanceID()
+	}
+	return err
+}
+
+// ToV1 converts the given v2 error into a v1 app error with the same code, message, extra data, component, instance ID and causes (as inner errors), without reporting it as created again; nil is returned for a nil error, including a nil *Error
+func ToV1(err AppError) v1.AppError {
+	if v1.IsNil(err) {
+		return nil
+	}
+	var component, instanceID string
+	if componenter, isComponenter := err.(v1Componenter); isComponenter {
+		component = componenter.Component()
+	}
+	if instanceIDer, isInstanceIDer := err.(v1InstanceIDer); isInstanceIDer {
+		instanceID = instanceIDer.InstanceID()
+	}
+	return convert.NewV1AppError(
+		convert.AppError{
+			Code:        int(err.Code()),
+			Message:     err.Message(),
+			InnerErrors: err.Unwrap(),
+			ExtraData:   err.Data(),
+			Component:   component,
+			InstanceID:  instanceID,
+		},
+	).(v1.AppError)
+}
@@ -0,0 +1,145 @@
+package apperror
+
+import (
+	"errors"
+	"net/http"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	v1 "github.com/zhongjie-cai/app-error"
+)
+
+func TestFromV1_Nil(t *testing.T) {
+	// assert
+	assert.Nil(t, FromV1(nil))
+}
+
+func TestFromV1_BaseAppError(t *testing.T) {
+	// arrange
+	var dummyInnerError = errors.New("some inner error")
+	var appError = v1.NewBaseAppError(CodeNotFound, "user %v not found", 42)
+	appError.Wrap(dummyInnerError)
+	appError.Attach("id", 42)
+	appError.AttachAttrs(v1.String("name", "bob"))
+	appError.SetComponent("users-db")
+
+	// act
+	var err = FromV1(appError).(*Error)
+
+	// assert
+	assert.Equal(t, CodeNotFound, err.Code())
+	assert.Equal(t, "user 42 not found", err.Message())
+	assert.Equal(t, []error{dummyInnerError}, err.Unwrap())
+	assert.Equal(t, map[string]interface{}{"id": int64(42), "name": "bob"}, err.Data())
+	assert.Equal(t, "users-db", err.Component())
+	assert.True(t, errors.Is(err, v1.ErrNotFound))
+	assert.True(t, errors.Is(err, dummyInnerError))
+}
+
+func TestToV1_Nil(t *testing.T) {
+	// assert
+	assert.Nil(t, ToV1(nil))
+}
+
+func TestToV1_Error(t *testing.T) {
+	// arrange
+	var dummyCause = errors.New("some cause")
+	var err = New(CodeBadRequest).Message("bad input").Cause(dummyCause).With("field", "name").Component("partner-api").Build()
+
+	// act
+	var appError = ToV1(err)
+
+	// assert
+	assert.Equal(t, "BadRequest", appError.Code())
+	assert.Equal(t, "bad input", appError.(*v1.BaseAppError).Message())
+	assert.True(t, appError.Contains(dummyCause))
+	assert.True(t, errors.Is(appError, v1.ErrBadRequest))
+	assert.Equal(t, "(BadRequest) bad input [ field = name ] [ some cause ]", appError.Error())
+	assert.Equal(t, "partner-api", appError.(*v1.BaseAppError).Component())
+	assert.Equal(t, "partner-api", ResponsibleComponent(appError))
+}
+
+func TestToV1_RegisteredCode(t *testing.T) {
+	// arrange
+	unregister(t, 3100)
+	MustRegister(Definition{Code: 3100, Name: "AdapterQuotaExceeded", HTTPStatusCode: http.StatusTooManyRequests})
+	var err = New(3100).Message("quota").Build()
+
+	// act
+	var appError = ToV1(err)
+	var problem = v1.NewProblem(appError)
+	var parsed, parseErr = v1.Parse(appError.Error())
+
+	// assert
+	assert.Equal(t, "(AdapterQuotaExceeded) quota", appError.Error())
+	assert.Equal(t, http.StatusTooManyRequests, appError.HTTPStatusCode())
+	assert.Equal(t, "AdapterQuotaExceeded", problem.Code)
+	assert.Equal(t, http.StatusTooManyRequests, problem.Status)
+	assert.NoError(t, parseErr)
+	assert.Equal(t, Code(3100), parsed.(*v1.BaseAppError).CodeEnum())
+}
+
+func TestRoundTrip_SameBinary(t *testing.T) {
+	// arrange
+	var original = New(CodeOperationLock).Message("locked").With("key", "k").Build()
+
+	// act
+	var roundTripped = FromV1(ToV1(original))
+
+	// assert
+	assert.Equal(t, original.Error(), roundTripped.Error())
+	assert.Equal(t, original.Data(), roundTripped.Data())
+}
+
+func TestAdapters_TypedNil(t *testing.T) {
+	// arrange
+	var nilV1 *v1.BaseAppError
+	var nilV2 *Error
+
+	// assert
+	assert.Nil(t, FromV1(nilV1))
+	assert.Nil(t, ToV1(nilV2))
+}
+
+func TestFromV1_NilAsError(t *testing.T) {
+	// arrange
+	var nilV1 *v1.BaseAppError
+
+	// act
+	var err error = FromV1(nilV1)
+
+	// assert
+	assert.True(t, err == nil)
+}
+
+func TestToV1_CountedOnce(t *testing.T) {
+	// arrange
+	var created = 0
+	v1.SetMetricsHook(func(event v1.MetricsEvent) {
+		if event.Kind == v1.MetricsEventCreated {
+			created++
+		}
+	})
+	defer v1.SetMetricsHook(nil)
+	var lifecycleEvents = 0
+	var handle = v1.RegisterObserver(func(event v1.LifecycleEvent) {
+		lifecycleEvents++
+	})
+	defer handle.Unregister()
+	v1.SetInstanceIDGenerator(func() string { return "some instance id" })
+	defer v1.SetInstanceIDGenerator(nil)
+	var err = New(CodeNotFound).Message("some message").With("id", 5).Cause(errors.New("some cause")).Build()
+	var builtEvents = lifecycleEvents
+
+	// act
+	var converted = ToV1(err)
+	var roundTripped = FromV1(converted)
+
+	// assert
+	assert.Equal(t, 1, created)
+	assert.Equal(t, builtEvents, lifecycleEvents)
+	assert.Equal(t, "some instance id", err.InstanceID())
+	assert.Equal(t, "some instance id", converted.(*v1.BaseAppError).InstanceID())
+	assert.Equal(t, "some instance id", roundTripped.(*Error).InstanceID())
+	assert.Equal(t, err.Error(), converted.Error())
+}
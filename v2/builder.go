@@ -0,0 +1,100 @@
+package apperror
+
+import (
+	"fmt"
+
+	v1 "github.com/zhongjie-cai/app-error"
+)
+
+// Builder builds immutable errors step by step
+type Builder struct {
+	code       Code
+	message    string
+	hasMessage bool
+	causes     []error
+	data       map[string]interface{}
+	component  string
+}
+
+// New starts building an error of the given code
+func New(code Code) *Builder {
+	return &Builder{
+		code: code,
+	}
+}
+
+// Message sets the message of the error; without it the registered default message of the code is used
+func (builder *Builder) Message(format string, parameters ...interface{}) *Builder {
+	builder.message = fmt.Sprintf(format, parameters...)
+	builder.hasMessage = true
+	return builder
+}
+
+// Cause adds the given causes to the error; nil causes are ignored
+func (builder *Builder) Cause(causes ...error) *Builder {
+	for _, cause := range causes {
+		if cause != nil {
+			builder.causes = append(builder.causes, cause)
+		}
+	}
+	return builder
+}
+
+// With adds/updates the given value to the extra data of the error by given name
+func (builder *Builder) With(name string, value interface{}) *Builder {
+	if builder.data == nil {
+		builder.data = map[string]interface{}{}
+	}
+	builder.data[name] = value
+	return builder
+}
+
+// WithAttrs adds/updates the given typed attributes to the extra data of the error
+func (builder *Builder) WithAttrs(attrs ...Attr) *Builder {
+	for _, attr := range attrs {
+		builder.With(attr.Key, attr.Value.Any())
+	}
+	return builder
+}
+
+// Component attributes the error to the named component, such as a database or a partner API
+func (builder *Builder) Component(component string) *Builder {
+	builder.component = component
+	return builder
+}
+
+// Build creates the error, reporting it to the v1 metrics hook as a created error; the builder can keep being used afterwards without affecting it
+func (builder *Builder) Build() *Error {
+	var err = builder.build()
+	err.instanceID = v1.NewInstanceID()
+	v1.EmitMetricsEvent(
+		v1.MetricsEvent{
+			Kind:           v1.MetricsEventCreated,
+			Code:           err.code,
+			HTTPStatusCode: err.HTTPStatusCode(),
+		},
+	)
+	return err
+}
+
+// build builds the error without reporting it to the metrics hook, for conversions of errors that were already reported
+func (builder *Builder) build() *Error {
+	var message = builder.message
+	if !builder.hasMessage {
+		message = resolve(builder.code).Message
+	}
+	var data map[string]interface{}
+	if len(builder.data) > 0 {
+		data = make(map[string]interface{}, len(builder.data))
+		for name, value := range builder.data {
+			data[name] = value
+		}
+	}
+	return &Error{
+		code:      builder.code,
+		message:   message,
+		causes:    append([]error(nil), builder.causes...),
+		data:      data,
+		component: builder.component,
+	}
+}
@@ -0,0 +1,49 @@
+package apperror
+
+import (
+	v1 "github.com/zhongjie-cai/app-error"
+)
+
+// Code is the error code enum; it is shared with v1 so that both versions agree on code values
+type Code = v1.Code
+
+// These are the built-in error codes, registered by default
+const (
+	CodeGeneralFailure   = v1.CodeGeneralFailure
+	CodeUnauthorized     = v1.CodeUnauthorized
+	CodeInvalidOperation = v1.CodeInvalidOperation
+	CodeBadRequest       = v1.CodeBadRequest
+	CodeNotFound         = v1.CodeNotFound
+	CodeCircuitBreak     = v1.CodeCircuitBreak
+	CodeOperationLock    = v1.CodeOperationLock
+	CodeAccessForbidden  = v1.CodeAccessForbidden
+	CodeDataCorruption   = v1.CodeDataCorruption
+	CodeNotImplemented   = v1.CodeNotImplemented
+)
+
+// FieldViolation is the v1 field violation error type, usable as a cause of v2 errors
+type FieldViolation = v1.FieldViolation
+
+// Attr is the v1 typed extra data attribute type
+type Attr = v1.Attr
+
+// Fault is the v1 fault classification of error codes, for SLO and error budget accounting
+type Fault = v1.Fault
+
+// These are the fault classifications
+const (
+	FaultUnspecified = v1.FaultUnspecified
+	FaultClient      = v1.FaultClient
+	FaultServer      = v1.FaultServer
+	FaultDependency  = v1.FaultDependency
+)
+
+// ResolveFault classifies a whole error tree of v1 and v2 errors; see the v1 ResolveFault
+func ResolveFault(err error) Fault {
+	return v1.ResolveFault(err)
+}
+
+// ResponsibleComponent returns the component ultimately responsible for a whole error tree of v1 and v2 errors; see the v1 ResponsibleComponent
+func ResponsibleComponent(err error) string {
+	return v1.ResponsibleComponent(err)
+}
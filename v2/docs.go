@@ -0,0 +1,215 @@
+package apperror
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"sort"
+	"strconv"
+	"strings"
+)
+
+// DocFormat is the output format of the error code documentation
+type DocFormat string
+
+// These are the supported documentation formats
+const (
+	// DocFormatMarkdown renders a Markdown table of the error codes
+	DocFormatMarkdown DocFormat = "markdown"
+	// DocFormatJSONSchema renders a JSON schema of the "code" member of problem responses
+	DocFormatJSONSchema DocFormat = "jsonschema"
+	// DocFormatOpenAPI renders OpenAPI 3 components (schemas and responses) for problem responses
+	DocFormatOpenAPI DocFormat = "openapi"
+)
+
+const (
+	contentTypeProblemJSON = "application/problem+json"
+	schemaRefErrorCode     = "#/components/schemas/ErrorCode"
+	schemaRefProblem       = "#/components/schemas/Problem"
+)
+
+// WriteDocs writes the documentation of all registered error codes in the given format
+func WriteDocs(w io.Writer, format DocFormat) error {
+	return WriteDefinitionDocs(w, format, Definitions())
+}
+
+// WriteDefinitionDocs writes the documentation of the given definitions in the given format
+func WriteDefinitionDocs(w io.Writer, format DocFormat, definitions []Definition) error {
+	switch format {
+	case DocFormatMarkdown:
+		return writeMarkdown(w, definitions)
+	case DocFormatJSONSchema:
+		return writeJSON(w, jsonSchema(definitions))
+	case DocFormatOpenAPI:
+		return writeJSON(w, openAPIComponents(definitions))
+	}
+	return fmt.Errorf("apperror: unknown doc format %q", format)
+}
+
+func escapeMarkdown(value string) string {
+	return strings.NewReplacer("|", "\\|", "\n", " ").Replace(value)
+}
+
+func writeMarkdown(w io.Writer, definitions []Definition) error {
+	var builder strings.Builder
+	builder.WriteString("| Name | Code | HTTP Status | Message | Severity | Retryable |\n")
+	builder.WriteString("| --- | ---: | --- | --- | --- | --- |\n")
+	for _, definition := range definitions {
+		fmt.Fprintf(
+			&builder,
+			"| %s | %d | %d %s | %s | %s | %t |\n",
+			escapeMarkdown(definition.Name),
+			int(definition.Code),
+			definition.HTTPStatusCode,
+			http.StatusText(definition.HTTPStatusCode),
+			escapeMarkdown(definition.Message),
+			escapeMarkdown(definition.Severity),
+			definition.Retryable,
+		)
+	}
+	var _, err = io.WriteString(w, builder.String())
+	return err
+}
+
+func writeJSON(w io.Writer, value interface{}) error {
+	var encoder = json.NewEncoder(w)
+	encoder.SetIndent("", "  ")
+	return encoder.Encode(value)
+}
+
+// orderedMap keeps the insertion order of its keys when marshalled, so that the documents are stable and readable
+type orderedMap struct {
+	keys   []string
+	values map[string]interface{}
+}
+
+func newOrderedMap() *orderedMap {
+	return &orderedMap{values: map[string]interface{}{}}
+}
+
+func (m *orderedMap) set(key string, value interface{}) *orderedMap {
+	if _, exists := m.values[key]; !exists {
+		m.keys = append(m.keys, key)
+	}
+	m.values[key] = value
+	return m
+}
+
+func (m *orderedMap) MarshalJSON() ([]byte, error) {
+	var builder strings.Builder
+	builder.WriteString("{")
+	for index, key := range m.keys {
+		if index > 0 {
+			builder.WriteString(",")
+		}
+		var name, _ = json.Marshal(key)
+		var value, err = json.Marshal(m.values[key])
+		if err != nil {
+			return nil, err
+		}
+		builder.Write(name)
+		builder.WriteString(":")
+		builder.Write(value)
+	}
+	builder.WriteString("}")
+	return []byte(builder.String()), nil
+}
+
+func codeSchema(definitions []Definition) *orderedMap {
+	var options = make([]interface{}, 0, len(definitions))
+	for _, definition := range definitions {
+		options = append(
+			options,
+			newOrderedMap().
+				set("const", definition.Name).
+				set("description", definition.Message).
+				set("x-code", int(definition.Code)).
+				set("x-http-status", definition.HTTPStatusCode).
+				set("x-severity", definition.Severity).
+				set("x-retryable", definition.Retryable),
+		)
+	}
+	return newOrderedMap().
+		set("title", "ErrorCode").
+		set("description", "The name of the error code carried in the \"code\" member of problem responses").
+		set("type", "string").
+		set("oneOf", options)
+}
+
+func jsonSchema(definitions []Definition) *orderedMap {
+	var schema = newOrderedMap().
+		set("$schema", "https://json-schema.org/draft/2020-12/schema").
+		set("$id", "ErrorCode")
+	var code = codeSchema(definitions)
+	for _, key := range code.keys {
+		schema.set(key, code.values[key])
+	}
+	return schema
+}
+
+func problemSchema() *orderedMap {
+	var property = func(schemaType string, description string) *orderedMap {
+		return newOrderedMap().set("type", schemaType).set("description", description)
+	}
+	var violation = newOrderedMap().
+		set("type", "object").
+		set("properties", newOrderedMap().
+			set("field", property("string", "The path of the invalid field")).
+			set("pointer", property("string", "The RFC 6901 JSON pointer of the invalid field")).
+			set("rule", property("string", "The rule the field violates")).
+			set("message", property("string", "The description of the violation")).
+			set("value", newOrderedMap().set("description", "The rejected value")))
+	return newOrderedMap().
+		set("type", "object").
+		set("description", "An RFC 7807 problem details response").
+		set("required", []string{"type", "title", "status", "code"}).
+		set("properties", newOrderedMap().
+			set("type", property("string", "A URI reference identifying the problem type")).
+			set("title", property("string", "The HTTP status text of the problem")).
+			set("status", property("integer", "The HTTP status code of the problem")).
+			set("detail", property("string", "The description of this occurrence of the problem")).
+			set("instance", property("string", "The unique ID of this occurrence of the problem, also sent in the X-Error-Instance-Id header")).
+			set("code", newOrderedMap().set("$ref", schemaRefErrorCode)).
+			set("violations", newOrderedMap().set("type", "array").set("items", violation)))
+}
+
+func openAPIComponents(definitions []Definition) *orderedMap {
+	var statuses []int
+	var byStatus = map[int][]Definition{}
+	for _, definition := range definitions {
+		if _, exists := byStatus[definition.HTTPStatusCode]; !exists {
+			statuses = append(statuses, definition.HTTPStatusCode)
+		}
+		byStatus[definition.HTTPStatusCode] = append(byStatus[definition.HTTPStatusCode], definition)
+	}
+	sort.Ints(statuses)
+	var responses = newOrderedMap()
+	for _, status := range statuses {
+		var names = make([]string, 0, len(byStatus[status]))
+		var examples = newOrderedMap()
+		for _, definition := range byStatus[status] {
+			names = append(names, definition.Name)
+			examples.set(definition.Name, newOrderedMap().
+				set("summary", definition.Message).
+				set("value", newOrderedMap().
+					set("type", "about:blank").
+					set("title", http.StatusText(status)).
+					set("status", status).
+					set("detail", definition.Message).
+					set("code", definition.Name)))
+		}
+		responses.set("Error"+strconv.Itoa(status), newOrderedMap().
+			set("description", fmt.Sprintf("%s: %s", http.StatusText(status), strings.Join(names, ", "))).
+			set("content", newOrderedMap().
+				set(contentTypeProblemJSON, newOrderedMap().
+					set("schema", newOrderedMap().set("$ref", schemaRefProblem)).
+					set("examples", examples))))
+	}
+	return newOrderedMap().
+		set("components", newOrderedMap().
+			set("schemas", newOrderedMap().
+				set("ErrorCode", codeSchema(definitions)).
+				set("Problem", problemSchema())).
+			set("responses", responses))
+}
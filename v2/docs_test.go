@@ -0,0 +1,110 @@
+package apperror
+
+import (
+	"bytes"
+	"encoding/json"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+var docDefinitions = []Definition{
+	{Code: 4, Name: "NotFound", HTTPStatusCode: 404, Message: "Not | found", Severity: "error"},
+	{Code: 1000, Name: "Gone", HTTPStatusCode: 404, Message: "Gone", Severity: "warning", Retryable: true},
+	{Code: 3, Name: "BadRequest", HTTPStatusCode: 400, Message: "Bad", Severity: "error"},
+}
+
+func TestWriteDefinitionDocs_Markdown(t *testing.T) {
+	// arrange
+	var buffer bytes.Buffer
+
+	// act
+	var err = WriteDefinitionDocs(&buffer, DocFormatMarkdown, docDefinitions)
+
+	// assert
+	assert.NoError(t, err)
+	assert.Equal(t, "| Name | Code | HTTP Status | Message | Severity | Retryable |\n"+
+		"| --- | ---: | --- | --- | --- | --- |\n"+
+		"| NotFound | 4 | 404 Not Found | Not \\| found | error | false |\n"+
+		"| Gone | 1000 | 404 Not Found | Gone | warning | true |\n"+
+		"| BadRequest | 3 | 400 Bad Request | Bad | error | false |\n", buffer.String())
+}
+
+func TestWriteDefinitionDocs_JSONSchema(t *testing.T) {
+	// arrange
+	var buffer bytes.Buffer
+	var schema map[string]interface{}
+
+	// act
+	var err = WriteDefinitionDocs(&buffer, DocFormatJSONSchema, docDefinitions[:1])
+
+	// assert
+	assert.NoError(t, err)
+	assert.NoError(t, json.Unmarshal(buffer.Bytes(), &schema))
+	assert.Equal(t, "https://json-schema.org/draft/2020-12/schema", schema["$schema"])
+	assert.Equal(t, "string", schema["type"])
+	assert.Equal(t, []interface{}{
+		map[string]interface{}{
+			"const":         "NotFound",
+			"description":   "Not | found",
+			"x-code":        float64(4),
+			"x-http-status": float64(404),
+			"x-severity":    "error",
+			"x-retryable":   false,
+		},
+	}, schema["oneOf"])
+	assert.Less(t, bytes.Index(buffer.Bytes(), []byte(`"$schema"`)), bytes.Index(buffer.Bytes(), []byte(`"oneOf"`)))
+}
+
+func TestWriteDefinitionDocs_OpenAPI(t *testing.T) {
+	// arrange
+	var buffer bytes.Buffer
+	var document struct {
+		Components struct {
+			Schemas   map[string]interface{} `json:"schemas"`
+			Responses map[string]struct {
+				Description string `json:"description"`
+				Content     map[string]struct {
+					Schema   map[string]string          `json:"schema"`
+					Examples map[string]json.RawMessage `json:"examples"`
+				} `json:"content"`
+			} `json:"responses"`
+		} `json:"components"`
+	}
+
+	// act
+	var err = WriteDefinitionDocs(&buffer, DocFormatOpenAPI, docDefinitions)
+
+	// assert
+	assert.NoError(t, err)
+	assert.NoError(t, json.Unmarshal(buffer.Bytes(), &document))
+	assert.Contains(t, document.Components.Schemas, "ErrorCode")
+	assert.Contains(t, document.Components.Schemas, "Problem")
+	assert.Len(t, document.Components.Responses, 2)
+	var notFound = document.Components.Responses["Error404"]
+	assert.Equal(t, "Not Found: NotFound, Gone", notFound.Description)
+	assert.Equal(t, "#/components/schemas/Problem", notFound.Content["application/problem+json"].Schema["$ref"])
+	assert.JSONEq(t, `{"summary": "Gone", "value": {"type": "about:blank", "title": "Not Found", "status": 404, "detail": "Gone", "code": "Gone"}}`,
+		string(notFound.Content["application/problem+json"].Examples["Gone"]))
+	assert.Less(t, bytes.Index(buffer.Bytes(), []byte(`"Error400"`)), bytes.Index(buffer.Bytes(), []byte(`"Error404"`)))
+}
+
+func TestWriteDocs_Registered(t *testing.T) {
+	// arrange
+	var buffer bytes.Buffer
+
+	// act
+	var err = WriteDocs(&buffer, DocFormatMarkdown)
+
+	// assert
+	assert.NoError(t, err)
+	assert.Contains(t, buffer.String(), "| NotImplemented | 9 | 501 Not Implemented |")
+}
+
+func TestWriteDocs_UnknownFormat(t *testing.T) {
+	// act
+	var err = WriteDocs(&bytes.Buffer{}, DocFormat("html"))
+
+	// assert
+	assert.EqualError(t, err, `apperror: unknown doc format "html"`)
+}
@@ -0,0 +1,171 @@
+package apperror
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+
+	v1 "github.com/zhongjie-cai/app-error"
+	"github.com/zhongjie-cai/app-error/internal/errortext"
+)
+
+// These are print formatting related constants, the same as v1's
+const (
+	errorMessageFormat   string = "(%v) %v%v" // (Code) Message [extra data]
+	errorExtraDataFormat string = "%v = %+v"  // name = value
+	errorJoiningFormat   string = " [ %v ]"   // [ content ]
+	errorSeparator       string = " | "
+	nilErrorText         string = "<nil>" // what fmt prints for a nil error
+)
+
+// AppError is the v2 error interface; unlike v1, its Code returns the enum, its data is read-only and its causes are exposed through Unwrap for errors.Is and errors.As
+type AppError interface {
+	error
+	// Code returns the error code enum
+	Code() Code
+	// Name returns the registered name of the error code
+	Name() string
+	// HTTPStatusCode returns the HTTP status code registered for the error code
+	HTTPStatusCode() int
+	// Message returns the message of the error, without its code, data or causes
+	Message() string
+	// Data returns a copy of the extra data of the error
+	Data() map[string]interface{}
+	// Unwrap returns the causes of the error
+	Unwrap() []error
+}
+
+// Error is the immutable implementation of AppError, created with New(...).Build(); all its methods are safe to call on a nil receiver, which acts as an empty general failure
+type Error struct {
+	code       Code
+	message    string
+	causes     []error
+	data       map[string]interface{}
+	component  string
+	instanceID string
+}
+
+// Code returns the error code enum
+func (err *Error) Code() Code {
+	if err == nil {
+		return CodeGeneralFailure
+	}
+	return err.code
+}
+
+// Name returns the registered name of the error code, or "Unknown" if it is not registered
+func (err *Error) Name() string {
+	return resolve(err.Code()).Name
+}
+
+// HTTPStatusCode returns the HTTP status code registered for the error code, or 500 if it is not registered
+func (err *Error) HTTPStatusCode() int {
+	return resolve(err.Code()).HTTPStatusCode
+}
+
+// Fault returns the fault registered for the error code, or FaultServer if it is not registered; see ResolveFault for the classification of a whole error tree
+func (err *Error) Fault() Fault {
+	return resolve(err.Code()).Fault
+}
+
+// Component returns the component the error is attributed to, if any; see ResponsibleComponent for the component ultimately responsible for a whole error tree
+func (err *Error) Component() string {
+	if err == nil {
+		return ""
+	}
+	return err.component
+}
+
+// InstanceID returns the unique ID the error was given when built, or carried over from the v1 app error it was converted from; see v1 SetInstanceIDGenerator
+func (err *Error) InstanceID() string {
+	if err == nil {
+		return ""
+	}
+	return err.instanceID
+}
+
+// Message returns the message of the error, without its code, data or causes
+func (err *Error) Message() string {
+	if err == nil {
+		return ""
+	}
+	return err.message
+}
+
+// Data returns a copy of the extra data of the error
+func (err *Error) Data() map[string]interface{} {
+	if err == nil {
+		return map[string]interface{}{}
+	}
+	var data = make(map[string]interface{}, len(err.data))
+	for name, value := range err.data {
+		data[name] = value
+	}
+	return data
+}
+
+// Unwrap returns the causes of the error
+func (err *Error) Unwrap() []error {
+	if err == nil {
+		return nil
+	}
+	return err.causes
+}
+
+// Is matches the v1 sentinel of the error's code, so that errors.Is(err, apperror.ErrNotFound) works across versions
+func (err *Error) Is(target error) bool {
+	if err == nil {
+		return false
+	}
+	return target == error(err.code.Sentinel())
+}
+
+// EscapesOwnText reports that the error escapes its own text in escaped print mode, so errors wrapping it leave that text as is
+func (err *Error) EscapesOwnText() bool {
+	return true
+}
+
+// Error prints the error in the v1 style "(Code) Message [Extra Data] [Causes]", with extra data sorted by name, honoring the v1 print settings
+func (err *Error) Error() string {
+	if err == nil {
+		return nilErrorText
+	}
+	var escape = func(text string) string { return text }
+	if v1.CurrentPrintMode() == v1.PrintModeEscaped {
+		escape = errortext.Escape
+	}
+	var instanceID = ""
+	if v1.CurrentPrintInstanceID() {
+		instanceID = err.instanceID
+	}
+	var extraData = ""
+	if len(err.data) > 0 || instanceID != "" {
+		var names = make([]string, 0, len(err.data))
+		for name := range err.data {
+			names = append(names, name)
+		}
+		sort.Strings(names)
+		var pairs = make([]string, 0, len(names)+1)
+		for _, name := range names {
+			pairs = append(pairs, fmt.Sprintf(errorExtraDataFormat, escape(name), escape(fmt.Sprintf("%+v", err.data[name]))))
+		}
+		if instanceID != "" {
+			pairs = append(pairs, fmt.Sprintf(errorExtraDataFormat, v1.InstanceIDDataName, escape(instanceID)))
+		}
+		extraData = fmt.Sprintf(errorJoiningFormat, strings.Join(pairs, errorSeparator))
+	}
+	var causes = ""
+	if len(err.causes) > 0 {
+		var messages = make([]string, 0, len(err.causes))
+		for _, cause := range err.causes {
+			if errortext.EscapesOwnText(cause) {
+				// app errors of either version escape their own text
+				messages = append(messages, cause.Error())
+			} else {
+				messages = append(messages, escape(cause.Error()))
+			}
+		}
+		causes = fmt.Sprintf(errorJoiningFormat, strings.Join(messages, errorSeparator))
+	}
+	return fmt.Sprintf(errorMessageFormat, err.Name(), escape(err.message), extraData) + causes
+}
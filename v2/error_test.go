@@ -0,0 +1,189 @@
+package apperror
+
+import (
+	"errors"
+	"fmt"
+	"net/http"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	v1 "github.com/zhongjie-cai/app-error"
+)
+
+func TestBuild_DefaultMessage(t *testing.T) {
+	// act
+	var err = New(CodeBadRequest).Build()
+
+	// assert
+	assert.Equal(t, CodeBadRequest, err.Code())
+	assert.Equal(t, "BadRequest", err.Name())
+	assert.Equal(t, http.StatusBadRequest, err.HTTPStatusCode())
+	assert.Equal(t, "Request URI or body is invalid", err.Message())
+	assert.Empty(t, err.Data())
+	assert.Empty(t, err.Unwrap())
+	assert.Equal(t, "(BadRequest) Request URI or body is invalid", err.Error())
+}
+
+func TestBuild_Full(t *testing.T) {
+	// arrange
+	var dummyCause = errors.New("some cause")
+	var builder = New(CodeNotFound).
+		Message("user %v not found", 42).
+		Cause(nil, dummyCause).
+		With("b", 2).
+		WithAttrs(v1.String("a", "x"))
+
+	// act
+	var err = builder.Build()
+	builder.With("c", 3)
+
+	// assert
+	assert.Equal(t, "(NotFound) user 42 not found [ a = x | b = 2 ] [ some cause ]", err.Error())
+	assert.Equal(t, []error{dummyCause}, err.Unwrap())
+	assert.Equal(t, map[string]interface{}{"a": "x", "b": 2}, err.Data())
+	assert.True(t, errors.Is(err, dummyCause))
+}
+
+func TestError_PrintInstanceID(t *testing.T) {
+	// arrange
+	v1.SetInstanceIDGenerator(func() string { return "some id" })
+	defer v1.SetInstanceIDGenerator(nil)
+	v1.SetPrintInstanceID(true)
+	defer v1.SetPrintInstanceID(false)
+	var err = New(CodeNotFound).Message("user not found").With("b", 2).Build()
+
+	// act
+	var result = err.Error()
+
+	// assert
+	assert.Equal(t, "(NotFound) user not found [ b = 2 | @instance = some id ]", result)
+}
+
+func TestBuild_UnknownCode(t *testing.T) {
+	// act
+	var err = New(Code(-5)).Build()
+
+	// assert
+	assert.Equal(t, "Unknown", err.Name())
+	assert.Equal(t, http.StatusInternalServerError, err.HTTPStatusCode())
+	assert.Equal(t, "(Unknown) An unknown error occurred", err.Error())
+}
+
+func TestError_DataIsCopy(t *testing.T) {
+	// arrange
+	var err = New(CodeGeneralFailure).With("a", 1).Build()
+
+	// act
+	err.Data()["a"] = 2
+
+	// assert
+	assert.Equal(t, 1, err.Data()["a"])
+}
+
+func TestError_IsV1Sentinel(t *testing.T) {
+	// arrange
+	var err = New(CodeNotFound).Build()
+	var wrapped = New(CodeGeneralFailure).Cause(err).Build()
+
+	// assert
+	assert.True(t, errors.Is(err, v1.ErrNotFound))
+	assert.True(t, errors.Is(wrapped, v1.ErrNotFound))
+	assert.True(t, errors.Is(wrapped, v1.ErrGeneralFailure))
+	assert.False(t, errors.Is(err, v1.ErrBadRequest))
+}
+
+func TestError_EscapedPrintMode(t *testing.T) {
+	// arrange
+	v1.SetPrintMode(v1.PrintModeEscaped)
+	defer v1.SetPrintMode(v1.PrintModeLegacy)
+	var inner = v1.NewBaseAppError(CodeNotFound, "x | y")
+	var err = New(CodeBadRequest).Message("a = b").With("k", "[v]").Cause(errors.New("p | q"), inner).Build()
+
+	// act
+	var text = err.Error()
+	var parsed, parseErr = v1.Parse(text)
+
+	// assert
+	assert.Equal(t, `(BadRequest) a \= b [ k = \[v\] ] [ p \| q | (NotFound) x \| y ]`, text)
+	assert.NoError(t, parseErr)
+	assert.Equal(t, text, parsed.Error())
+}
+
+func TestError_EscapedPrintMode_InsideV1Error(t *testing.T) {
+	// arrange
+	v1.SetPrintMode(v1.PrintModeEscaped)
+	defer v1.SetPrintMode(v1.PrintModeLegacy)
+	var inner = New(CodeNotFound).Message("a|b").Build()
+	var err = v1.NewBaseAppError(CodeBadRequest, "some message")
+	err.Wrap(inner)
+
+	// act
+	var text = err.Error()
+	var parsed, parseErr = v1.Parse(text)
+
+	// assert
+	assert.Equal(t, `(BadRequest) some message [ (NotFound) a\|b ]`, text)
+	assert.NoError(t, parseErr)
+	assert.True(t, errors.Is(parsed, v1.ErrNotFound))
+	assert.Equal(t, text, parsed.Error())
+}
+
+func TestError_EscapedPrintMode_AttributedInsideV1Error(t *testing.T) {
+	// arrange
+	v1.SetPrintMode(v1.PrintModeEscaped)
+	defer v1.SetPrintMode(v1.PrintModeLegacy)
+	var inner = New(CodeNotFound).Message("a|b").Build()
+	var err = v1.NewBaseAppError(CodeBadRequest, "some message")
+	err.WrapFrom("db", inner, errors.New("c|d"))
+
+	// act
+	var text = err.Error()
+	var parsed, parseErr = v1.Parse(text)
+
+	// assert
+	assert.Equal(t, `(BadRequest) some message [ (NotFound) a\|b | c\|d ]`, text)
+	assert.NoError(t, parseErr)
+	assert.True(t, errors.Is(parsed.(*v1.BaseAppError).InnerErrors()[0], v1.ErrNotFound))
+	assert.Equal(t, text, parsed.Error())
+}
+
+func TestBuild_EmitsCreatedEvent(t *testing.T) {
+	// arrange
+	var events []v1.MetricsEvent
+	v1.SetMetricsHook(func(event v1.MetricsEvent) {
+		events = append(events, event)
+	})
+	defer v1.SetMetricsHook(nil)
+
+	// act
+	var err = New(CodeNotFound).Build()
+	var converted = FromV1(v1.GetBadRequestError())
+
+	// assert
+	assert.NotNil(t, err)
+	assert.NotNil(t, converted)
+	assert.Equal(t, []v1.MetricsEvent{
+		{Kind: v1.MetricsEventCreated, Code: CodeNotFound, HTTPStatusCode: 404},
+		{Kind: v1.MetricsEventCreated, Code: CodeBadRequest, HTTPStatusCode: 400},
+	}, events)
+}
+
+func TestError_NilReceiver(t *testing.T) {
+	// arrange
+	var sut *Error
+	var err error = sut
+
+	// assert
+	assert.Equal(t, "<nil>", err.Error())
+	assert.Equal(t, "<nil>", fmt.Sprint(sut))
+	assert.Equal(t, CodeGeneralFailure, sut.Code())
+	assert.Equal(t, "GeneralFailure", sut.Name())
+	assert.Equal(t, http.StatusInternalServerError, sut.HTTPStatusCode())
+	assert.Equal(t, FaultServer, sut.Fault())
+	assert.Empty(t, sut.Component())
+	assert.Empty(t, sut.Message())
+	assert.Empty(t, sut.Data())
+	assert.Nil(t, sut.Unwrap())
+	assert.False(t, errors.Is(err, v1.ErrGeneralFailure))
+	assert.True(t, sut.EscapesOwnText())
+}
@@ -0,0 +1,156 @@
+package apperror
+
+import (
+	"fmt"
+	"net/http"
+	"sort"
+	"sync"
+
+	v1 "github.com/zhongjie-cai/app-error"
+)
+
+// Definition describes a registered error code
+type Definition struct {
+	// Code is the numeric value of the error code
+	Code Code
+	// Name is the unique string representation of the error code, e.g. "NotFound"
+	Name string
+	// HTTPStatusCode is the HTTP status code the error code maps to
+	HTTPStatusCode int
+	// Message is the default message of errors built with the error code
+	Message string
+	// GRPCCode is the gRPC status code the error code maps to; 0 (OK) means unmapped and callers should fall back to Unknown
+	GRPCCode uint32
+	// Severity is a free-form severity label, e.g. "warning" or "error"
+	Severity string
+	// Retryable tells whether an operation failing with the error code may succeed when retried
+	Retryable bool
+	// Fault tells whose fault errors with the error code are; when unspecified, it is client for 4xx HTTP status codes and server otherwise
+	Fault Fault
+}
+
+var (
+	registryLock   sync.RWMutex
+	registryCodes  = map[Code]Definition{}
+	registryNames  = map[string]Definition{}
+	unknownMessage = "An unknown error occurred"
+)
+
+// builtInGRPCCodes maps the built-in error codes to their gRPC status codes
+var builtInGRPCCodes = map[Code]uint32{
+	CodeGeneralFailure:   13, // Internal
+	CodeUnauthorized:     16, // Unauthenticated
+	CodeInvalidOperation: 9,  // FailedPrecondition
+	CodeBadRequest:       3,  // InvalidArgument
+	CodeNotFound:         5,  // NotFound
+	CodeCircuitBreak:     14, // Unavailable
+	CodeOperationLock:    10, // Aborted
+	CodeAccessForbidden:  7,  // PermissionDenied
+	CodeDataCorruption:   15, // DataLoss
+	CodeNotImplemented:   12, // Unimplemented
+}
+
+func init() {
+	for code := CodeGeneralFailure; code <= CodeNotImplemented; code++ {
+		var sentinel = code.Sentinel().(*v1.BaseAppError)
+		MustRegister(
+			Definition{
+				Code:           code,
+				Name:           code.String(),
+				HTTPStatusCode: code.HTTPStatusCode(),
+				Message:        sentinel.Message(),
+				GRPCCode:       builtInGRPCCodes[code],
+				Severity:       "error",
+				Retryable:      code == CodeCircuitBreak || code == CodeOperationLock,
+				Fault:          code.Fault(),
+			},
+		)
+	}
+}
+
+// defaultFault classifies the codes registered without a fault by their HTTP status code
+func defaultFault(httpStatusCode int) Fault {
+	if httpStatusCode >= http.StatusBadRequest && httpStatusCode < http.StatusInternalServerError {
+		return FaultClient
+	}
+	return FaultServer
+}
+
+// Register adds the given definition to the registry, also naming and classifying its code for the v1 Code.String, Code.HTTPStatusCode and Code.Fault; it fails if the code or the name is already registered
+func Register(definition Definition) error {
+	if definition.Name == "" {
+		return fmt.Errorf("apperror: code %d has no name", int(definition.Code))
+	}
+	registryLock.Lock()
+	defer registryLock.Unlock()
+	if existing, isRegistered := registryCodes[definition.Code]; isRegistered {
+		return fmt.Errorf("apperror: code %d is already registered as %q", int(definition.Code), existing.Name)
+	}
+	if existing, isRegistered := registryNames[definition.Name]; isRegistered {
+		return fmt.Errorf("apperror: name %q is already registered for code %d", definition.Name, int(existing.Code))
+	}
+	if definition.HTTPStatusCode == 0 {
+		definition.HTTPStatusCode = http.StatusInternalServerError
+	}
+	if definition.Fault == FaultUnspecified {
+		definition.Fault = defaultFault(definition.HTTPStatusCode)
+	}
+	v1.RegisterFault(definition.Code, definition.Fault)
+	v1.RegisterCode(definition.Code, definition.Name, definition.HTTPStatusCode)
+	registryCodes[definition.Code] = definition
+	registryNames[definition.Name] = definition
+	return nil
+}
+
+// MustRegister adds the given definitions to the registry, panicking if any of them cannot be registered
+func MustRegister(definitions ...Definition) {
+	for _, definition := range definitions {
+		if err := Register(definition); err != nil {
+			panic(err)
+		}
+	}
+}
+
+// Lookup returns the definition registered for the given code
+func Lookup(code Code) (Definition, bool) {
+	registryLock.RLock()
+	defer registryLock.RUnlock()
+	var definition, isRegistered = registryCodes[code]
+	return definition, isRegistered
+}
+
+// LookupName returns the definition registered under the given name
+func LookupName(name string) (Definition, bool) {
+	registryLock.RLock()
+	defer registryLock.RUnlock()
+	var definition, isRegistered = registryNames[name]
+	return definition, isRegistered
+}
+
+// Definitions returns all registered definitions ordered by code
+func Definitions() []Definition {
+	registryLock.RLock()
+	defer registryLock.RUnlock()
+	var definitions = make([]Definition, 0, len(registryCodes))
+	for _, definition := range registryCodes {
+		definitions = append(definitions, definition)
+	}
+	sort.Slice(definitions, func(i, j int) bool {
+		return definitions[i].Code < definitions[j].Code
+	})
+	return definitions
+}
+
+func resolve(code Code) Definition {
+	var definition, isRegistered = Lookup(code)
+	if !isRegistered {
+		return Definition{
+			Code:           code,
+			Name:           "Unknown",
+			HTTPStatusCode: http.StatusInternalServerError,
+			Message:        unknownMessage,
+			Fault:          FaultServer,
+		}
+	}
+	return definition
+}
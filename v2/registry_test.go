@@ -0,0 +1,130 @@
+package apperror
+
+import (
+	"net/http"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+// unregister removes the given codes from the registry once the test ends, so that the test can be run again in the same process
+func unregister(t *testing.T, codes ...Code) {
+	t.Cleanup(func() {
+		registryLock.Lock()
+		defer registryLock.Unlock()
+		for _, code := range codes {
+			delete(registryNames, registryCodes[code].Name)
+			delete(registryCodes, code)
+		}
+	})
+}
+
+func TestLookup_BuiltInCode(t *testing.T) {
+	// act
+	var definition, isRegistered = Lookup(CodeNotFound)
+
+	// assert
+	assert.True(t, isRegistered)
+	assert.Equal(t, CodeNotFound, definition.Code)
+	assert.Equal(t, "NotFound", definition.Name)
+	assert.Equal(t, http.StatusNotFound, definition.HTTPStatusCode)
+	assert.Equal(t, "Requested resource is not found in the storage", definition.Message)
+}
+
+func TestRegister_CustomCode(t *testing.T) {
+	// arrange
+	var dummyCode = Code(1001)
+	unregister(t, dummyCode)
+
+	// act
+	var err = Register(Definition{Code: dummyCode, Name: "QuotaExceeded", Message: "some message"})
+	var definition, isRegistered = LookupName("QuotaExceeded")
+
+	// assert
+	assert.NoError(t, err)
+	assert.True(t, isRegistered)
+	assert.Equal(t, dummyCode, definition.Code)
+	assert.Equal(t, http.StatusInternalServerError, definition.HTTPStatusCode)
+	assert.Contains(t, Definitions(), definition)
+}
+
+func TestRegister_Duplicates(t *testing.T) {
+	// act
+	var codeErr = Register(Definition{Code: CodeNotFound, Name: "SomethingElse"})
+	var nameErr = Register(Definition{Code: Code(1002), Name: "NotFound"})
+	var emptyErr = Register(Definition{Code: Code(1003)})
+
+	// assert
+	assert.EqualError(t, codeErr, `apperror: code 4 is already registered as "NotFound"`)
+	assert.EqualError(t, nameErr, `apperror: name "NotFound" is already registered for code 4`)
+	assert.EqualError(t, emptyErr, "apperror: code 1003 has no name")
+	assert.Panics(t, func() { MustRegister(Definition{Code: CodeNotFound, Name: "NotFound"}) })
+}
+
+func TestDefinitions_Ordered(t *testing.T) {
+	// act
+	var definitions = Definitions()
+
+	// assert
+	for index := 1; index < len(definitions); index++ {
+		assert.Less(t, definitions[index-1].Code, definitions[index].Code)
+	}
+}
+
+func TestLookup_BuiltInClassification(t *testing.T) {
+	// act
+	var notFound, _ = Lookup(CodeNotFound)
+	var circuitBreak, _ = Lookup(CodeCircuitBreak)
+
+	// assert
+	assert.Equal(t, uint32(5), notFound.GRPCCode)
+	assert.Equal(t, "error", notFound.Severity)
+	assert.False(t, notFound.Retryable)
+	assert.Equal(t, uint32(14), circuitBreak.GRPCCode)
+	assert.True(t, circuitBreak.Retryable)
+}
+
+func TestLookup_BuiltInFault(t *testing.T) {
+	// act
+	var notFound, _ = Lookup(CodeNotFound)
+	var circuitBreak, _ = Lookup(CodeCircuitBreak)
+	var dataCorruption, _ = Lookup(CodeDataCorruption)
+
+	// assert
+	assert.Equal(t, FaultClient, notFound.Fault)
+	assert.Equal(t, FaultDependency, circuitBreak.Fault)
+	assert.Equal(t, FaultServer, dataCorruption.Fault)
+}
+
+func TestRegister_Fault(t *testing.T) {
+	// arrange
+	var explicitCode = Code(1004)
+	var clientCode = Code(1005)
+	var serverCode = Code(1006)
+	unregister(t, explicitCode, clientCode, serverCode)
+
+	// act
+	MustRegister(
+		Definition{Code: explicitCode, Name: "UpstreamTimeout", HTTPStatusCode: http.StatusGatewayTimeout, Fault: FaultDependency},
+		Definition{Code: clientCode, Name: "QuotaExhausted", HTTPStatusCode: http.StatusTooManyRequests},
+		Definition{Code: serverCode, Name: "CacheBroken"},
+	)
+
+	// assert
+	assert.Equal(t, FaultDependency, resolve(explicitCode).Fault)
+	assert.Equal(t, FaultClient, resolve(clientCode).Fault)
+	assert.Equal(t, FaultServer, resolve(serverCode).Fault)
+	assert.Equal(t, FaultServer, resolve(Code(1999)).Fault)
+	assert.Equal(t, FaultDependency, explicitCode.Fault())
+	assert.Equal(t, FaultClient, clientCode.Fault())
+	assert.Equal(t, FaultDependency, New(explicitCode).Build().Fault())
+}
+
+func TestResolveFault_MixedVersions(t *testing.T) {
+	// arrange
+	var sut = New(CodeGeneralFailure).Cause(New(CodeCircuitBreak).Build()).Build()
+
+	// assert
+	assert.Equal(t, FaultDependency, ResolveFault(sut))
+	assert.Equal(t, FaultClient, ResolveFault(ToV1(New(CodeBadRequest).Build())))
+}
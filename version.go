@@ -0,0 +1,56 @@
+package apperror
+
+import (
+	"errors"
+	"fmt"
+	"reflect"
+)
+
+// immutableErrorTypes are the error types whose text never changes on its own, only through the errors they wrap, if any
+var immutableErrorTypes = map[reflect.Type]bool{
+	reflect.TypeOf(errors.New("")):                                     true,
+	reflect.TypeOf(fmt.Errorf("%w", errors.New(""))):                   true,
+	reflect.TypeOf(fmt.Errorf("%w%w", errors.New(""), errors.New(""))): true,
+	reflect.TypeOf(errors.Join(errors.New(""))):                        true,
+	reflect.TypeOf(&componentError{}):                                  true,
+	reflect.TypeOf(new(messageText)):                                   true,
+}
+
+func (baseAppError *BaseAppError) sumVersions() (uint64, bool) {
+	if baseAppError == nil {
+		return 0, true
+	}
+	var version, isVersioned = sumTreeVersions(baseAppError.innerErrors)
+	return version + baseAppError.getCache().version.Load(), isVersioned
+}
+
+// treeVersion sums the versions of the given error and all app errors nested in it, and tells whether the tree is versioned as a whole
+func treeVersion(err error) (uint64, bool) {
+	if err == nil {
+		return 0, true
+	}
+	if typedError, isTyped := err.(*BaseAppError); isTyped {
+		// only the app error itself: a type embedding it may override Error() with text no version tracks
+		return typedError.sumVersions()
+	}
+	if !immutableErrorTypes[reflect.TypeOf(err)] {
+		return 0, false
+	}
+	switch typedError := err.(type) {
+	case interface{ Unwrap() error }:
+		return treeVersion(typedError.Unwrap())
+	case interface{ Unwrap() []error }:
+		return sumTreeVersions(typedError.Unwrap())
+	}
+	return 0, true
+}
+
+func sumTreeVersions(errs []error) (uint64, bool) {
+	var version, isVersioned = uint64(0), true
+	for _, err := range errs {
+		var innerVersion, isInnerVersioned = treeVersion(err)
+		version += innerVersion
+		isVersioned = isVersioned && isInnerVersioned
+	}
+	return version, isVersioned
+}
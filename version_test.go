@@ -0,0 +1,271 @@
+package apperror
+
+import (
+	"errors"
+	"fmt"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestTreeVersion_Nil(t *testing.T) {
+	// arrange
+	var dummyBaseAppError *BaseAppError
+
+	// mock
+	createMock(t)
+
+	// SUT + act
+	var result1, isVersioned1 = treeVersion(nil)
+	var result2, isVersioned2 = treeVersion(dummyBaseAppError)
+
+	// assert
+	assert.Zero(t, result1)
+	assert.True(t, isVersioned1)
+	assert.Zero(t, result2)
+	assert.True(t, isVersioned2)
+
+	// verify
+	verifyAll(t)
+}
+
+func TestTreeVersion_PlainError(t *testing.T) {
+	// arrange
+	var dummyError = errors.New("some error")
+
+	// mock
+	createMock(t)
+
+	// SUT + act
+	var result, isVersioned = treeVersion(dummyError)
+
+	// assert
+	assert.Zero(t, result)
+	assert.True(t, isVersioned)
+
+	// verify
+	verifyAll(t)
+}
+
+func TestTreeVersion_NestedErrors(t *testing.T) {
+	// arrange
+	var dummyInnerError1 = &BaseAppError{}
+	dummyInnerError1.getCache().version.Store(2)
+	var dummyInnerError2 = &BaseAppError{}
+	dummyInnerError2.getCache().version.Store(3)
+	var dummyInnerError3 = &BaseAppError{}
+	dummyInnerError3.getCache().version.Store(5)
+	var dummyInnerError4 = &BaseAppError{}
+	dummyInnerError4.getCache().version.Store(7)
+	var dummyBaseAppError = &BaseAppError{
+		innerErrors: []error{
+			errors.New("some error"),
+			dummyInnerError1,
+			fmt.Errorf("some wrapper: %w", dummyInnerError2),
+			errors.Join(dummyInnerError3, dummyInnerError4),
+		},
+	}
+	dummyBaseAppError.getCache().version.Store(11)
+
+	// mock
+	createMock(t)
+
+	// SUT + act
+	var result, isVersioned = treeVersion(dummyBaseAppError)
+
+	// assert
+	assert.Equal(t, uint64(28), result)
+	assert.True(t, isVersioned)
+
+	// verify
+	verifyAll(t)
+}
+
+func TestBaseAppError_Error_InvalidatedByNestedChange(t *testing.T) {
+	// arrange
+	var dummyLeaf = NewBaseAppError(CodeNotFound, "some leaf")
+	var dummyMiddle = NewBaseAppError(CodeBadRequest, "some middle")
+	dummyMiddle.Wrap(&componentError{component: "some component", err: dummyLeaf})
+	var sut = NewBaseAppError(CodeGeneralFailure, "some root")
+	sut.Wrap(dummyMiddle)
+	var before = sut.Error()
+
+	// act
+	dummyLeaf.Attach("id", 5)
+	var afterAttach = sut.Error()
+	dummyLeaf.Wrap(errors.New("some cause"))
+	var afterWrap = sut.Error()
+
+	// assert
+	assert.Equal(t, "(GeneralFailure) some root [ (BadRequest) some middle [ (NotFound) some leaf ] ]", before)
+	assert.Equal(t, "(GeneralFailure) some root [ (BadRequest) some middle [ (NotFound) some leaf [ id = 5 ] ] ]", afterAttach)
+	assert.Equal(t, "(GeneralFailure) some root [ (BadRequest) some middle [ (NotFound) some leaf [ id = 5 ] [ some cause ] ] ]", afterWrap)
+}
+
+type embeddingAppError struct {
+	*BaseAppError
+}
+
+type innerErrorsAppError struct {
+	innerErrors []error
+}
+
+func (err innerErrorsAppError) Error() string {
+	return "some inner errors app error"
+}
+
+func (err innerErrorsAppError) InnerErrors() []error {
+	return err.innerErrors
+}
+
+func TestTreeVersion_EmbeddedAndUntrackedErrors(t *testing.T) {
+	// arrange
+	var dummyEmbedded = &BaseAppError{}
+	dummyEmbedded.getCache().version.Store(2)
+	var dummyInnerError = &BaseAppError{}
+	dummyInnerError.getCache().version.Store(3)
+
+	// mock
+	createMock(t)
+
+	// SUT + act
+	var _, isVersioned1 = treeVersion(embeddingAppError{dummyEmbedded})
+	var _, isVersioned2 = treeVersion(innerErrorsAppError{[]error{dummyInnerError, errors.New("some error")}})
+	var _, isVersioned3 = treeVersion(fmt.Errorf("some wrapper: %w", innerErrorsAppError{}))
+
+	// assert
+	assert.False(t, isVersioned1)
+	assert.False(t, isVersioned2)
+	assert.False(t, isVersioned3)
+
+	// verify
+	verifyAll(t)
+}
+
+func TestBaseAppError_Error_InvalidatedByEmbeddedChange(t *testing.T) {
+	// arrange
+	var dummyInner = embeddingAppError{NewBaseAppError(CodeNotFound, "some inner")}
+	var sut = NewBaseAppError(CodeGeneralFailure, "some root")
+	sut.Wrap(dummyInner)
+	var before = sut.Error()
+
+	// act
+	dummyInner.Attach("id", 5)
+	var after = sut.Error()
+
+	// assert
+	assert.Equal(t, "(GeneralFailure) some root [ (NotFound) some inner ]", before)
+	assert.Equal(t, "(GeneralFailure) some root [ (NotFound) some inner [ id = 5 ] ]", after)
+}
+
+// overridingAppError embeds an app error and overrides its Error() with a field no version tracks
+type overridingAppError struct {
+	*BaseAppError
+	Detail string
+}
+
+func (err *overridingAppError) Error() string {
+	return err.BaseAppError.Error() + " detail=" + err.Detail
+}
+
+func TestBaseAppError_Error_RenderedAgainWithOverridingEmbedder(t *testing.T) {
+	// arrange
+	var dummyInner = &overridingAppError{
+		BaseAppError: NewBaseAppError(CodeNotFound, "some inner"),
+		Detail:       "a",
+	}
+	var sut = GetGeneralFailureError(dummyInner)
+	var before = sut.Error()
+
+	// act
+	dummyInner.Detail = "b"
+	var after = sut.Error()
+
+	// assert
+	assert.Equal(t, "(GeneralFailure) An error occurred during execution [ (NotFound) some inner detail=a ]", before)
+	assert.Equal(t, "(GeneralFailure) An error occurred during execution [ (NotFound) some inner detail=b ]", after)
+}
+
+// customAppError is an AppError implemented outside the package, with its own mutable inner errors and extra data
+type customAppError struct {
+	innerErrors []error
+	extraData   []string
+}
+
+func (err *customAppError) Error() string {
+	var text = "some custom error"
+	for _, data := range err.extraData {
+		text += " " + data
+	}
+	for _, innerError := range err.innerErrors {
+		text += " / " + innerError.Error()
+	}
+	return text
+}
+func (err *customAppError) PrintError(code Code, e error, extraData map[string]interface{}) string {
+	return err.Error()
+}
+func (err *customAppError) Code() string               { return "GeneralFailure" }
+func (err *customAppError) HTTPStatusCode() int        { return 500 }
+func (err *customAppError) Contains(target error) bool { return false }
+func (err *customAppError) Wrap(innerErrors ...error) {
+	err.innerErrors = append(err.innerErrors, innerErrors...)
+}
+func (err *customAppError) Attach(name string, value interface{}) {
+	err.extraData = append(err.extraData, fmt.Sprintf("%v=%v", name, value))
+}
+
+func TestBaseAppError_Error_RenderedAgainWithCustomAppError(t *testing.T) {
+	// arrange
+	var dummyCustom = &customAppError{}
+	var sut = GetGeneralFailureError(dummyCustom)
+	var before = sut.Error()
+
+	// act
+	dummyCustom.Attach("id", 5)
+	var afterAttach = sut.Error()
+	dummyCustom.Wrap(errors.New("some cause"))
+	var afterWrap = sut.Error()
+
+	// assert
+	assert.Equal(t, "(GeneralFailure) An error occurred during execution [ some custom error ]", before)
+	assert.Equal(t, "(GeneralFailure) An error occurred during execution [ some custom error id=5 ]", afterAttach)
+	assert.Equal(t, "(GeneralFailure) An error occurred during execution [ some custom error id=5 / some cause ]", afterWrap)
+}
+
+func TestBaseAppError_Error_InvalidatedByPrintMode(t *testing.T) {
+	// arrange
+	var sut = NewBaseAppError(CodeNotFound, "some [message]")
+	sut.Wrap(errors.New("some | cause"))
+	var before = sut.Error()
+
+	// act
+	SetPrintMode(PrintModeEscaped)
+	defer SetPrintMode(PrintModeLegacy)
+	var after = sut.Error()
+
+	// assert
+	assert.Equal(t, "(NotFound) some [message] [ some | cause ]", before)
+	assert.NotEqual(t, before, after)
+}
+
+func TestBaseAppError_Error_ConcurrentOnFrozenError(t *testing.T) {
+	// arrange
+	var sut = NewBaseAppError(CodeNotFound, "some message")
+	sut.Attach("id", 5)
+	sut.Wrap(errors.New("some cause"))
+	sut.Freeze()
+	var results = make(chan string, 8)
+
+	// act
+	for i := 0; i < cap(results); i++ {
+		go func() {
+			results <- sut.Error()
+		}()
+	}
+
+	// assert
+	for i := 0; i < cap(results); i++ {
+		assert.Equal(t, "(NotFound) some message [ id = 5 ] [ some cause ]", <-results)
+	}
+}
@@ -0,0 +1,204 @@
+package apperror
+
+import (
+	"reflect"
+	"sort"
+	"strings"
+)
+
+// FieldViolation is an error describing a field whose value is rejected by a validation rule; wrap it into an app error to report it to clients
+type FieldViolation struct {
+	// Field is the path of the rejected field, e.g. "items[3].price"
+	Field string
+	// Rule is the name of the validation rule that rejected the field, e.g. "required"
+	Rule string
+	// Message is the human readable description of the violation
+	Message string
+	// Value is the rejected value
+	Value interface{}
+	// Cause is the error the violation was converted from, if any, kept for errors.Is and errors.As
+	Cause error
+}
+
+type fieldViolationJSON struct {
+	Field   string      `json:"field"`
+	Pointer string      `json:"pointer"`
+	Rule    string      `json:"rule,omitempty"`
+	Message string      `json:"message"`
+	Value   interface{} `json:"value,omitempty"`
+}
+
+// validatorFieldError is the shape of field errors returned by github.com/go-playground/validator
+type validatorFieldError interface {
+	error
+	Namespace() string
+	Field() string
+	Tag() string
+	Value() interface{}
+}
+
+// Error prints the field violation as "Field : Message [ rule = Rule | value = Value ]"
+func (fieldViolation FieldViolation) Error() string {
+	var details = []string{}
+	if fieldViolation.Rule != "" {
+		details = append(
+			details,
+			fmtSprintf(
+				errorExtraDataFormat,
+				"rule",
+				fieldViolation.Rule,
+			),
+		)
+	}
+	if fieldViolation.Value != nil {
+		details = append(
+			details,
+			fmtSprintf(
+				errorExtraDataFormat,
+				"value",
+				fieldViolation.Value,
+			),
+		)
+	}
+	var detailMessage = ""
+	if len(details) > 0 {
+		detailMessage = fmtSprintf(
+			errorJoiningFormat,
+			stringsJoin(
+				details,
+				errorSeparator,
+			),
+		)
+	}
+	return fmtSprint(
+		fieldViolation.Field,
+		errorPointer,
+		fieldViolation.Message,
+		detailMessage,
+	)
+}
+
+// Unwrap returns the error the field violation was converted from, if any
+func (fieldViolation FieldViolation) Unwrap() error {
+	return fieldViolation.Cause
+}
+
+// Pointer returns the field path as a RFC 6901 JSON pointer, e.g. "items[3].price" becomes "/items/3/price"
+func (fieldViolation FieldViolation) Pointer() string {
+	if fieldViolation.Field == "" {
+		return ""
+	}
+	var tokens = strings.FieldsFunc(
+		fieldViolation.Field,
+		func(r rune) bool {
+			return r == '.' || r == '[' || r == ']'
+		},
+	)
+	var pointer = ""
+	for _, token := range tokens {
+		token = strings.ReplaceAll(token, "~", "~0")
+		token = strings.ReplaceAll(token, "/", "~1")
+		pointer += "/" + token
+	}
+	return pointer
+}
+
+// MarshalJSON renders the field violation as a JSON object including its JSON pointer
+func (fieldViolation FieldViolation) MarshalJSON() ([]byte, error) {
+	return jsonMarshal(
+		fieldViolationJSON{
+			Field:   fieldViolation.Field,
+			Pointer: fieldViolation.Pointer(),
+			Rule:    fieldViolation.Rule,
+			Message: fieldViolation.Message,
+			Value:   fieldViolation.Value,
+		},
+	)
+}
+
+func trimNamespace(namespace string) string {
+	var index = strings.Index(namespace, ".")
+	if index < 0 {
+		return namespace
+	}
+	return namespace[index+1:]
+}
+
+func joinFieldPath(prefix string, field string) string {
+	if prefix == "" {
+		return field
+	}
+	if field == "" || strings.HasPrefix(field, "[") {
+		return prefix + field
+	}
+	return prefix + "." + field
+}
+
+func convertFieldViolations(prefix string, err error) []FieldViolation {
+	switch typedError := err.(type) {
+	case nil:
+		return nil
+	case FieldViolation:
+		typedError.Field = joinFieldPathFunc(prefix, typedError.Field)
+		return []FieldViolation{typedError}
+	case validatorFieldError:
+		return []FieldViolation{
+			{
+				Field:   joinFieldPathFunc(prefix, trimNamespaceFunc(typedError.Namespace())),
+				Rule:    typedError.Tag(),
+				Message: typedError.Error(),
+				Value:   typedError.Value(),
+				Cause:   typedError,
+			},
+		}
+	}
+	var fieldViolations []FieldViolation
+	var value = reflect.ValueOf(err)
+	switch value.Kind() {
+	case reflect.Slice:
+		for index := 0; index < value.Len(); index++ {
+			var item, isError = value.Index(index).Interface().(error)
+			if isError {
+				fieldViolations = append(
+					fieldViolations,
+					convertFieldViolations(prefix, item)...,
+				)
+			}
+		}
+	case reflect.Map:
+		if value.Type().Key().Kind() != reflect.String {
+			break
+		}
+		var keys = value.MapKeys()
+		sort.Slice(keys, func(i, j int) bool {
+			return keys[i].String() < keys[j].String()
+		})
+		for _, key := range keys {
+			var item, isError = value.MapIndex(key).Interface().(error)
+			if isError {
+				fieldViolations = append(
+					fieldViolations,
+					convertFieldViolations(joinFieldPathFunc(prefix, key.String()), item)...,
+				)
+			}
+		}
+	default:
+		fieldViolations = append(
+			fieldViolations,
+			FieldViolation{
+				Field:   prefix,
+				Message: err.Error(),
+				Cause:   err,
+			},
+		)
+	}
+	return fieldViolations
+}
+
+// NewFieldViolations converts the given validation error into field violations; it understands the field errors (and their slices) of github.com/go-playground/validator, the field-keyed error maps of github.com/go-ozzo/ozzo-validation, and FieldViolation itself
+func NewFieldViolations(err error) []FieldViolation {
+	return convertFieldViolationsFunc(
+		"",
+		err,
+	)
+}
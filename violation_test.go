@@ -0,0 +1,470 @@
+package apperror
+
+import (
+	"errors"
+	"math/rand"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+type dummyValidatorFieldError struct {
+	namespace string
+	tag       string
+	value     interface{}
+}
+
+func (dummyValidatorFieldError dummyValidatorFieldError) Error() string {
+	return "some validator error"
+}
+
+func (dummyValidatorFieldError dummyValidatorFieldError) Namespace() string {
+	return dummyValidatorFieldError.namespace
+}
+
+func (dummyValidatorFieldError dummyValidatorFieldError) Field() string {
+	return "some field"
+}
+
+func (dummyValidatorFieldError dummyValidatorFieldError) Tag() string {
+	return dummyValidatorFieldError.tag
+}
+
+func (dummyValidatorFieldError dummyValidatorFieldError) Value() interface{} {
+	return dummyValidatorFieldError.value
+}
+
+type dummyValidatorErrors []dummyValidatorFieldError
+
+func (dummyValidatorErrors dummyValidatorErrors) Error() string {
+	return "some validator errors"
+}
+
+type dummyFieldErrorMap map[string]error
+
+func (dummyFieldErrorMap dummyFieldErrorMap) Error() string {
+	return "some field error map"
+}
+
+func TestFieldViolation_Error_NoDetails(t *testing.T) {
+	// arrange
+	var dummyField = "some field"
+	var dummyMessage = "some message"
+	var dummyResult = "some result"
+
+	// mock
+	createMock(t)
+
+	// expect
+	fmtSprintExpected = 1
+	fmtSprint = func(a ...interface{}) string {
+		fmtSprintCalled++
+		assert.Equal(t, []interface{}{dummyField, errorPointer, dummyMessage, ""}, a)
+		return dummyResult
+	}
+
+	// SUT
+	var sut = FieldViolation{
+		Field:   dummyField,
+		Message: dummyMessage,
+	}
+
+	// act
+	var result = sut.Error()
+
+	// assert
+	assert.Equal(t, dummyResult, result)
+
+	// verify
+	verifyAll(t)
+}
+
+func TestFieldViolation_Error_WithDetails(t *testing.T) {
+	// arrange
+	var dummyField = "some field"
+	var dummyRule = "some rule"
+	var dummyMessage = "some message"
+	var dummyValue = rand.Int()
+	var dummyRuleMessage = "some rule message"
+	var dummyValueMessage = "some value message"
+	var dummyJoinedMessage = "some joined message"
+	var dummyDetailMessage = "some detail message"
+	var dummyResult = "some result"
+
+	// mock
+	createMock(t)
+
+	// expect
+	fmtSprintfExpected = 3
+	fmtSprintf = func(format string, a ...interface{}) string {
+		fmtSprintfCalled++
+		if fmtSprintfCalled == 1 {
+			assert.Equal(t, errorExtraDataFormat, format)
+			assert.Equal(t, []interface{}{"rule", dummyRule}, a)
+			return dummyRuleMessage
+		} else if fmtSprintfCalled == 2 {
+			assert.Equal(t, errorExtraDataFormat, format)
+			assert.Equal(t, []interface{}{"value", dummyValue}, a)
+			return dummyValueMessage
+		}
+		assert.Equal(t, errorJoiningFormat, format)
+		assert.Equal(t, []interface{}{dummyJoinedMessage}, a)
+		return dummyDetailMessage
+	}
+	stringsJoinExpected = 1
+	stringsJoin = func(a []string, sep string) string {
+		stringsJoinCalled++
+		assert.Equal(t, []string{dummyRuleMessage, dummyValueMessage}, a)
+		assert.Equal(t, errorSeparator, sep)
+		return dummyJoinedMessage
+	}
+	fmtSprintExpected = 1
+	fmtSprint = func(a ...interface{}) string {
+		fmtSprintCalled++
+		assert.Equal(t, []interface{}{dummyField, errorPointer, dummyMessage, dummyDetailMessage}, a)
+		return dummyResult
+	}
+
+	// SUT
+	var sut = FieldViolation{
+		Field:   dummyField,
+		Rule:    dummyRule,
+		Message: dummyMessage,
+		Value:   dummyValue,
+	}
+
+	// act
+	var result = sut.Error()
+
+	// assert
+	assert.Equal(t, dummyResult, result)
+
+	// verify
+	verifyAll(t)
+}
+
+func TestFieldViolation_Pointer_EmptyField(t *testing.T) {
+	// mock
+	createMock(t)
+
+	// SUT
+	var sut = FieldViolation{}
+
+	// act
+	var result = sut.Pointer()
+
+	// assert
+	assert.Zero(t, result)
+
+	// verify
+	verifyAll(t)
+}
+
+func TestFieldViolation_Pointer_NestedField(t *testing.T) {
+	// mock
+	createMock(t)
+
+	// SUT
+	var sut = FieldViolation{
+		Field: "items[3].price/unit.a~b",
+	}
+
+	// act
+	var result = sut.Pointer()
+
+	// assert
+	assert.Equal(t, "/items/3/price~1unit/a~0b", result)
+
+	// verify
+	verifyAll(t)
+}
+
+func TestFieldViolation_MarshalJSON(t *testing.T) {
+	// arrange
+	var dummyValue = rand.Int()
+	var dummyResult = []byte("some result")
+	var dummyError = errors.New("some error")
+
+	// mock
+	createMock(t)
+
+	// expect
+	jsonMarshalExpected = 1
+	jsonMarshal = func(v interface{}) ([]byte, error) {
+		jsonMarshalCalled++
+		assert.Equal(t, fieldViolationJSON{
+			Field:   "items[3]",
+			Pointer: "/items/3",
+			Rule:    "some rule",
+			Message: "some message",
+			Value:   dummyValue,
+		}, v)
+		return dummyResult, dummyError
+	}
+
+	// SUT
+	var sut = FieldViolation{
+		Field:   "items[3]",
+		Rule:    "some rule",
+		Message: "some message",
+		Value:   dummyValue,
+	}
+
+	// act
+	var result, err = sut.MarshalJSON()
+
+	// assert
+	assert.Equal(t, dummyResult, result)
+	assert.Equal(t, dummyError, err)
+
+	// verify
+	verifyAll(t)
+}
+
+func TestTrimNamespace_NoStructName(t *testing.T) {
+	// mock
+	createMock(t)
+
+	// SUT + act
+	var result = trimNamespace(
+		"Name",
+	)
+
+	// assert
+	assert.Equal(t, "Name", result)
+
+	// verify
+	verifyAll(t)
+}
+
+func TestTrimNamespace_WithStructName(t *testing.T) {
+	// mock
+	createMock(t)
+
+	// SUT + act
+	var result = trimNamespace(
+		"Order.Items[3].Price",
+	)
+
+	// assert
+	assert.Equal(t, "Items[3].Price", result)
+
+	// verify
+	verifyAll(t)
+}
+
+func TestJoinFieldPath(t *testing.T) {
+	// mock
+	createMock(t)
+
+	// SUT + act + assert
+	assert.Equal(t, "price", joinFieldPath("", "price"))
+	assert.Equal(t, "items", joinFieldPath("items", ""))
+	assert.Equal(t, "items[3]", joinFieldPath("items", "[3]"))
+	assert.Equal(t, "items.price", joinFieldPath("items", "price"))
+
+	// verify
+	verifyAll(t)
+}
+
+func TestConvertFieldViolations_NilError(t *testing.T) {
+	// mock
+	createMock(t)
+
+	// SUT + act
+	var result = convertFieldViolations(
+		"some prefix",
+		nil,
+	)
+
+	// assert
+	assert.Nil(t, result)
+
+	// verify
+	verifyAll(t)
+}
+
+func TestConvertFieldViolations_FieldViolation(t *testing.T) {
+	// arrange
+	var dummyPrefix = "some prefix"
+	var dummyFieldViolation = FieldViolation{
+		Field:   "some field",
+		Message: "some message",
+	}
+	var dummyPath = "some path"
+
+	// mock
+	createMock(t)
+
+	// expect
+	joinFieldPathFuncExpected = 1
+	joinFieldPathFunc = func(prefix string, field string) string {
+		joinFieldPathFuncCalled++
+		assert.Equal(t, dummyPrefix, prefix)
+		assert.Equal(t, dummyFieldViolation.Field, field)
+		return dummyPath
+	}
+
+	// SUT + act
+	var result = convertFieldViolations(
+		dummyPrefix,
+		dummyFieldViolation,
+	)
+
+	// assert
+	assert.Equal(t, []FieldViolation{{Field: dummyPath, Message: dummyFieldViolation.Message}}, result)
+
+	// verify
+	verifyAll(t)
+}
+
+func TestConvertFieldViolations_ValidatorFieldError(t *testing.T) {
+	// arrange
+	var dummyPrefix = "some prefix"
+	var dummyFieldError = dummyValidatorFieldError{
+		namespace: "some namespace",
+		tag:       "some tag",
+		value:     rand.Int(),
+	}
+	var dummyField = "some field"
+	var dummyPath = "some path"
+
+	// mock
+	createMock(t)
+
+	// expect
+	trimNamespaceFuncExpected = 1
+	trimNamespaceFunc = func(namespace string) string {
+		trimNamespaceFuncCalled++
+		assert.Equal(t, dummyFieldError.namespace, namespace)
+		return dummyField
+	}
+	joinFieldPathFuncExpected = 1
+	joinFieldPathFunc = func(prefix string, field string) string {
+		joinFieldPathFuncCalled++
+		assert.Equal(t, dummyPrefix, prefix)
+		assert.Equal(t, dummyField, field)
+		return dummyPath
+	}
+
+	// SUT + act
+	var result = convertFieldViolations(
+		dummyPrefix,
+		dummyFieldError,
+	)
+
+	// assert
+	assert.Equal(t, []FieldViolation{{
+		Field:   dummyPath,
+		Rule:    dummyFieldError.tag,
+		Message: dummyFieldError.Error(),
+		Value:   dummyFieldError.value,
+		Cause:   dummyFieldError,
+	}}, result)
+
+	// verify
+	verifyAll(t)
+}
+
+func TestConvertFieldViolations_PlainError(t *testing.T) {
+	// arrange
+	var dummyPrefix = "some prefix"
+	var dummyError = errors.New("some error")
+
+	// mock
+	createMock(t)
+
+	// SUT + act
+	var result = convertFieldViolations(
+		dummyPrefix,
+		dummyError,
+	)
+
+	// assert
+	assert.Equal(t, []FieldViolation{{Field: dummyPrefix, Message: dummyError.Error(), Cause: dummyError}}, result)
+
+	// verify
+	verifyAll(t)
+}
+
+func TestNewFieldViolations_ValidatorErrors(t *testing.T) {
+	// arrange
+	var dummyErrors = dummyValidatorErrors{
+		{namespace: "Order.Items[3].Price", tag: "gt", value: -1},
+		{namespace: "Order.Name", tag: "required"},
+	}
+
+	// SUT + act
+	var result = NewFieldViolations(
+		dummyErrors,
+	)
+
+	// assert
+	assert.Equal(t, []FieldViolation{
+		{Field: "Items[3].Price", Rule: "gt", Message: "some validator error", Value: -1, Cause: dummyErrors[0]},
+		{Field: "Name", Rule: "required", Message: "some validator error", Cause: dummyErrors[1]},
+	}, result)
+}
+
+func TestNewFieldViolations_FieldErrorMap(t *testing.T) {
+	// arrange
+	var dummyNameError = errors.New("cannot be blank")
+	var dummyZipError = errors.New("must be 5 digits")
+	var dummyIndexedError = errors.New("some indexed error")
+	var dummyErrors = dummyFieldErrorMap{
+		"name": dummyNameError,
+		"address": dummyFieldErrorMap{
+			"zip": dummyZipError,
+		},
+		"[0]": dummyIndexedError,
+	}
+
+	// SUT + act
+	var result = NewFieldViolations(
+		dummyErrors,
+	)
+
+	// assert
+	assert.Equal(t, []FieldViolation{
+		{Field: "[0]", Message: "some indexed error", Cause: dummyIndexedError},
+		{Field: "address.zip", Message: "must be 5 digits", Cause: dummyZipError},
+		{Field: "name", Message: "cannot be blank", Cause: dummyNameError},
+	}, result)
+}
+
+func TestCollector_AddField_ValidatorErrors(t *testing.T) {
+	// arrange
+	var collector = NewCollector()
+
+	// act
+	collector.AddField("items[3]", dummyValidatorErrors{
+		{namespace: "Item.Price", tag: "gt", value: -1},
+	})
+	var result = collector.Build(CodeBadRequest, "Request body is invalid")
+
+	// assert
+	assert.Equal(t, "(BadRequest) Request body is invalid [ items[3].Price : some validator error [ rule = gt | value = -1 ] ]", result.Error())
+}
+
+func TestFieldViolation_Unwrap(t *testing.T) {
+	// arrange
+	var dummyCause = errors.New("some cause")
+
+	// assert
+	assert.Nil(t, FieldViolation{}.Unwrap())
+	assert.Equal(t, dummyCause, FieldViolation{Cause: dummyCause}.Unwrap())
+}
+
+func TestCollector_AddField_KeepsCause(t *testing.T) {
+	// arrange
+	var collector = NewCollector()
+
+	// act
+	collector.AddField("items[0]", GetNotFoundError())
+	var result = collector.Build(CodeBadRequest, "Request body is invalid")
+
+	// assert
+	assert.True(t, errors.Is(result, ErrNotFound))
+	assert.True(t, errors.Is(result, ErrBadRequest))
+	assert.Equal(t, "(BadRequest) Request body is invalid [ items[0] : (NotFound) Requested resource is not found in the storage ]", result.Error())
+}